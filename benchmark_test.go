@@ -0,0 +1,107 @@
+package strval
+
+import "testing"
+
+// The options are built once, outside the timed loops, the same way callers are expected to build them
+var benchmarkOptions = []struct {
+	name    string
+	option  StringValidationOption
+	valid   string
+	invalid string
+}{
+	{"MustHaveMinLengthOf", MustHaveMinLengthOf(8), "correct horse battery", "short"},
+	{"MustHaveMaxLengthOf", MustHaveMaxLengthOf(8), "short", "correct horse battery"},
+	{"MustNotBeEmpty", MustNotBeEmpty(), "   value", "   \t\n"},
+	{"MustBeAlphaNumeric", MustBeAlphaNumeric(), "Username1234567890", "user_name-1234567890"},
+	{"MustContainNumbers", MustContainNumbers(), "correcthorse7", "correcthorse"},
+	{"MustContainAtLeastOne", MustContainAtLeastOne([]rune("!@#$%")), "correcthorse!", "correcthorse"},
+	{"MustNotContainAnyOf", MustNotContainAnyOf([]rune("<>&\"'")), "plain text value", "<script>"},
+	{"MustContainUppercaseLetter", MustContainUppercaseLetter(), "correcthorseB", "correcthorse"},
+	{"MustContainLowercaseLetter", MustContainLowercaseLetter(), "CORRECTHORSEb", "CORRECTHORSE"},
+	{"MustOnlyContainPrintableCharacters", MustOnlyContainPrintableCharacters(), "printable text", "bell\a"},
+	{"MustOnlyContainASCIICharacters", MustOnlyContainASCIICharacters(), "ascii text", "naïve"},
+	{"MustBeValidEmailFormat", MustBeValidEmailFormat(), "first.last+tag@example.co.uk", "first.last@example"},
+}
+
+// Benchmarks every built-in StringValidationOption against a valid and an invalid input
+func BenchmarkOptions(b *testing.B) {
+	for _, bm := range benchmarkOptions {
+		bm := bm
+
+		b.Run(bm.name+"/valid", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = bm.option(bm.valid, "field")
+			}
+		})
+
+		b.Run(bm.name+"/invalid", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = bm.option(bm.invalid, "field")
+			}
+		})
+	}
+}
+
+// Benchmarks ValidateStringWithName with a typical set of password options
+func BenchmarkValidateStringWithName(b *testing.B) {
+	options := []StringValidationOption{
+		MustNotBeEmpty(),
+		MustHaveMinLengthOf(8),
+		MustHaveMaxLengthOf(64),
+		MustContainNumbers(),
+		MustContainUppercaseLetter(),
+		MustContainLowercaseLetter(),
+		MustContainAtLeastOne([]rune("!@#$%")),
+		MustOnlyContainASCIICharacters(),
+	}
+
+	b.Run("valid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = ValidateStringWithName("Correct-Horse-7!", "password", options...)
+		}
+	})
+
+	b.Run("invalid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = ValidateStringWithName("", "password", options...)
+		}
+	})
+}
+
+// Tests that validating a valid string with prebuilt options does not allocate
+func TestValidStringDoesNotAllocate(t *testing.T) {
+	for _, bm := range benchmarkOptions {
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = bm.option(bm.valid, "field")
+		})
+
+		if allocs != 0 {
+			t.Errorf("%s allocated %v times for a valid string, want 0", bm.name, allocs)
+		}
+	}
+
+	options := []StringValidationOption{
+		MustNotBeEmpty(),
+		MustHaveMinLengthOf(8),
+		MustHaveMaxLengthOf(64),
+		MustContainNumbers(),
+		MustContainUppercaseLetter(),
+		MustContainLowercaseLetter(),
+		MustContainAtLeastOne([]rune("!@#$%")),
+		MustNotContainAnyOf([]rune("<>")),
+		MustOnlyContainPrintableCharacters(),
+		MustOnlyContainASCIICharacters(),
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = ValidateStringWithName("Correct7Horse!b", "password", options...)
+	})
+
+	if allocs != 0 {
+		t.Errorf("ValidateStringWithName() allocated %v times for a valid string, want 0", allocs)
+	}
+}
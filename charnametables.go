@@ -0,0 +1,791 @@
+// Code generated by gen_charnames.go. DO NOT EDIT.
+
+package strval
+
+// characterNames holds the names of the invisible characters, separators, Latin-1 and General Punctuation characters,
+// taken from UnicodeData.txt and NameAliases.txt of Unicode 15.0.0. Controls are named with their control alias.
+// See https://www.unicode.org/license.html for the Unicode license agreement.
+var characterNames = [...]characterName{
+	{0x0000, "NULL"},
+	{0x0001, "START OF HEADING"},
+	{0x0002, "START OF TEXT"},
+	{0x0003, "END OF TEXT"},
+	{0x0004, "END OF TRANSMISSION"},
+	{0x0005, "ENQUIRY"},
+	{0x0006, "ACKNOWLEDGE"},
+	{0x0007, "ALERT"},
+	{0x0008, "BACKSPACE"},
+	{0x0009, "CHARACTER TABULATION"},
+	{0x000A, "LINE FEED"},
+	{0x000B, "LINE TABULATION"},
+	{0x000C, "FORM FEED"},
+	{0x000D, "CARRIAGE RETURN"},
+	{0x000E, "SHIFT OUT"},
+	{0x000F, "SHIFT IN"},
+	{0x0010, "DATA LINK ESCAPE"},
+	{0x0011, "DEVICE CONTROL ONE"},
+	{0x0012, "DEVICE CONTROL TWO"},
+	{0x0013, "DEVICE CONTROL THREE"},
+	{0x0014, "DEVICE CONTROL FOUR"},
+	{0x0015, "NEGATIVE ACKNOWLEDGE"},
+	{0x0016, "SYNCHRONOUS IDLE"},
+	{0x0017, "END OF TRANSMISSION BLOCK"},
+	{0x0018, "CANCEL"},
+	{0x0019, "END OF MEDIUM"},
+	{0x001A, "SUBSTITUTE"},
+	{0x001B, "ESCAPE"},
+	{0x001C, "INFORMATION SEPARATOR FOUR"},
+	{0x001D, "INFORMATION SEPARATOR THREE"},
+	{0x001E, "INFORMATION SEPARATOR TWO"},
+	{0x001F, "INFORMATION SEPARATOR ONE"},
+	{0x0020, "SPACE"},
+	{0x0021, "EXCLAMATION MARK"},
+	{0x0022, "QUOTATION MARK"},
+	{0x0023, "NUMBER SIGN"},
+	{0x0024, "DOLLAR SIGN"},
+	{0x0025, "PERCENT SIGN"},
+	{0x0026, "AMPERSAND"},
+	{0x0027, "APOSTROPHE"},
+	{0x0028, "LEFT PARENTHESIS"},
+	{0x0029, "RIGHT PARENTHESIS"},
+	{0x002A, "ASTERISK"},
+	{0x002B, "PLUS SIGN"},
+	{0x002C, "COMMA"},
+	{0x002D, "HYPHEN-MINUS"},
+	{0x002E, "FULL STOP"},
+	{0x002F, "SOLIDUS"},
+	{0x0030, "DIGIT ZERO"},
+	{0x0031, "DIGIT ONE"},
+	{0x0032, "DIGIT TWO"},
+	{0x0033, "DIGIT THREE"},
+	{0x0034, "DIGIT FOUR"},
+	{0x0035, "DIGIT FIVE"},
+	{0x0036, "DIGIT SIX"},
+	{0x0037, "DIGIT SEVEN"},
+	{0x0038, "DIGIT EIGHT"},
+	{0x0039, "DIGIT NINE"},
+	{0x003A, "COLON"},
+	{0x003B, "SEMICOLON"},
+	{0x003C, "LESS-THAN SIGN"},
+	{0x003D, "EQUALS SIGN"},
+	{0x003E, "GREATER-THAN SIGN"},
+	{0x003F, "QUESTION MARK"},
+	{0x0040, "COMMERCIAL AT"},
+	{0x0041, "LATIN CAPITAL LETTER A"},
+	{0x0042, "LATIN CAPITAL LETTER B"},
+	{0x0043, "LATIN CAPITAL LETTER C"},
+	{0x0044, "LATIN CAPITAL LETTER D"},
+	{0x0045, "LATIN CAPITAL LETTER E"},
+	{0x0046, "LATIN CAPITAL LETTER F"},
+	{0x0047, "LATIN CAPITAL LETTER G"},
+	{0x0048, "LATIN CAPITAL LETTER H"},
+	{0x0049, "LATIN CAPITAL LETTER I"},
+	{0x004A, "LATIN CAPITAL LETTER J"},
+	{0x004B, "LATIN CAPITAL LETTER K"},
+	{0x004C, "LATIN CAPITAL LETTER L"},
+	{0x004D, "LATIN CAPITAL LETTER M"},
+	{0x004E, "LATIN CAPITAL LETTER N"},
+	{0x004F, "LATIN CAPITAL LETTER O"},
+	{0x0050, "LATIN CAPITAL LETTER P"},
+	{0x0051, "LATIN CAPITAL LETTER Q"},
+	{0x0052, "LATIN CAPITAL LETTER R"},
+	{0x0053, "LATIN CAPITAL LETTER S"},
+	{0x0054, "LATIN CAPITAL LETTER T"},
+	{0x0055, "LATIN CAPITAL LETTER U"},
+	{0x0056, "LATIN CAPITAL LETTER V"},
+	{0x0057, "LATIN CAPITAL LETTER W"},
+	{0x0058, "LATIN CAPITAL LETTER X"},
+	{0x0059, "LATIN CAPITAL LETTER Y"},
+	{0x005A, "LATIN CAPITAL LETTER Z"},
+	{0x005B, "LEFT SQUARE BRACKET"},
+	{0x005C, "REVERSE SOLIDUS"},
+	{0x005D, "RIGHT SQUARE BRACKET"},
+	{0x005E, "CIRCUMFLEX ACCENT"},
+	{0x005F, "LOW LINE"},
+	{0x0060, "GRAVE ACCENT"},
+	{0x0061, "LATIN SMALL LETTER A"},
+	{0x0062, "LATIN SMALL LETTER B"},
+	{0x0063, "LATIN SMALL LETTER C"},
+	{0x0064, "LATIN SMALL LETTER D"},
+	{0x0065, "LATIN SMALL LETTER E"},
+	{0x0066, "LATIN SMALL LETTER F"},
+	{0x0067, "LATIN SMALL LETTER G"},
+	{0x0068, "LATIN SMALL LETTER H"},
+	{0x0069, "LATIN SMALL LETTER I"},
+	{0x006A, "LATIN SMALL LETTER J"},
+	{0x006B, "LATIN SMALL LETTER K"},
+	{0x006C, "LATIN SMALL LETTER L"},
+	{0x006D, "LATIN SMALL LETTER M"},
+	{0x006E, "LATIN SMALL LETTER N"},
+	{0x006F, "LATIN SMALL LETTER O"},
+	{0x0070, "LATIN SMALL LETTER P"},
+	{0x0071, "LATIN SMALL LETTER Q"},
+	{0x0072, "LATIN SMALL LETTER R"},
+	{0x0073, "LATIN SMALL LETTER S"},
+	{0x0074, "LATIN SMALL LETTER T"},
+	{0x0075, "LATIN SMALL LETTER U"},
+	{0x0076, "LATIN SMALL LETTER V"},
+	{0x0077, "LATIN SMALL LETTER W"},
+	{0x0078, "LATIN SMALL LETTER X"},
+	{0x0079, "LATIN SMALL LETTER Y"},
+	{0x007A, "LATIN SMALL LETTER Z"},
+	{0x007B, "LEFT CURLY BRACKET"},
+	{0x007C, "VERTICAL LINE"},
+	{0x007D, "RIGHT CURLY BRACKET"},
+	{0x007E, "TILDE"},
+	{0x007F, "DELETE"},
+	{0x0080, "PADDING CHARACTER"},
+	{0x0081, "HIGH OCTET PRESET"},
+	{0x0082, "BREAK PERMITTED HERE"},
+	{0x0083, "NO BREAK HERE"},
+	{0x0084, "INDEX"},
+	{0x0085, "NEXT LINE"},
+	{0x0086, "START OF SELECTED AREA"},
+	{0x0087, "END OF SELECTED AREA"},
+	{0x0088, "CHARACTER TABULATION SET"},
+	{0x0089, "CHARACTER TABULATION WITH JUSTIFICATION"},
+	{0x008A, "LINE TABULATION SET"},
+	{0x008B, "PARTIAL LINE FORWARD"},
+	{0x008C, "PARTIAL LINE BACKWARD"},
+	{0x008D, "REVERSE LINE FEED"},
+	{0x008E, "SINGLE SHIFT TWO"},
+	{0x008F, "SINGLE SHIFT THREE"},
+	{0x0090, "DEVICE CONTROL STRING"},
+	{0x0091, "PRIVATE USE ONE"},
+	{0x0092, "PRIVATE USE TWO"},
+	{0x0093, "SET TRANSMIT STATE"},
+	{0x0094, "CANCEL CHARACTER"},
+	{0x0095, "MESSAGE WAITING"},
+	{0x0096, "START OF GUARDED AREA"},
+	{0x0097, "END OF GUARDED AREA"},
+	{0x0098, "START OF STRING"},
+	{0x0099, "SINGLE GRAPHIC CHARACTER INTRODUCER"},
+	{0x009A, "SINGLE CHARACTER INTRODUCER"},
+	{0x009B, "CONTROL SEQUENCE INTRODUCER"},
+	{0x009C, "STRING TERMINATOR"},
+	{0x009D, "OPERATING SYSTEM COMMAND"},
+	{0x009E, "PRIVACY MESSAGE"},
+	{0x009F, "APPLICATION PROGRAM COMMAND"},
+	{0x00A0, "NO-BREAK SPACE"},
+	{0x00A1, "INVERTED EXCLAMATION MARK"},
+	{0x00A2, "CENT SIGN"},
+	{0x00A3, "POUND SIGN"},
+	{0x00A4, "CURRENCY SIGN"},
+	{0x00A5, "YEN SIGN"},
+	{0x00A6, "BROKEN BAR"},
+	{0x00A7, "SECTION SIGN"},
+	{0x00A8, "DIAERESIS"},
+	{0x00A9, "COPYRIGHT SIGN"},
+	{0x00AA, "FEMININE ORDINAL INDICATOR"},
+	{0x00AB, "LEFT-POINTING DOUBLE ANGLE QUOTATION MARK"},
+	{0x00AC, "NOT SIGN"},
+	{0x00AD, "SOFT HYPHEN"},
+	{0x00AE, "REGISTERED SIGN"},
+	{0x00AF, "MACRON"},
+	{0x00B0, "DEGREE SIGN"},
+	{0x00B1, "PLUS-MINUS SIGN"},
+	{0x00B2, "SUPERSCRIPT TWO"},
+	{0x00B3, "SUPERSCRIPT THREE"},
+	{0x00B4, "ACUTE ACCENT"},
+	{0x00B5, "MICRO SIGN"},
+	{0x00B6, "PILCROW SIGN"},
+	{0x00B7, "MIDDLE DOT"},
+	{0x00B8, "CEDILLA"},
+	{0x00B9, "SUPERSCRIPT ONE"},
+	{0x00BA, "MASCULINE ORDINAL INDICATOR"},
+	{0x00BB, "RIGHT-POINTING DOUBLE ANGLE QUOTATION MARK"},
+	{0x00BC, "VULGAR FRACTION ONE QUARTER"},
+	{0x00BD, "VULGAR FRACTION ONE HALF"},
+	{0x00BE, "VULGAR FRACTION THREE QUARTERS"},
+	{0x00BF, "INVERTED QUESTION MARK"},
+	{0x00C0, "LATIN CAPITAL LETTER A WITH GRAVE"},
+	{0x00C1, "LATIN CAPITAL LETTER A WITH ACUTE"},
+	{0x00C2, "LATIN CAPITAL LETTER A WITH CIRCUMFLEX"},
+	{0x00C3, "LATIN CAPITAL LETTER A WITH TILDE"},
+	{0x00C4, "LATIN CAPITAL LETTER A WITH DIAERESIS"},
+	{0x00C5, "LATIN CAPITAL LETTER A WITH RING ABOVE"},
+	{0x00C6, "LATIN CAPITAL LETTER AE"},
+	{0x00C7, "LATIN CAPITAL LETTER C WITH CEDILLA"},
+	{0x00C8, "LATIN CAPITAL LETTER E WITH GRAVE"},
+	{0x00C9, "LATIN CAPITAL LETTER E WITH ACUTE"},
+	{0x00CA, "LATIN CAPITAL LETTER E WITH CIRCUMFLEX"},
+	{0x00CB, "LATIN CAPITAL LETTER E WITH DIAERESIS"},
+	{0x00CC, "LATIN CAPITAL LETTER I WITH GRAVE"},
+	{0x00CD, "LATIN CAPITAL LETTER I WITH ACUTE"},
+	{0x00CE, "LATIN CAPITAL LETTER I WITH CIRCUMFLEX"},
+	{0x00CF, "LATIN CAPITAL LETTER I WITH DIAERESIS"},
+	{0x00D0, "LATIN CAPITAL LETTER ETH"},
+	{0x00D1, "LATIN CAPITAL LETTER N WITH TILDE"},
+	{0x00D2, "LATIN CAPITAL LETTER O WITH GRAVE"},
+	{0x00D3, "LATIN CAPITAL LETTER O WITH ACUTE"},
+	{0x00D4, "LATIN CAPITAL LETTER O WITH CIRCUMFLEX"},
+	{0x00D5, "LATIN CAPITAL LETTER O WITH TILDE"},
+	{0x00D6, "LATIN CAPITAL LETTER O WITH DIAERESIS"},
+	{0x00D7, "MULTIPLICATION SIGN"},
+	{0x00D8, "LATIN CAPITAL LETTER O WITH STROKE"},
+	{0x00D9, "LATIN CAPITAL LETTER U WITH GRAVE"},
+	{0x00DA, "LATIN CAPITAL LETTER U WITH ACUTE"},
+	{0x00DB, "LATIN CAPITAL LETTER U WITH CIRCUMFLEX"},
+	{0x00DC, "LATIN CAPITAL LETTER U WITH DIAERESIS"},
+	{0x00DD, "LATIN CAPITAL LETTER Y WITH ACUTE"},
+	{0x00DE, "LATIN CAPITAL LETTER THORN"},
+	{0x00DF, "LATIN SMALL LETTER SHARP S"},
+	{0x00E0, "LATIN SMALL LETTER A WITH GRAVE"},
+	{0x00E1, "LATIN SMALL LETTER A WITH ACUTE"},
+	{0x00E2, "LATIN SMALL LETTER A WITH CIRCUMFLEX"},
+	{0x00E3, "LATIN SMALL LETTER A WITH TILDE"},
+	{0x00E4, "LATIN SMALL LETTER A WITH DIAERESIS"},
+	{0x00E5, "LATIN SMALL LETTER A WITH RING ABOVE"},
+	{0x00E6, "LATIN SMALL LETTER AE"},
+	{0x00E7, "LATIN SMALL LETTER C WITH CEDILLA"},
+	{0x00E8, "LATIN SMALL LETTER E WITH GRAVE"},
+	{0x00E9, "LATIN SMALL LETTER E WITH ACUTE"},
+	{0x00EA, "LATIN SMALL LETTER E WITH CIRCUMFLEX"},
+	{0x00EB, "LATIN SMALL LETTER E WITH DIAERESIS"},
+	{0x00EC, "LATIN SMALL LETTER I WITH GRAVE"},
+	{0x00ED, "LATIN SMALL LETTER I WITH ACUTE"},
+	{0x00EE, "LATIN SMALL LETTER I WITH CIRCUMFLEX"},
+	{0x00EF, "LATIN SMALL LETTER I WITH DIAERESIS"},
+	{0x00F0, "LATIN SMALL LETTER ETH"},
+	{0x00F1, "LATIN SMALL LETTER N WITH TILDE"},
+	{0x00F2, "LATIN SMALL LETTER O WITH GRAVE"},
+	{0x00F3, "LATIN SMALL LETTER O WITH ACUTE"},
+	{0x00F4, "LATIN SMALL LETTER O WITH CIRCUMFLEX"},
+	{0x00F5, "LATIN SMALL LETTER O WITH TILDE"},
+	{0x00F6, "LATIN SMALL LETTER O WITH DIAERESIS"},
+	{0x00F7, "DIVISION SIGN"},
+	{0x00F8, "LATIN SMALL LETTER O WITH STROKE"},
+	{0x00F9, "LATIN SMALL LETTER U WITH GRAVE"},
+	{0x00FA, "LATIN SMALL LETTER U WITH ACUTE"},
+	{0x00FB, "LATIN SMALL LETTER U WITH CIRCUMFLEX"},
+	{0x00FC, "LATIN SMALL LETTER U WITH DIAERESIS"},
+	{0x00FD, "LATIN SMALL LETTER Y WITH ACUTE"},
+	{0x00FE, "LATIN SMALL LETTER THORN"},
+	{0x00FF, "LATIN SMALL LETTER Y WITH DIAERESIS"},
+	{0x034F, "COMBINING GRAPHEME JOINER"},
+	{0x0600, "ARABIC NUMBER SIGN"},
+	{0x0601, "ARABIC SIGN SANAH"},
+	{0x0602, "ARABIC FOOTNOTE MARKER"},
+	{0x0603, "ARABIC SIGN SAFHA"},
+	{0x0604, "ARABIC SIGN SAMVAT"},
+	{0x0605, "ARABIC NUMBER MARK ABOVE"},
+	{0x061C, "ARABIC LETTER MARK"},
+	{0x06DD, "ARABIC END OF AYAH"},
+	{0x070F, "SYRIAC ABBREVIATION MARK"},
+	{0x0890, "ARABIC POUND MARK ABOVE"},
+	{0x0891, "ARABIC PIASTRE MARK ABOVE"},
+	{0x08E2, "ARABIC DISPUTED END OF AYAH"},
+	{0x115F, "HANGUL CHOSEONG FILLER"},
+	{0x1160, "HANGUL JUNGSEONG FILLER"},
+	{0x1680, "OGHAM SPACE MARK"},
+	{0x17B4, "KHMER VOWEL INHERENT AQ"},
+	{0x17B5, "KHMER VOWEL INHERENT AA"},
+	{0x180B, "MONGOLIAN FREE VARIATION SELECTOR ONE"},
+	{0x180C, "MONGOLIAN FREE VARIATION SELECTOR TWO"},
+	{0x180D, "MONGOLIAN FREE VARIATION SELECTOR THREE"},
+	{0x180E, "MONGOLIAN VOWEL SEPARATOR"},
+	{0x180F, "MONGOLIAN FREE VARIATION SELECTOR FOUR"},
+	{0x2000, "EN QUAD"},
+	{0x2001, "EM QUAD"},
+	{0x2002, "EN SPACE"},
+	{0x2003, "EM SPACE"},
+	{0x2004, "THREE-PER-EM SPACE"},
+	{0x2005, "FOUR-PER-EM SPACE"},
+	{0x2006, "SIX-PER-EM SPACE"},
+	{0x2007, "FIGURE SPACE"},
+	{0x2008, "PUNCTUATION SPACE"},
+	{0x2009, "THIN SPACE"},
+	{0x200A, "HAIR SPACE"},
+	{0x200B, "ZERO WIDTH SPACE"},
+	{0x200C, "ZERO WIDTH NON-JOINER"},
+	{0x200D, "ZERO WIDTH JOINER"},
+	{0x200E, "LEFT-TO-RIGHT MARK"},
+	{0x200F, "RIGHT-TO-LEFT MARK"},
+	{0x2010, "HYPHEN"},
+	{0x2011, "NON-BREAKING HYPHEN"},
+	{0x2012, "FIGURE DASH"},
+	{0x2013, "EN DASH"},
+	{0x2014, "EM DASH"},
+	{0x2015, "HORIZONTAL BAR"},
+	{0x2016, "DOUBLE VERTICAL LINE"},
+	{0x2017, "DOUBLE LOW LINE"},
+	{0x2018, "LEFT SINGLE QUOTATION MARK"},
+	{0x2019, "RIGHT SINGLE QUOTATION MARK"},
+	{0x201A, "SINGLE LOW-9 QUOTATION MARK"},
+	{0x201B, "SINGLE HIGH-REVERSED-9 QUOTATION MARK"},
+	{0x201C, "LEFT DOUBLE QUOTATION MARK"},
+	{0x201D, "RIGHT DOUBLE QUOTATION MARK"},
+	{0x201E, "DOUBLE LOW-9 QUOTATION MARK"},
+	{0x201F, "DOUBLE HIGH-REVERSED-9 QUOTATION MARK"},
+	{0x2020, "DAGGER"},
+	{0x2021, "DOUBLE DAGGER"},
+	{0x2022, "BULLET"},
+	{0x2023, "TRIANGULAR BULLET"},
+	{0x2024, "ONE DOT LEADER"},
+	{0x2025, "TWO DOT LEADER"},
+	{0x2026, "HORIZONTAL ELLIPSIS"},
+	{0x2027, "HYPHENATION POINT"},
+	{0x2028, "LINE SEPARATOR"},
+	{0x2029, "PARAGRAPH SEPARATOR"},
+	{0x202A, "LEFT-TO-RIGHT EMBEDDING"},
+	{0x202B, "RIGHT-TO-LEFT EMBEDDING"},
+	{0x202C, "POP DIRECTIONAL FORMATTING"},
+	{0x202D, "LEFT-TO-RIGHT OVERRIDE"},
+	{0x202E, "RIGHT-TO-LEFT OVERRIDE"},
+	{0x202F, "NARROW NO-BREAK SPACE"},
+	{0x2030, "PER MILLE SIGN"},
+	{0x2031, "PER TEN THOUSAND SIGN"},
+	{0x2032, "PRIME"},
+	{0x2033, "DOUBLE PRIME"},
+	{0x2034, "TRIPLE PRIME"},
+	{0x2035, "REVERSED PRIME"},
+	{0x2036, "REVERSED DOUBLE PRIME"},
+	{0x2037, "REVERSED TRIPLE PRIME"},
+	{0x2038, "CARET"},
+	{0x2039, "SINGLE LEFT-POINTING ANGLE QUOTATION MARK"},
+	{0x203A, "SINGLE RIGHT-POINTING ANGLE QUOTATION MARK"},
+	{0x203B, "REFERENCE MARK"},
+	{0x203C, "DOUBLE EXCLAMATION MARK"},
+	{0x203D, "INTERROBANG"},
+	{0x203E, "OVERLINE"},
+	{0x203F, "UNDERTIE"},
+	{0x2040, "CHARACTER TIE"},
+	{0x2041, "CARET INSERTION POINT"},
+	{0x2042, "ASTERISM"},
+	{0x2043, "HYPHEN BULLET"},
+	{0x2044, "FRACTION SLASH"},
+	{0x2045, "LEFT SQUARE BRACKET WITH QUILL"},
+	{0x2046, "RIGHT SQUARE BRACKET WITH QUILL"},
+	{0x2047, "DOUBLE QUESTION MARK"},
+	{0x2048, "QUESTION EXCLAMATION MARK"},
+	{0x2049, "EXCLAMATION QUESTION MARK"},
+	{0x204A, "TIRONIAN SIGN ET"},
+	{0x204B, "REVERSED PILCROW SIGN"},
+	{0x204C, "BLACK LEFTWARDS BULLET"},
+	{0x204D, "BLACK RIGHTWARDS BULLET"},
+	{0x204E, "LOW ASTERISK"},
+	{0x204F, "REVERSED SEMICOLON"},
+	{0x2050, "CLOSE UP"},
+	{0x2051, "TWO ASTERISKS ALIGNED VERTICALLY"},
+	{0x2052, "COMMERCIAL MINUS SIGN"},
+	{0x2053, "SWUNG DASH"},
+	{0x2054, "INVERTED UNDERTIE"},
+	{0x2055, "FLOWER PUNCTUATION MARK"},
+	{0x2056, "THREE DOT PUNCTUATION"},
+	{0x2057, "QUADRUPLE PRIME"},
+	{0x2058, "FOUR DOT PUNCTUATION"},
+	{0x2059, "FIVE DOT PUNCTUATION"},
+	{0x205A, "TWO DOT PUNCTUATION"},
+	{0x205B, "FOUR DOT MARK"},
+	{0x205C, "DOTTED CROSS"},
+	{0x205D, "TRICOLON"},
+	{0x205E, "VERTICAL FOUR DOTS"},
+	{0x205F, "MEDIUM MATHEMATICAL SPACE"},
+	{0x2060, "WORD JOINER"},
+	{0x2061, "FUNCTION APPLICATION"},
+	{0x2062, "INVISIBLE TIMES"},
+	{0x2063, "INVISIBLE SEPARATOR"},
+	{0x2064, "INVISIBLE PLUS"},
+	{0x2066, "LEFT-TO-RIGHT ISOLATE"},
+	{0x2067, "RIGHT-TO-LEFT ISOLATE"},
+	{0x2068, "FIRST STRONG ISOLATE"},
+	{0x2069, "POP DIRECTIONAL ISOLATE"},
+	{0x206A, "INHIBIT SYMMETRIC SWAPPING"},
+	{0x206B, "ACTIVATE SYMMETRIC SWAPPING"},
+	{0x206C, "INHIBIT ARABIC FORM SHAPING"},
+	{0x206D, "ACTIVATE ARABIC FORM SHAPING"},
+	{0x206E, "NATIONAL DIGIT SHAPES"},
+	{0x206F, "NOMINAL DIGIT SHAPES"},
+	{0x3000, "IDEOGRAPHIC SPACE"},
+	{0x3164, "HANGUL FILLER"},
+	{0xFE00, "VARIATION SELECTOR-1"},
+	{0xFE01, "VARIATION SELECTOR-2"},
+	{0xFE02, "VARIATION SELECTOR-3"},
+	{0xFE03, "VARIATION SELECTOR-4"},
+	{0xFE04, "VARIATION SELECTOR-5"},
+	{0xFE05, "VARIATION SELECTOR-6"},
+	{0xFE06, "VARIATION SELECTOR-7"},
+	{0xFE07, "VARIATION SELECTOR-8"},
+	{0xFE08, "VARIATION SELECTOR-9"},
+	{0xFE09, "VARIATION SELECTOR-10"},
+	{0xFE0A, "VARIATION SELECTOR-11"},
+	{0xFE0B, "VARIATION SELECTOR-12"},
+	{0xFE0C, "VARIATION SELECTOR-13"},
+	{0xFE0D, "VARIATION SELECTOR-14"},
+	{0xFE0E, "VARIATION SELECTOR-15"},
+	{0xFE0F, "VARIATION SELECTOR-16"},
+	{0xFEFF, "ZERO WIDTH NO-BREAK SPACE"},
+	{0xFFA0, "HALFWIDTH HANGUL FILLER"},
+	{0xFFF9, "INTERLINEAR ANNOTATION ANCHOR"},
+	{0xFFFA, "INTERLINEAR ANNOTATION SEPARATOR"},
+	{0xFFFB, "INTERLINEAR ANNOTATION TERMINATOR"},
+	{0xFFFC, "OBJECT REPLACEMENT CHARACTER"},
+	{0xFFFD, "REPLACEMENT CHARACTER"},
+	{0x110BD, "KAITHI NUMBER SIGN"},
+	{0x110CD, "KAITHI NUMBER SIGN ABOVE"},
+	{0x13430, "EGYPTIAN HIEROGLYPH VERTICAL JOINER"},
+	{0x13431, "EGYPTIAN HIEROGLYPH HORIZONTAL JOINER"},
+	{0x13432, "EGYPTIAN HIEROGLYPH INSERT AT TOP START"},
+	{0x13433, "EGYPTIAN HIEROGLYPH INSERT AT BOTTOM START"},
+	{0x13434, "EGYPTIAN HIEROGLYPH INSERT AT TOP END"},
+	{0x13435, "EGYPTIAN HIEROGLYPH INSERT AT BOTTOM END"},
+	{0x13436, "EGYPTIAN HIEROGLYPH OVERLAY MIDDLE"},
+	{0x13437, "EGYPTIAN HIEROGLYPH BEGIN SEGMENT"},
+	{0x13438, "EGYPTIAN HIEROGLYPH END SEGMENT"},
+	{0x13439, "EGYPTIAN HIEROGLYPH INSERT AT MIDDLE"},
+	{0x1343A, "EGYPTIAN HIEROGLYPH INSERT AT TOP"},
+	{0x1343B, "EGYPTIAN HIEROGLYPH INSERT AT BOTTOM"},
+	{0x1343C, "EGYPTIAN HIEROGLYPH BEGIN ENCLOSURE"},
+	{0x1343D, "EGYPTIAN HIEROGLYPH END ENCLOSURE"},
+	{0x1343E, "EGYPTIAN HIEROGLYPH BEGIN WALLED ENCLOSURE"},
+	{0x1343F, "EGYPTIAN HIEROGLYPH END WALLED ENCLOSURE"},
+	{0x1BCA0, "SHORTHAND FORMAT LETTER OVERLAP"},
+	{0x1BCA1, "SHORTHAND FORMAT CONTINUING OVERLAP"},
+	{0x1BCA2, "SHORTHAND FORMAT DOWN STEP"},
+	{0x1BCA3, "SHORTHAND FORMAT UP STEP"},
+	{0x1D173, "MUSICAL SYMBOL BEGIN BEAM"},
+	{0x1D174, "MUSICAL SYMBOL END BEAM"},
+	{0x1D175, "MUSICAL SYMBOL BEGIN TIE"},
+	{0x1D176, "MUSICAL SYMBOL END TIE"},
+	{0x1D177, "MUSICAL SYMBOL BEGIN SLUR"},
+	{0x1D178, "MUSICAL SYMBOL END SLUR"},
+	{0x1D179, "MUSICAL SYMBOL BEGIN PHRASE"},
+	{0x1D17A, "MUSICAL SYMBOL END PHRASE"},
+	{0xE0001, "LANGUAGE TAG"},
+	{0xE0020, "TAG SPACE"},
+	{0xE0021, "TAG EXCLAMATION MARK"},
+	{0xE0022, "TAG QUOTATION MARK"},
+	{0xE0023, "TAG NUMBER SIGN"},
+	{0xE0024, "TAG DOLLAR SIGN"},
+	{0xE0025, "TAG PERCENT SIGN"},
+	{0xE0026, "TAG AMPERSAND"},
+	{0xE0027, "TAG APOSTROPHE"},
+	{0xE0028, "TAG LEFT PARENTHESIS"},
+	{0xE0029, "TAG RIGHT PARENTHESIS"},
+	{0xE002A, "TAG ASTERISK"},
+	{0xE002B, "TAG PLUS SIGN"},
+	{0xE002C, "TAG COMMA"},
+	{0xE002D, "TAG HYPHEN-MINUS"},
+	{0xE002E, "TAG FULL STOP"},
+	{0xE002F, "TAG SOLIDUS"},
+	{0xE0030, "TAG DIGIT ZERO"},
+	{0xE0031, "TAG DIGIT ONE"},
+	{0xE0032, "TAG DIGIT TWO"},
+	{0xE0033, "TAG DIGIT THREE"},
+	{0xE0034, "TAG DIGIT FOUR"},
+	{0xE0035, "TAG DIGIT FIVE"},
+	{0xE0036, "TAG DIGIT SIX"},
+	{0xE0037, "TAG DIGIT SEVEN"},
+	{0xE0038, "TAG DIGIT EIGHT"},
+	{0xE0039, "TAG DIGIT NINE"},
+	{0xE003A, "TAG COLON"},
+	{0xE003B, "TAG SEMICOLON"},
+	{0xE003C, "TAG LESS-THAN SIGN"},
+	{0xE003D, "TAG EQUALS SIGN"},
+	{0xE003E, "TAG GREATER-THAN SIGN"},
+	{0xE003F, "TAG QUESTION MARK"},
+	{0xE0040, "TAG COMMERCIAL AT"},
+	{0xE0041, "TAG LATIN CAPITAL LETTER A"},
+	{0xE0042, "TAG LATIN CAPITAL LETTER B"},
+	{0xE0043, "TAG LATIN CAPITAL LETTER C"},
+	{0xE0044, "TAG LATIN CAPITAL LETTER D"},
+	{0xE0045, "TAG LATIN CAPITAL LETTER E"},
+	{0xE0046, "TAG LATIN CAPITAL LETTER F"},
+	{0xE0047, "TAG LATIN CAPITAL LETTER G"},
+	{0xE0048, "TAG LATIN CAPITAL LETTER H"},
+	{0xE0049, "TAG LATIN CAPITAL LETTER I"},
+	{0xE004A, "TAG LATIN CAPITAL LETTER J"},
+	{0xE004B, "TAG LATIN CAPITAL LETTER K"},
+	{0xE004C, "TAG LATIN CAPITAL LETTER L"},
+	{0xE004D, "TAG LATIN CAPITAL LETTER M"},
+	{0xE004E, "TAG LATIN CAPITAL LETTER N"},
+	{0xE004F, "TAG LATIN CAPITAL LETTER O"},
+	{0xE0050, "TAG LATIN CAPITAL LETTER P"},
+	{0xE0051, "TAG LATIN CAPITAL LETTER Q"},
+	{0xE0052, "TAG LATIN CAPITAL LETTER R"},
+	{0xE0053, "TAG LATIN CAPITAL LETTER S"},
+	{0xE0054, "TAG LATIN CAPITAL LETTER T"},
+	{0xE0055, "TAG LATIN CAPITAL LETTER U"},
+	{0xE0056, "TAG LATIN CAPITAL LETTER V"},
+	{0xE0057, "TAG LATIN CAPITAL LETTER W"},
+	{0xE0058, "TAG LATIN CAPITAL LETTER X"},
+	{0xE0059, "TAG LATIN CAPITAL LETTER Y"},
+	{0xE005A, "TAG LATIN CAPITAL LETTER Z"},
+	{0xE005B, "TAG LEFT SQUARE BRACKET"},
+	{0xE005C, "TAG REVERSE SOLIDUS"},
+	{0xE005D, "TAG RIGHT SQUARE BRACKET"},
+	{0xE005E, "TAG CIRCUMFLEX ACCENT"},
+	{0xE005F, "TAG LOW LINE"},
+	{0xE0060, "TAG GRAVE ACCENT"},
+	{0xE0061, "TAG LATIN SMALL LETTER A"},
+	{0xE0062, "TAG LATIN SMALL LETTER B"},
+	{0xE0063, "TAG LATIN SMALL LETTER C"},
+	{0xE0064, "TAG LATIN SMALL LETTER D"},
+	{0xE0065, "TAG LATIN SMALL LETTER E"},
+	{0xE0066, "TAG LATIN SMALL LETTER F"},
+	{0xE0067, "TAG LATIN SMALL LETTER G"},
+	{0xE0068, "TAG LATIN SMALL LETTER H"},
+	{0xE0069, "TAG LATIN SMALL LETTER I"},
+	{0xE006A, "TAG LATIN SMALL LETTER J"},
+	{0xE006B, "TAG LATIN SMALL LETTER K"},
+	{0xE006C, "TAG LATIN SMALL LETTER L"},
+	{0xE006D, "TAG LATIN SMALL LETTER M"},
+	{0xE006E, "TAG LATIN SMALL LETTER N"},
+	{0xE006F, "TAG LATIN SMALL LETTER O"},
+	{0xE0070, "TAG LATIN SMALL LETTER P"},
+	{0xE0071, "TAG LATIN SMALL LETTER Q"},
+	{0xE0072, "TAG LATIN SMALL LETTER R"},
+	{0xE0073, "TAG LATIN SMALL LETTER S"},
+	{0xE0074, "TAG LATIN SMALL LETTER T"},
+	{0xE0075, "TAG LATIN SMALL LETTER U"},
+	{0xE0076, "TAG LATIN SMALL LETTER V"},
+	{0xE0077, "TAG LATIN SMALL LETTER W"},
+	{0xE0078, "TAG LATIN SMALL LETTER X"},
+	{0xE0079, "TAG LATIN SMALL LETTER Y"},
+	{0xE007A, "TAG LATIN SMALL LETTER Z"},
+	{0xE007B, "TAG LEFT CURLY BRACKET"},
+	{0xE007C, "TAG VERTICAL LINE"},
+	{0xE007D, "TAG RIGHT CURLY BRACKET"},
+	{0xE007E, "TAG TILDE"},
+	{0xE007F, "CANCEL TAG"},
+	{0xE0100, "VARIATION SELECTOR-17"},
+	{0xE0101, "VARIATION SELECTOR-18"},
+	{0xE0102, "VARIATION SELECTOR-19"},
+	{0xE0103, "VARIATION SELECTOR-20"},
+	{0xE0104, "VARIATION SELECTOR-21"},
+	{0xE0105, "VARIATION SELECTOR-22"},
+	{0xE0106, "VARIATION SELECTOR-23"},
+	{0xE0107, "VARIATION SELECTOR-24"},
+	{0xE0108, "VARIATION SELECTOR-25"},
+	{0xE0109, "VARIATION SELECTOR-26"},
+	{0xE010A, "VARIATION SELECTOR-27"},
+	{0xE010B, "VARIATION SELECTOR-28"},
+	{0xE010C, "VARIATION SELECTOR-29"},
+	{0xE010D, "VARIATION SELECTOR-30"},
+	{0xE010E, "VARIATION SELECTOR-31"},
+	{0xE010F, "VARIATION SELECTOR-32"},
+	{0xE0110, "VARIATION SELECTOR-33"},
+	{0xE0111, "VARIATION SELECTOR-34"},
+	{0xE0112, "VARIATION SELECTOR-35"},
+	{0xE0113, "VARIATION SELECTOR-36"},
+	{0xE0114, "VARIATION SELECTOR-37"},
+	{0xE0115, "VARIATION SELECTOR-38"},
+	{0xE0116, "VARIATION SELECTOR-39"},
+	{0xE0117, "VARIATION SELECTOR-40"},
+	{0xE0118, "VARIATION SELECTOR-41"},
+	{0xE0119, "VARIATION SELECTOR-42"},
+	{0xE011A, "VARIATION SELECTOR-43"},
+	{0xE011B, "VARIATION SELECTOR-44"},
+	{0xE011C, "VARIATION SELECTOR-45"},
+	{0xE011D, "VARIATION SELECTOR-46"},
+	{0xE011E, "VARIATION SELECTOR-47"},
+	{0xE011F, "VARIATION SELECTOR-48"},
+	{0xE0120, "VARIATION SELECTOR-49"},
+	{0xE0121, "VARIATION SELECTOR-50"},
+	{0xE0122, "VARIATION SELECTOR-51"},
+	{0xE0123, "VARIATION SELECTOR-52"},
+	{0xE0124, "VARIATION SELECTOR-53"},
+	{0xE0125, "VARIATION SELECTOR-54"},
+	{0xE0126, "VARIATION SELECTOR-55"},
+	{0xE0127, "VARIATION SELECTOR-56"},
+	{0xE0128, "VARIATION SELECTOR-57"},
+	{0xE0129, "VARIATION SELECTOR-58"},
+	{0xE012A, "VARIATION SELECTOR-59"},
+	{0xE012B, "VARIATION SELECTOR-60"},
+	{0xE012C, "VARIATION SELECTOR-61"},
+	{0xE012D, "VARIATION SELECTOR-62"},
+	{0xE012E, "VARIATION SELECTOR-63"},
+	{0xE012F, "VARIATION SELECTOR-64"},
+	{0xE0130, "VARIATION SELECTOR-65"},
+	{0xE0131, "VARIATION SELECTOR-66"},
+	{0xE0132, "VARIATION SELECTOR-67"},
+	{0xE0133, "VARIATION SELECTOR-68"},
+	{0xE0134, "VARIATION SELECTOR-69"},
+	{0xE0135, "VARIATION SELECTOR-70"},
+	{0xE0136, "VARIATION SELECTOR-71"},
+	{0xE0137, "VARIATION SELECTOR-72"},
+	{0xE0138, "VARIATION SELECTOR-73"},
+	{0xE0139, "VARIATION SELECTOR-74"},
+	{0xE013A, "VARIATION SELECTOR-75"},
+	{0xE013B, "VARIATION SELECTOR-76"},
+	{0xE013C, "VARIATION SELECTOR-77"},
+	{0xE013D, "VARIATION SELECTOR-78"},
+	{0xE013E, "VARIATION SELECTOR-79"},
+	{0xE013F, "VARIATION SELECTOR-80"},
+	{0xE0140, "VARIATION SELECTOR-81"},
+	{0xE0141, "VARIATION SELECTOR-82"},
+	{0xE0142, "VARIATION SELECTOR-83"},
+	{0xE0143, "VARIATION SELECTOR-84"},
+	{0xE0144, "VARIATION SELECTOR-85"},
+	{0xE0145, "VARIATION SELECTOR-86"},
+	{0xE0146, "VARIATION SELECTOR-87"},
+	{0xE0147, "VARIATION SELECTOR-88"},
+	{0xE0148, "VARIATION SELECTOR-89"},
+	{0xE0149, "VARIATION SELECTOR-90"},
+	{0xE014A, "VARIATION SELECTOR-91"},
+	{0xE014B, "VARIATION SELECTOR-92"},
+	{0xE014C, "VARIATION SELECTOR-93"},
+	{0xE014D, "VARIATION SELECTOR-94"},
+	{0xE014E, "VARIATION SELECTOR-95"},
+	{0xE014F, "VARIATION SELECTOR-96"},
+	{0xE0150, "VARIATION SELECTOR-97"},
+	{0xE0151, "VARIATION SELECTOR-98"},
+	{0xE0152, "VARIATION SELECTOR-99"},
+	{0xE0153, "VARIATION SELECTOR-100"},
+	{0xE0154, "VARIATION SELECTOR-101"},
+	{0xE0155, "VARIATION SELECTOR-102"},
+	{0xE0156, "VARIATION SELECTOR-103"},
+	{0xE0157, "VARIATION SELECTOR-104"},
+	{0xE0158, "VARIATION SELECTOR-105"},
+	{0xE0159, "VARIATION SELECTOR-106"},
+	{0xE015A, "VARIATION SELECTOR-107"},
+	{0xE015B, "VARIATION SELECTOR-108"},
+	{0xE015C, "VARIATION SELECTOR-109"},
+	{0xE015D, "VARIATION SELECTOR-110"},
+	{0xE015E, "VARIATION SELECTOR-111"},
+	{0xE015F, "VARIATION SELECTOR-112"},
+	{0xE0160, "VARIATION SELECTOR-113"},
+	{0xE0161, "VARIATION SELECTOR-114"},
+	{0xE0162, "VARIATION SELECTOR-115"},
+	{0xE0163, "VARIATION SELECTOR-116"},
+	{0xE0164, "VARIATION SELECTOR-117"},
+	{0xE0165, "VARIATION SELECTOR-118"},
+	{0xE0166, "VARIATION SELECTOR-119"},
+	{0xE0167, "VARIATION SELECTOR-120"},
+	{0xE0168, "VARIATION SELECTOR-121"},
+	{0xE0169, "VARIATION SELECTOR-122"},
+	{0xE016A, "VARIATION SELECTOR-123"},
+	{0xE016B, "VARIATION SELECTOR-124"},
+	{0xE016C, "VARIATION SELECTOR-125"},
+	{0xE016D, "VARIATION SELECTOR-126"},
+	{0xE016E, "VARIATION SELECTOR-127"},
+	{0xE016F, "VARIATION SELECTOR-128"},
+	{0xE0170, "VARIATION SELECTOR-129"},
+	{0xE0171, "VARIATION SELECTOR-130"},
+	{0xE0172, "VARIATION SELECTOR-131"},
+	{0xE0173, "VARIATION SELECTOR-132"},
+	{0xE0174, "VARIATION SELECTOR-133"},
+	{0xE0175, "VARIATION SELECTOR-134"},
+	{0xE0176, "VARIATION SELECTOR-135"},
+	{0xE0177, "VARIATION SELECTOR-136"},
+	{0xE0178, "VARIATION SELECTOR-137"},
+	{0xE0179, "VARIATION SELECTOR-138"},
+	{0xE017A, "VARIATION SELECTOR-139"},
+	{0xE017B, "VARIATION SELECTOR-140"},
+	{0xE017C, "VARIATION SELECTOR-141"},
+	{0xE017D, "VARIATION SELECTOR-142"},
+	{0xE017E, "VARIATION SELECTOR-143"},
+	{0xE017F, "VARIATION SELECTOR-144"},
+	{0xE0180, "VARIATION SELECTOR-145"},
+	{0xE0181, "VARIATION SELECTOR-146"},
+	{0xE0182, "VARIATION SELECTOR-147"},
+	{0xE0183, "VARIATION SELECTOR-148"},
+	{0xE0184, "VARIATION SELECTOR-149"},
+	{0xE0185, "VARIATION SELECTOR-150"},
+	{0xE0186, "VARIATION SELECTOR-151"},
+	{0xE0187, "VARIATION SELECTOR-152"},
+	{0xE0188, "VARIATION SELECTOR-153"},
+	{0xE0189, "VARIATION SELECTOR-154"},
+	{0xE018A, "VARIATION SELECTOR-155"},
+	{0xE018B, "VARIATION SELECTOR-156"},
+	{0xE018C, "VARIATION SELECTOR-157"},
+	{0xE018D, "VARIATION SELECTOR-158"},
+	{0xE018E, "VARIATION SELECTOR-159"},
+	{0xE018F, "VARIATION SELECTOR-160"},
+	{0xE0190, "VARIATION SELECTOR-161"},
+	{0xE0191, "VARIATION SELECTOR-162"},
+	{0xE0192, "VARIATION SELECTOR-163"},
+	{0xE0193, "VARIATION SELECTOR-164"},
+	{0xE0194, "VARIATION SELECTOR-165"},
+	{0xE0195, "VARIATION SELECTOR-166"},
+	{0xE0196, "VARIATION SELECTOR-167"},
+	{0xE0197, "VARIATION SELECTOR-168"},
+	{0xE0198, "VARIATION SELECTOR-169"},
+	{0xE0199, "VARIATION SELECTOR-170"},
+	{0xE019A, "VARIATION SELECTOR-171"},
+	{0xE019B, "VARIATION SELECTOR-172"},
+	{0xE019C, "VARIATION SELECTOR-173"},
+	{0xE019D, "VARIATION SELECTOR-174"},
+	{0xE019E, "VARIATION SELECTOR-175"},
+	{0xE019F, "VARIATION SELECTOR-176"},
+	{0xE01A0, "VARIATION SELECTOR-177"},
+	{0xE01A1, "VARIATION SELECTOR-178"},
+	{0xE01A2, "VARIATION SELECTOR-179"},
+	{0xE01A3, "VARIATION SELECTOR-180"},
+	{0xE01A4, "VARIATION SELECTOR-181"},
+	{0xE01A5, "VARIATION SELECTOR-182"},
+	{0xE01A6, "VARIATION SELECTOR-183"},
+	{0xE01A7, "VARIATION SELECTOR-184"},
+	{0xE01A8, "VARIATION SELECTOR-185"},
+	{0xE01A9, "VARIATION SELECTOR-186"},
+	{0xE01AA, "VARIATION SELECTOR-187"},
+	{0xE01AB, "VARIATION SELECTOR-188"},
+	{0xE01AC, "VARIATION SELECTOR-189"},
+	{0xE01AD, "VARIATION SELECTOR-190"},
+	{0xE01AE, "VARIATION SELECTOR-191"},
+	{0xE01AF, "VARIATION SELECTOR-192"},
+	{0xE01B0, "VARIATION SELECTOR-193"},
+	{0xE01B1, "VARIATION SELECTOR-194"},
+	{0xE01B2, "VARIATION SELECTOR-195"},
+	{0xE01B3, "VARIATION SELECTOR-196"},
+	{0xE01B4, "VARIATION SELECTOR-197"},
+	{0xE01B5, "VARIATION SELECTOR-198"},
+	{0xE01B6, "VARIATION SELECTOR-199"},
+	{0xE01B7, "VARIATION SELECTOR-200"},
+	{0xE01B8, "VARIATION SELECTOR-201"},
+	{0xE01B9, "VARIATION SELECTOR-202"},
+	{0xE01BA, "VARIATION SELECTOR-203"},
+	{0xE01BB, "VARIATION SELECTOR-204"},
+	{0xE01BC, "VARIATION SELECTOR-205"},
+	{0xE01BD, "VARIATION SELECTOR-206"},
+	{0xE01BE, "VARIATION SELECTOR-207"},
+	{0xE01BF, "VARIATION SELECTOR-208"},
+	{0xE01C0, "VARIATION SELECTOR-209"},
+	{0xE01C1, "VARIATION SELECTOR-210"},
+	{0xE01C2, "VARIATION SELECTOR-211"},
+	{0xE01C3, "VARIATION SELECTOR-212"},
+	{0xE01C4, "VARIATION SELECTOR-213"},
+	{0xE01C5, "VARIATION SELECTOR-214"},
+	{0xE01C6, "VARIATION SELECTOR-215"},
+	{0xE01C7, "VARIATION SELECTOR-216"},
+	{0xE01C8, "VARIATION SELECTOR-217"},
+	{0xE01C9, "VARIATION SELECTOR-218"},
+	{0xE01CA, "VARIATION SELECTOR-219"},
+	{0xE01CB, "VARIATION SELECTOR-220"},
+	{0xE01CC, "VARIATION SELECTOR-221"},
+	{0xE01CD, "VARIATION SELECTOR-222"},
+	{0xE01CE, "VARIATION SELECTOR-223"},
+	{0xE01CF, "VARIATION SELECTOR-224"},
+	{0xE01D0, "VARIATION SELECTOR-225"},
+	{0xE01D1, "VARIATION SELECTOR-226"},
+	{0xE01D2, "VARIATION SELECTOR-227"},
+	{0xE01D3, "VARIATION SELECTOR-228"},
+	{0xE01D4, "VARIATION SELECTOR-229"},
+	{0xE01D5, "VARIATION SELECTOR-230"},
+	{0xE01D6, "VARIATION SELECTOR-231"},
+	{0xE01D7, "VARIATION SELECTOR-232"},
+	{0xE01D8, "VARIATION SELECTOR-233"},
+	{0xE01D9, "VARIATION SELECTOR-234"},
+	{0xE01DA, "VARIATION SELECTOR-235"},
+	{0xE01DB, "VARIATION SELECTOR-236"},
+	{0xE01DC, "VARIATION SELECTOR-237"},
+	{0xE01DD, "VARIATION SELECTOR-238"},
+	{0xE01DE, "VARIATION SELECTOR-239"},
+	{0xE01DF, "VARIATION SELECTOR-240"},
+	{0xE01E0, "VARIATION SELECTOR-241"},
+	{0xE01E1, "VARIATION SELECTOR-242"},
+	{0xE01E2, "VARIATION SELECTOR-243"},
+	{0xE01E3, "VARIATION SELECTOR-244"},
+	{0xE01E4, "VARIATION SELECTOR-245"},
+	{0xE01E5, "VARIATION SELECTOR-246"},
+	{0xE01E6, "VARIATION SELECTOR-247"},
+	{0xE01E7, "VARIATION SELECTOR-248"},
+	{0xE01E8, "VARIATION SELECTOR-249"},
+	{0xE01E9, "VARIATION SELECTOR-250"},
+	{0xE01EA, "VARIATION SELECTOR-251"},
+	{0xE01EB, "VARIATION SELECTOR-252"},
+	{0xE01EC, "VARIATION SELECTOR-253"},
+	{0xE01ED, "VARIATION SELECTOR-254"},
+	{0xE01EE, "VARIATION SELECTOR-255"},
+	{0xE01EF, "VARIATION SELECTOR-256"},
+}
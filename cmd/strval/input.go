@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// A value read from the input
+type value struct {
+	// The name of the file holding the value, <stdin> for stdin
+	file string
+	// The position of the value, 1-based; the column is counted in bytes
+	line, column int
+	// The CSV column or JSON field holding the value, empty for lines
+	field string
+	text  string
+}
+
+// readLines reads every line as a value, without its line ending
+func readLines(file string, r io.Reader, emit func(value)) error {
+	br := bufio.NewReader(r)
+
+	for line := 1; ; line++ {
+		text, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		// The newline ending the last line does not start another value
+		if text == "" && err == io.EOF {
+			return nil
+		}
+
+		text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+		emit(value{file: file, line: line, column: 1, text: text})
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// readCSV reads the values of columns of CSV input, whose first record names the columns
+func readCSV(file string, r io.Reader, fields []string, delimiter string, emit func(value)) error {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	if delimiter != "" {
+		comma, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) {
+			return fmt.Errorf("the delimiter must be a single character, got %q", delimiter)
+		}
+		reader.Comma = comma
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Spreadsheet exports often start with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[name] = i
+	}
+
+	indexes := make([]int, len(fields))
+	for i, field := range fields {
+		index, ok := columns[field]
+		if !ok {
+			return fmt.Errorf("%s: no column named %q", file, field)
+		}
+		indexes[i] = index
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		for i, field := range fields {
+			line, column := reader.FieldPos(indexes[i])
+			emit(value{file: file, line: line, column: column, field: field, text: record[indexes[i]]})
+		}
+	}
+}
+
+// readJSONLines reads the values of fields of JSON Lines input; blank lines are skipped
+func readJSONLines(file string, r io.Reader, fields []string, emit func(value)) error {
+	br := bufio.NewReader(r)
+
+	for line := 1; ; line++ {
+		text, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if len(bytes.TrimSpace(text)) > 0 {
+			found, jsonErr := jsonFieldValues(text, fields)
+			if jsonErr != nil {
+				return fmt.Errorf("%s:%d: %w", file, line, jsonErr)
+			}
+
+			for _, field := range fields {
+				v, ok := found[field]
+				if !ok {
+					// A missing field is validated as an empty string, like strval.RuleSet does
+					v = value{column: 1}
+				}
+				v.file, v.line, v.field = file, line, field
+				emit(v)
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// jsonFieldValues finds the values of fields, given as dotted paths, in a JSON object
+// Returns the values with their column, keyed by path
+func jsonFieldValues(line []byte, fields []string) (map[string]value, error) {
+	wanted := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		wanted[field] = true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, errors.New("expected a JSON object")
+	}
+
+	found := map[string]value{}
+	if err := walkJSONObject(decoder, line, "", wanted, found); err != nil {
+		return nil, err
+	}
+
+	// The closing brace, then nothing but whitespace
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+
+	return found, nil
+}
+
+// walkJSONObject records the wanted fields of the object whose opening brace was just read
+func walkJSONObject(decoder *json.Decoder, line []byte, prefix string, wanted map[string]bool, found map[string]value) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		path := token.(string)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		// The value starts after the colon following the key
+		start := int(decoder.InputOffset())
+		for start < len(line) && (line[start] == ':' || line[start] == ' ' || line[start] == '\t' || line[start] == '\r' || line[start] == '\n') {
+			start++
+		}
+
+		var raw json.RawMessage
+		switch {
+		case wanted[path]:
+			if err := decoder.Decode(&raw); err != nil {
+				return err
+			}
+
+			text, err := jsonText(raw)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			found[path] = value{column: start + 1, text: text}
+		case start < len(line) && line[start] == '{' && hasWantedPrefix(wanted, path+"."):
+			if _, err := decoder.Token(); err != nil {
+				return err
+			}
+			if err := walkJSONObject(decoder, line, path, wanted, found); err != nil {
+				return err
+			}
+			if _, err := decoder.Token(); err != nil {
+				return err
+			}
+		default:
+			if err := decoder.Decode(&raw); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasWantedPrefix checks if a wanted field is nested under a path
+func hasWantedPrefix(wanted map[string]bool, prefix string) bool {
+	for field := range wanted {
+		if strings.HasPrefix(field, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonText converts a JSON value to the string that is validated
+func jsonText(raw json.RawMessage) (string, error) {
+	switch raw[0] {
+	case '"':
+		var text string
+		err := json.Unmarshal(raw, &text)
+		return text, err
+	case 'n':
+		return "", nil
+	case '{', '[':
+		return "", errors.New("expected a string, got an object or array")
+	default:
+		// Numbers and booleans are validated as written
+		return string(raw), nil
+	}
+}
@@ -0,0 +1,239 @@
+// Command strval validates strings read from stdin or files against strval rules.
+//
+// Usage:
+//
+//	strval [flags] [file ...]
+//
+// Without files, or with the file -, values are read from stdin. The input format is
+// chosen with -format, or from the file extension:
+//
+//   - lines: every line is a value, validated with the rules of -rules
+//   - csv (.csv, .tsv): the first record names the columns; the columns given with -field
+//     are validated with -rules, or the columns named after the fields of a -ruleset file
+//   - jsonl (.jsonl, .ndjson): every line is a JSON object; the fields given with -field
+//     are validated with -rules, or the fields of a -ruleset file. Nested fields are
+//     selected with a dotted path such as user.email. Missing fields and null are validated
+//     as empty strings, numbers and booleans as their JSON text.
+//
+// Failures are reported with their line and column, in bytes from 1, as text, JSON or JUnit
+// XML. With -summary, only the number of failures of every rule is reported.
+//
+// The exit status is 0 if every value is valid, 1 if a value is invalid and 2 if the
+// arguments or the input cannot be used.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmars8047/strval"
+)
+
+// The name of the values read as lines, used in messages
+const lineValueName = "value"
+
+// A list of flag values, for flags that can be repeated
+type stringList []string
+
+// String returns the values separated by commas
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set adds a value
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// The options of the command
+type config struct {
+	rules     string
+	ruleSet   string
+	fields    stringList
+	format    string
+	delimiter string
+	output    string
+	summary   bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with its arguments and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var cfg config
+
+	flags := flag.NewFlagSet("strval", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&cfg.rules, "rules", "", "rules validating every value, e.g. 'required|max:64,runes|email'")
+	flags.StringVar(&cfg.ruleSet, "ruleset", "", "JSON or YAML rule set file giving the rules of each CSV column or JSON field")
+	flags.Var(&cfg.fields, "field", "CSV column or JSON field to validate, can be repeated")
+	flags.StringVar(&cfg.format, "format", "", "input format: lines, csv or jsonl; from the file extension when empty")
+	flags.StringVar(&cfg.delimiter, "delimiter", "", "CSV field delimiter; a comma, or a tab for .tsv files, when empty")
+	flags.StringVar(&cfg.output, "output", "text", "report format: text, json or junit")
+	flags.BoolVar(&cfg.summary, "summary", false, "report the number of failures of every rule instead of every failure")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: strval [flags] [file ...]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	rep, err := newReporter(cfg.output, cfg.summary, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "strval: %v\n", err)
+		return 2
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	for _, file := range files {
+		if err := validateFile(cfg, file, stdin, rep); err != nil {
+			fmt.Fprintf(stderr, "strval: %v\n", err)
+			return 2
+		}
+	}
+
+	if err := rep.finish(); err != nil {
+		fmt.Fprintf(stderr, "strval: %v\n", err)
+		return 2
+	}
+
+	if rep.invalidCount() > 0 {
+		return 1
+	}
+	return 0
+}
+
+// validateFile validates the values of a file, - for stdin
+func validateFile(cfg config, file string, stdin io.Reader, rep reporter) error {
+	name := file
+	r := stdin
+	if file == "-" {
+		name = "<stdin>"
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	format := cfg.format
+	if format == "" {
+		format = formatOf(file)
+	}
+
+	switch format {
+	case "lines":
+		if cfg.ruleSet != "" || len(cfg.fields) > 0 {
+			return errors.New("-ruleset and -field need csv or jsonl input, see -format")
+		}
+
+		options, err := strval.CompileRules(cfg.rules)
+		if err != nil {
+			return err
+		}
+		if len(options) == 0 {
+			return errors.New("lines input needs -rules")
+		}
+
+		return readLines(name, r, func(v value) {
+			rep.value(v, strval.ValidateStringWithName(v.text, lineValueName, options...))
+		})
+	case "csv", "jsonl":
+		fields, options, err := fieldOptions(cfg)
+		if err != nil {
+			return err
+		}
+
+		emit := func(v value) {
+			rep.value(v, strval.ValidateStringWithName(v.text, v.field, options[v.field]...))
+		}
+
+		if format == "jsonl" {
+			return readJSONLines(name, r, fields, emit)
+		}
+
+		delimiter := cfg.delimiter
+		if delimiter == "" && strings.EqualFold(filepath.Ext(file), ".tsv") {
+			delimiter = "\t"
+		}
+		return readCSV(name, r, fields, delimiter, emit)
+	default:
+		return fmt.Errorf("unknown format %q, expected lines, csv or jsonl", format)
+	}
+}
+
+// formatOf returns the input format of a file from its extension
+func formatOf(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv", ".tsv":
+		return "csv"
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	default:
+		return "lines"
+	}
+}
+
+// fieldOptions returns the fields of CSV or JSON Lines input to validate, and their options
+func fieldOptions(cfg config) ([]string, map[string][]strval.StringValidationOption, error) {
+	options := map[string][]strval.StringValidationOption{}
+
+	if cfg.ruleSet != "" {
+		if cfg.rules != "" {
+			return nil, nil, errors.New("-rules and -ruleset cannot be used together")
+		}
+
+		rs, err := strval.LoadRuleSetFile(cfg.ruleSet)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fields := cfg.fields
+		if len(fields) == 0 {
+			fields = rs.Fields()
+		}
+
+		for _, field := range fields {
+			if len(rs.Rules(field)) == 0 {
+				return nil, nil, fmt.Errorf("field %q has no rules in %s", field, cfg.ruleSet)
+			}
+			options[field] = rs.Options(field)
+		}
+
+		return fields, options, nil
+	}
+
+	if len(cfg.fields) == 0 {
+		return nil, nil, errors.New("csv and jsonl input need -field with -rules, or -ruleset")
+	}
+
+	compiled, err := strval.CompileRules(cfg.rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(compiled) == 0 {
+		return nil, nil, errors.New("-field needs -rules")
+	}
+
+	for _, field := range cfg.fields {
+		options[field] = compiled
+	}
+
+	return cfg.fields, options, nil
+}
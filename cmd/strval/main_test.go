@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests run(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	csvFile := write("users.csv", "\ufeffname,email\nalice,alice@example.com\nbob,not-an-email\n")
+	tsvFile := write("users.tsv", "name\temail\nalice\talice@example.com\n\tbob@example.com\n")
+	jsonlFile := write("users.jsonl", "{\"user\": {\"email\": \"a@example.com\"}}\n\n{\"user\": {\"email\": \"nope\"}}\n")
+	ruleSetFile := write("rules.yaml", "version: 1\nfields:\n  name: [required]\n  email: [required, email]\n")
+
+	// Test cases
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedStatus int
+		expectedOutput []string
+	}{
+		{
+			name:           "valid lines",
+			args:           []string{"-rules", "required|max:5"},
+			stdin:          "one\ntwo\n",
+			expectedStatus: 0,
+		},
+		{
+			name:           "invalid lines",
+			args:           []string{"-rules", "required|max:5"},
+			stdin:          "one\n\ntoo long\r\n",
+			expectedStatus: 1,
+			expectedOutput: []string{
+				"<stdin>:2:1: value must not be empty (not_empty)",
+				"<stdin>:3:1: value must have a maximum length of 5 (max_length)",
+			},
+		},
+		{
+			name:           "last line without newline",
+			args:           []string{"-rules", "min:3", "-"},
+			stdin:          "abc\nab",
+			expectedStatus: 1,
+			expectedOutput: []string{"<stdin>:2:1: value must have a minimum length of 3 (min_length)"},
+		},
+		{
+			name:           "csv columns",
+			args:           []string{"-field", "email", "-rules", "email", csvFile},
+			expectedStatus: 1,
+			expectedOutput: []string{csvFile + ":3:5: email must be a valid email format (email)"},
+		},
+		{
+			name:           "tsv with a rule set",
+			args:           []string{"-ruleset", ruleSetFile, tsvFile},
+			expectedStatus: 1,
+			expectedOutput: []string{tsvFile + ":3:1: name must not be empty (not_empty)"},
+		},
+		{
+			name:           "csv from stdin",
+			args:           []string{"-format", "csv", "-delimiter", ";", "-field", "code", "-rules", "min:2"},
+			stdin:          "id;code\n1;x\n",
+			expectedStatus: 1,
+			expectedOutput: []string{"<stdin>:2:3: code must have a minimum length of 2 (min_length)"},
+		},
+		{
+			name:           "jsonl nested field",
+			args:           []string{"-field", "user.email", "-rules", "email", jsonlFile},
+			expectedStatus: 1,
+			expectedOutput: []string{jsonlFile + ":3:20: user.email must be a valid email format (email)"},
+		},
+		{
+			name:           "jsonl missing and null fields",
+			args:           []string{"-format", "jsonl", "-field", "id", "-field", "name", "-rules", "required"},
+			stdin:          "{\"id\": 7, \"name\": null}\n",
+			expectedStatus: 1,
+			expectedOutput: []string{"<stdin>:1:19: name must not be empty (not_empty)"},
+		},
+		{
+			name:           "offending characters",
+			args:           []string{"-rules", "printable"},
+			stdin:          "ab\u200bc\n",
+			expectedStatus: 1,
+			expectedOutput: []string{
+				"<stdin>:1:1: value must only contain printable characters (printable)\n\tab\\u200bc\n\t  ^^^^^^\n",
+			},
+		},
+		{
+			name:           "summary",
+			args:           []string{"-summary", "-rules", "required|min:3"},
+			stdin:          "abc\n\nab\n",
+			expectedStatus: 1,
+			expectedOutput: []string{"min_length  2", "not_empty   1", "3 values checked, 2 invalid"},
+		},
+		{
+			name:           "unknown rule",
+			args:           []string{"-rules", "nope"},
+			stdin:          "abc\n",
+			expectedStatus: 2,
+			expectedOutput: []string{"strval:"},
+		},
+		{
+			name:           "lines without rules",
+			args:           []string{},
+			stdin:          "abc\n",
+			expectedStatus: 2,
+			expectedOutput: []string{"lines input needs -rules"},
+		},
+		{
+			name:           "fields without rules",
+			args:           []string{"-field", "name", csvFile},
+			expectedStatus: 2,
+			expectedOutput: []string{"-field needs -rules"},
+		},
+		{
+			name:           "fields without csv or jsonl",
+			args:           []string{"-field", "name", "-rules", "required"},
+			stdin:          "abc\n",
+			expectedStatus: 2,
+			expectedOutput: []string{"need csv or jsonl input"},
+		},
+		{
+			name:           "missing csv column",
+			args:           []string{"-field", "phone", "-rules", "required", csvFile},
+			expectedStatus: 2,
+			expectedOutput: []string{`no column named "phone"`},
+		},
+		{
+			name:           "jsonl array field",
+			args:           []string{"-format", "jsonl", "-field", "tags", "-rules", "required"},
+			stdin:          "{\"tags\": [\"a\"]}\n",
+			expectedStatus: 2,
+			expectedOutput: []string{"<stdin>:1: field tags: expected a string"},
+		},
+		{
+			name:           "jsonl trailing data",
+			args:           []string{"-format", "jsonl", "-field", "id", "-rules", "required"},
+			stdin:          "{\"id\": \"1\"} {}\n",
+			expectedStatus: 2,
+			expectedOutput: []string{"unexpected data after the JSON object"},
+		},
+		{
+			name:           "summary as junit",
+			args:           []string{"-summary", "-output", "junit", "-rules", "required"},
+			expectedStatus: 2,
+			expectedOutput: []string{"-summary reports text or json"},
+		},
+		{
+			name:           "missing file",
+			args:           []string{"-rules", "required", filepath.Join(dir, "missing.txt")},
+			expectedStatus: 2,
+			expectedOutput: []string{"missing.txt"},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.expectedStatus {
+				t.Errorf("run() = %d, want %d: %s", status, tt.expectedStatus, stderr.String())
+			}
+
+			output := stdout.String() + stderr.String()
+			for _, expected := range tt.expectedOutput {
+				if !strings.Contains(output, expected) {
+					t.Errorf("run() output = %q, want it to contain %q", output, expected)
+				}
+			}
+		})
+	}
+}
+
+// Tests run with -output json and -output junit
+func TestRunOutput(t *testing.T) {
+	const stdin = "abc\n\nab\n"
+	args := []string{"-rules", "required|min:3"}
+
+	t.Run("json", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if status := run(append([]string{"-output", "json"}, args...), strings.NewReader(stdin), &stdout, &stderr); status != 1 {
+			t.Fatalf("run() = %d, want 1: %s", status, stderr.String())
+		}
+
+		var report struct {
+			Checked  int       `json:"checked"`
+			Invalid  int       `json:"invalid"`
+			Failures []failure `json:"failures"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+			t.Fatalf("failed to parse the report: %v\n%s", err, stdout.String())
+		}
+
+		expected := []failure{
+			{File: "<stdin>", Line: 2, Column: 1, Rule: "not_empty", Message: "value must not be empty"},
+			{File: "<stdin>", Line: 2, Column: 1, Rule: "min_length", Message: "value must have a minimum length of 3"},
+			{File: "<stdin>", Line: 3, Column: 1, Value: "ab", Rule: "min_length", Message: "value must have a minimum length of 3"},
+		}
+		if report.Checked != 3 || report.Invalid != 2 || len(report.Failures) != len(expected) {
+			t.Fatalf("report = %+v, want 3 checked, 2 invalid and %d failures", report, len(expected))
+		}
+		for i := range expected {
+			if !reflect.DeepEqual(report.Failures[i], expected[i]) {
+				t.Errorf("failure %d = %+v, want %+v", i, report.Failures[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if status := run(append([]string{"-output", "junit"}, args...), strings.NewReader(stdin), &stdout, &stderr); status != 1 {
+			t.Fatalf("run() = %d, want 1: %s", status, stderr.String())
+		}
+
+		var doc junitTestSuites
+		if err := xml.Unmarshal(stdout.Bytes(), &doc); err != nil {
+			t.Fatalf("failed to parse the report: %v\n%s", err, stdout.String())
+		}
+
+		if doc.Tests != 3 || doc.Failures != 2 || len(doc.Suites) != 1 || len(doc.Suites[0].Cases) != 3 {
+			t.Fatalf("report = %+v, want one suite of 3 tests with 2 failures", doc)
+		}
+
+		cases := doc.Suites[0].Cases
+		if cases[0].Failure != nil {
+			t.Errorf("case %q failed, want it to pass", cases[0].Name)
+		}
+		if f := cases[1].Failure; f == nil || f.Type != "not_empty,min_length" {
+			t.Errorf("case %q failure = %+v, want type not_empty,min_length", cases[1].Name, f)
+		}
+		if f := cases[2].Failure; f == nil || f.Text != "<stdin>:3:1: value must have a minimum length of 3 (min_length)" {
+			t.Errorf("case %q failure = %+v", cases[2].Name, f)
+		}
+	})
+
+	t.Run("summary json", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if status := run(append([]string{"-summary", "-output", "json"}, args...), strings.NewReader(stdin), &stdout, &stderr); status != 1 {
+			t.Fatalf("run() = %d, want 1: %s", status, stderr.String())
+		}
+
+		var summary struct {
+			Checked int            `json:"checked"`
+			Invalid int            `json:"invalid"`
+			Rules   map[string]int `json:"rules"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &summary); err != nil {
+			t.Fatalf("failed to parse the summary: %v\n%s", err, stdout.String())
+		}
+		if summary.Checked != 3 || summary.Invalid != 2 || summary.Rules["min_length"] != 2 || summary.Rules["not_empty"] != 1 {
+			t.Errorf("summary = %+v", summary)
+		}
+	})
+}
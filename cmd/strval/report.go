@@ -0,0 +1,316 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dmars8047/strval"
+)
+
+// A reporter reports the result of every value validated
+type reporter interface {
+	// value records the result of a value
+	value(v value, result strval.StringValidationResult)
+	// finish writes what has not been written yet
+	finish() error
+	// invalidCount returns the number of invalid values
+	invalidCount() int
+}
+
+// newReporter creates the reporter for an output format
+func newReporter(output string, summary bool, w io.Writer) (reporter, error) {
+	if summary {
+		switch output {
+		case "text", "json":
+			return &summaryReporter{w: w, json: output == "json", rules: map[string]int{}}, nil
+		default:
+			return nil, fmt.Errorf("-summary reports text or json, got %q", output)
+		}
+	}
+
+	switch output {
+	case "text":
+		return &textReporter{w: w}, nil
+	case "json":
+		return &jsonReporter{w: w, Failures: []failure{}}, nil
+	case "junit":
+		return &junitReporter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output %q, expected text, json or junit", output)
+	}
+}
+
+// A failed rule of a value
+type failure struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Field   string `json:"field,omitempty"`
+	Value   string `json:"value"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// The characters that made the rule fail, with a snippet of the value pointing at them
+	Characters []strval.OffendingCharacter `json:"characters,omitempty"`
+	Snippet    string                      `json:"-"`
+}
+
+// failures returns the failed rules of a value
+func failures(v value, result strval.StringValidationResult) []failure {
+	list := make([]failure, len(result.Errors))
+	for i, err := range result.Errors {
+		list[i] = failure{
+			File:    v.file,
+			Line:    v.line,
+			Column:  v.column,
+			Field:   v.field,
+			Value:   v.text,
+			Rule:    err.Rule,
+			Message: err.Message,
+		}
+
+		if len(err.Characters) > 0 {
+			list[i].Characters = err.Characters
+			list[i].Snippet = err.Snippet()
+		}
+	}
+
+	return list
+}
+
+// String formats the failure like a compiler diagnostic, followed by the indented snippet if there is one
+func (f failure) String() string {
+	diagnostic := fmt.Sprintf("%s:%d:%d: %s (%s)", f.File, f.Line, f.Column, f.Message, f.Rule)
+	if f.Snippet == "" {
+		return diagnostic
+	}
+
+	return diagnostic + "\n\t" + strings.ReplaceAll(f.Snippet, "\n", "\n\t")
+}
+
+// A textReporter writes a line for every failed rule as soon as it is found
+type textReporter struct {
+	w       io.Writer
+	err     error
+	invalid int
+}
+
+// value writes the failed rules of a value
+func (r *textReporter) value(v value, result strval.StringValidationResult) {
+	if result.Valid {
+		return
+	}
+	r.invalid++
+
+	for _, f := range failures(v, result) {
+		if _, err := fmt.Fprintln(r.w, f); err != nil && r.err == nil {
+			r.err = err
+		}
+	}
+}
+
+// finish returns the first error writing the report
+func (r *textReporter) finish() error {
+	return r.err
+}
+
+// invalidCount returns the number of invalid values
+func (r *textReporter) invalidCount() int {
+	return r.invalid
+}
+
+// A jsonReporter writes a JSON document listing every failed rule
+type jsonReporter struct {
+	w        io.Writer
+	Checked  int       `json:"checked"`
+	Invalid  int       `json:"invalid"`
+	Failures []failure `json:"failures"`
+}
+
+// value records the failed rules of a value
+func (r *jsonReporter) value(v value, result strval.StringValidationResult) {
+	r.Checked++
+	if !result.Valid {
+		r.Invalid++
+		r.Failures = append(r.Failures, failures(v, result)...)
+	}
+}
+
+// finish writes the document
+func (r *jsonReporter) finish() error {
+	encoder := json.NewEncoder(r.w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// invalidCount returns the number of invalid values
+func (r *jsonReporter) invalidCount() int {
+	return r.Invalid
+}
+
+// A summaryReporter writes the number of failures of every rule
+type summaryReporter struct {
+	w       io.Writer
+	json    bool
+	checked int
+	invalid int
+	rules   map[string]int
+}
+
+// value counts the failed rules of a value
+func (r *summaryReporter) value(v value, result strval.StringValidationResult) {
+	r.checked++
+	if result.Valid {
+		return
+	}
+	r.invalid++
+
+	for _, err := range result.Errors {
+		r.rules[err.Rule]++
+	}
+}
+
+// finish writes the counts, the rules with the most failures first
+func (r *summaryReporter) finish() error {
+	if r.json {
+		encoder := json.NewEncoder(r.w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			Checked int            `json:"checked"`
+			Invalid int            `json:"invalid"`
+			Rules   map[string]int `json:"rules"`
+		}{r.checked, r.invalid, r.rules})
+	}
+
+	rules := make([]string, 0, len(r.rules))
+	for rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if r.rules[rules[i]] != r.rules[rules[j]] {
+			return r.rules[rules[i]] > r.rules[rules[j]]
+		}
+		return rules[i] < rules[j]
+	})
+
+	tw := tabwriter.NewWriter(r.w, 0, 0, 2, ' ', 0)
+	if len(rules) > 0 {
+		fmt.Fprintln(tw, "RULE\tFAILURES")
+		for _, rule := range rules {
+			fmt.Fprintf(tw, "%s\t%d\n", rule, r.rules[rule])
+		}
+	}
+	fmt.Fprintf(tw, "%d values checked, %d invalid\n", r.checked, r.invalid)
+
+	return tw.Flush()
+}
+
+// invalidCount returns the number of invalid values
+func (r *summaryReporter) invalidCount() int {
+	return r.invalid
+}
+
+// The JUnit XML document, with a test suite for every file and a test case for every value
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Line      int           `xml:"line,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// A junitReporter writes a JUnit XML document, for CI systems that display test results
+type junitReporter struct {
+	w   io.Writer
+	doc junitTestSuites
+}
+
+// value records a test case for a value
+func (r *junitReporter) value(v value, result strval.StringValidationResult) {
+	suites := r.doc.Suites
+	if len(suites) == 0 || suites[len(suites)-1].Name != v.file {
+		r.doc.Suites = append(r.doc.Suites, junitTestSuite{Name: v.file})
+	}
+	suite := &r.doc.Suites[len(r.doc.Suites)-1]
+
+	name := fmt.Sprintf("line %d", v.line)
+	if v.field != "" {
+		name = fmt.Sprintf("%s at line %d, column %d", v.field, v.line, v.column)
+	}
+
+	testCase := junitTestCase{Name: name, Classname: v.file, File: v.file, Line: v.line}
+
+	if !result.Valid {
+		list := failures(v, result)
+		rules := make([]string, len(list))
+		lines := make([]string, len(list))
+		for i, f := range list {
+			rules[i] = f.Rule
+			lines[i] = f.String()
+		}
+
+		testCase.Failure = &junitFailure{
+			Message: strings.Join(result.Messages, "; "),
+			Type:    strings.Join(rules, ","),
+			Text:    strings.Join(lines, "\n"),
+		}
+		suite.Failures++
+		r.doc.Failures++
+	}
+
+	suite.Tests++
+	r.doc.Tests++
+	suite.Cases = append(suite.Cases, testCase)
+}
+
+// finish writes the document
+func (r *junitReporter) finish() error {
+	r.doc.Name = "strval"
+
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(r.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(r.doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(r.w, "\n")
+	return err
+}
+
+// invalidCount returns the number of invalid values
+func (r *junitReporter) invalidCount() int {
+	return r.doc.Failures
+}
@@ -0,0 +1,977 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dmars8047/strval"
+)
+
+// The import path of the strval package, used by the generated code
+const strvalImportPath = "github.com/dmars8047/strval"
+
+// errRecursiveType is returned for types that contain themselves without a struct in between, such as type T []T
+var errRecursiveType = errors.New("recursive type is not supported")
+
+// The kinds of field types the generator walks
+type kind int
+
+const (
+	// A type that is not validated, e.g. int or map[string]string
+	kindOther kind = iota
+	// A string, or a type of the package whose underlying type is string
+	kindString
+	// A pointer
+	kindPointer
+	// A slice or an array
+	kindList
+	// A named struct type declared in the package, validated by its own method
+	kindStruct
+	// A struct type literal, validated inline
+	kindAnonymousStruct
+	// A type declared in another package, validated with strval through reflection
+	kindForeign
+)
+
+// The type of a field, as far as validation is concerned
+type fieldType struct {
+	kind kind
+	// The element type of a pointer or list
+	elem *fieldType
+	// The name of a struct type, or of a string type that must be converted
+	name string
+	// The fields of a struct
+	fields *ast.StructType
+	// Whether a pointer type is named, so that its value has no methods
+	named bool
+	// Whether a type of another package is declared in the standard library, which has no strval tags
+	stdlib bool
+}
+
+// A field of a struct, as ValidateStruct sees it
+type structField struct {
+	// The Go name of the field
+	goName string
+	// The name of the field in result paths, empty for a flattened embedded struct
+	pathName string
+	typ      *fieldType
+	hasTag   bool
+	rules    strval.Rules
+}
+
+// An option variable of the generated file
+type optionVar struct {
+	name  string
+	exprs []string
+}
+
+// This represents the state of Generate
+type generator struct {
+	// The type declarations of the package, by name, and their names in the order they are declared
+	specs map[string]*ast.TypeSpec
+	order []string
+
+	// The names of the imported packages that are only used for standard library packages
+	stdlib map[string]bool
+
+	// The types being resolved, to detect recursive types such as type T []T
+	resolving map[string]bool
+
+	// Whether a struct type has fields to validate, for the types already looked at
+	needs map[string]bool
+
+	// The struct types that get a validateStrval method, in the order they are found
+	queued  map[string]bool
+	pending []string
+
+	vars     []optionVar
+	varNames map[string]bool
+	body     bytes.Buffer
+
+	usesString, usesJoin, usesIndex, usesRegexp, usesStruct bool
+}
+
+// Generate generates the validation methods of the struct types of the package in a directory
+// dir: The directory of the package
+// typeNames: The struct types getting a Validate method; every exported struct type with a strval tag, directly or through its fields, when empty
+// output: The name of the generated file, left out of the package so that a stale version does not get in the way
+// Returns the formatted source of the generated file
+func Generate(dir string, typeNames []string, output string) ([]byte, error) {
+	pkgName, files, err := parsePackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		specs:     map[string]*ast.TypeSpec{},
+		stdlib:    stdlibImports(files),
+		resolving: map[string]bool{},
+		needs:     map[string]bool{},
+		queued:    map[string]bool{},
+		varNames:  map[string]bool{},
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					g.specs[spec.Name.Name] = spec
+					g.order = append(g.order, spec.Name.Name)
+				}
+			}
+		}
+	}
+
+	roots, err := g.roots(typeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range roots {
+		fmt.Fprintf(&g.body, "\n// Validate validates the strval rules of the fields of %s without reflection,\n", name)
+		fmt.Fprintf(&g.body, "// returning the same result as strval.ValidateStruct\n")
+		fmt.Fprintf(&g.body, "func (v *%s) Validate() strval.StructValidationResult {\n", name)
+		fmt.Fprintf(&g.body, "result := strval.StructValidationResult{Valid: true, Fields: make(map[string]strval.StringValidationResult)}\n")
+		fmt.Fprintf(&g.body, "v.validateStrval(\"\", &result, map[any]bool{v: true})\n")
+		fmt.Fprintf(&g.body, "return result\n}\n")
+		g.queue(name)
+	}
+
+	// Methods are generated for the struct types reached from the roots as they are found
+	for len(g.pending) > 0 {
+		name := g.pending[0]
+		g.pending = g.pending[1:]
+
+		if err := g.structMethod(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.file(pkgName)
+}
+
+// parsePackage parses the Go files of the package in a directory, leaving out tests, the generated
+// file and files excluded by build constraints
+func parsePackage(dir, output string) (string, []*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Strings(paths)
+
+	fset := token.NewFileSet()
+	var pkgName string
+	var files []*ast.File
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if pkgName != "" && file.Name.Name != pkgName {
+			return "", nil, fmt.Errorf("found packages %s and %s in %s", pkgName, file.Name.Name, dir)
+		}
+		pkgName = file.Name.Name
+		files = append(files, file)
+	}
+
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	return pkgName, files, nil
+}
+
+// stdlibImports returns the names the files of a package import standard library packages as,
+// leaving out the names also used for other packages or that may not be the package name
+func stdlibImports(files []*ast.File) map[string]bool {
+	stdlib := map[string]bool{}
+	other := map[string]bool{}
+
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+
+			name := path[strings.LastIndexByte(path, '/')+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+
+			// The first element of the import path of a standard library package has no dot, and its package is named
+			// after the last element, unless it is a major version such as math/rand/v2
+			first, _, _ := strings.Cut(path, "/")
+			if strings.Contains(first, ".") || (spec.Name == nil && isMajorVersion(name)) {
+				other[name] = true
+			} else {
+				stdlib[name] = true
+			}
+		}
+	}
+
+	for name := range other {
+		delete(stdlib, name)
+	}
+
+	return stdlib
+}
+
+// isMajorVersion checks if an import path element is a major version suffix such as v2
+func isMajorVersion(element string) bool {
+	if len(element) < 2 || element[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(element[1:])
+	return err == nil
+}
+
+// roots returns the struct types getting a Validate method
+func (g *generator) roots(typeNames []string) ([]string, error) {
+	if len(typeNames) > 0 {
+		for _, name := range typeNames {
+			spec, ok := g.specs[name]
+			if !ok {
+				return nil, fmt.Errorf("type %s not found", name)
+			}
+
+			typ, err := g.resolve(spec.Name)
+			if err != nil {
+				return nil, err
+			}
+			if typ.kind != kindStruct || typ.name != name {
+				return nil, fmt.Errorf("type %s is not a struct", name)
+			}
+		}
+
+		return typeNames, nil
+	}
+
+	var roots []string
+	for _, name := range g.order {
+		spec := g.specs[name]
+		if !ast.IsExported(name) || spec.TypeParams != nil || spec.Assign.IsValid() {
+			continue
+		}
+
+		typ, err := g.resolve(spec.Name)
+		if errors.Is(err, errRecursiveType) {
+			// Such a type is not a struct, it only fails the generation when a field uses it
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if typ.kind == kindStruct && typ.name == name {
+			needs, err := g.structNeeds(typ)
+			if err != nil {
+				return nil, err
+			}
+			if needs {
+				roots = append(roots, name)
+			}
+		}
+	}
+
+	return roots, nil
+}
+
+// resolve determines how a field type is validated
+func (g *generator) resolve(expr ast.Expr) (*fieldType, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		spec, ok := g.specs[expr.Name]
+		if !ok {
+			if expr.Name == "string" {
+				return &fieldType{kind: kindString}, nil
+			}
+			return &fieldType{kind: kindOther}, nil
+		}
+
+		if spec.TypeParams != nil {
+			return nil, fmt.Errorf("generic type %s is not supported", expr.Name)
+		}
+
+		// A struct type does not resolve its fields, so only types like type T []T come back to themselves
+		if g.resolving[expr.Name] {
+			return nil, fmt.Errorf("type %s: %w", expr.Name, errRecursiveType)
+		}
+		g.resolving[expr.Name] = true
+		defer delete(g.resolving, expr.Name)
+
+		if spec.Assign.IsValid() {
+			// An alias has the methods of the type it names
+			return g.resolve(spec.Type)
+		}
+
+		underlying, err := g.resolve(spec.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		switch underlying.kind {
+		case kindString:
+			return &fieldType{kind: kindString, name: expr.Name}, nil
+		case kindStruct, kindAnonymousStruct:
+			// A defined type does not have the methods of its underlying type, so it gets its own
+			return &fieldType{kind: kindStruct, name: expr.Name, fields: underlying.fields}, nil
+		case kindPointer:
+			return &fieldType{kind: kindPointer, elem: underlying.elem, named: true}, nil
+		default:
+			return underlying, nil
+		}
+	case *ast.StarExpr:
+		elem, err := g.resolve(expr.X)
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: kindPointer, elem: elem}, nil
+	case *ast.ArrayType:
+		elem, err := g.resolve(expr.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: kindList, elem: elem}, nil
+	case *ast.StructType:
+		return &fieldType{kind: kindAnonymousStruct, fields: expr}, nil
+	case *ast.ParenExpr:
+		return g.resolve(expr.X)
+	case *ast.SelectorExpr:
+		return g.foreignType(expr, expr), nil
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		if base, ok := typeExprBase(expr).(*ast.SelectorExpr); ok {
+			return g.foreignType(expr, base), nil
+		}
+		return nil, fmt.Errorf("generic type %s is not supported", exprString(expr))
+	default:
+		return &fieldType{kind: kindOther}, nil
+	}
+}
+
+// foreignType describes a type declared in another package, selected by sel
+func (g *generator) foreignType(expr ast.Expr, sel *ast.SelectorExpr) *fieldType {
+	pkg, _ := sel.X.(*ast.Ident)
+	return &fieldType{kind: kindForeign, name: exprString(expr), stdlib: pkg != nil && g.stdlib[pkg.Name]}
+}
+
+// typeExprBase returns the generic type of an instantiation
+func typeExprBase(expr ast.Expr) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.IndexExpr:
+		return expr.X
+	case *ast.IndexListExpr:
+		return expr.X
+	default:
+		return expr
+	}
+}
+
+// exprString returns the source of a type expression
+func exprString(expr ast.Expr) string {
+	var b bytes.Buffer
+	_ = format.Node(&b, token.NewFileSet(), expr)
+	return b.String()
+}
+
+// structFields returns the fields of a struct that ValidateStruct walks
+func (g *generator) structFields(owner string, st *ast.StructType) ([]structField, error) {
+	var fields []structField
+
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(value)
+		}
+
+		strvalTag, hasTag := tag.Lookup("strval")
+		if strvalTag == "-" || tag.Get("json") == "-" {
+			continue
+		}
+
+		typ, err := g.resolve(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", owner, err)
+		}
+
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+
+		embedded := false
+		if len(names) == 0 {
+			// An embedded field is named after its type
+			base := typeExprBase(field.Type)
+			if star, ok := base.(*ast.StarExpr); ok {
+				base = star.X
+			}
+			switch base := base.(type) {
+			case *ast.Ident:
+				names = []string{base.Name}
+			case *ast.SelectorExpr:
+				names = []string{base.Sel.Name}
+			}
+
+			embeddedType := typ
+			if embeddedType.kind == kindPointer {
+				embeddedType = embeddedType.elem
+			}
+			// A type of another package is flattened by ValidateStruct when it is a struct, and has nothing to validate otherwise
+			embedded = embeddedType.kind == kindStruct || embeddedType.kind == kindForeign
+		}
+
+		for _, name := range names {
+			if !ast.IsExported(name) && !embedded {
+				continue
+			}
+
+			rules, err := parseTag(strvalTag)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", owner, name, err)
+			}
+
+			if typ.kind == kindForeign && hasTag {
+				return nil, fmt.Errorf("%s.%s: type %s is declared in another package", owner, name, typ.name)
+			}
+
+			pathName := jsonName(name, tag)
+			if embedded && !hasTag {
+				pathName = ""
+			}
+
+			fields = append(fields, structField{goName: name, pathName: pathName, typ: typ, hasTag: hasTag, rules: rules})
+		}
+	}
+
+	return fields, nil
+}
+
+// jsonName returns the JSON name of a field, falling back to the Go name, like strval does
+func jsonName(goName string, tag reflect.StructTag) string {
+	if jsonTag, ok := tag.Lookup("json"); ok {
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return goName
+}
+
+// parseTag parses a strval tag the way strval does, checking each rule with strval.ParseRules
+func parseTag(tag string) (strval.Rules, error) {
+	var rules strval.Rules
+
+	for _, token := range strings.Split(tag, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		name, arg, hasArg := strings.Cut(token, "=")
+		rule := strval.Rule{Name: name}
+		if hasArg {
+			rule.Args = []string{arg}
+		}
+
+		parsed, err := strval.ParseRules(rule.String())
+		if err != nil {
+			var parseErr *strval.ParseError
+			if errors.As(err, &parseErr) && parseErr.Expected == "registered rule name" {
+				return nil, fmt.Errorf("unknown rule %q, only the rules built into strval can be generated", name)
+			}
+			return nil, fmt.Errorf("rule %q: %w", token, err)
+		}
+
+		rules = append(rules, parsed...)
+	}
+
+	return rules, nil
+}
+
+// typeNeeds checks if a value of a type has anything to validate; owner names the value in errors
+func (g *generator) typeNeeds(owner string, typ *fieldType, hasTag bool) (bool, error) {
+	switch typ.kind {
+	case kindString:
+		return hasTag, nil
+	case kindPointer, kindList:
+		return g.typeNeeds(owner, typ.elem, hasTag)
+	case kindStruct:
+		return g.structNeeds(typ)
+	case kindAnonymousStruct:
+		fields, err := g.structFields(owner, typ.fields)
+		if err != nil {
+			return false, err
+		}
+		return g.fieldsNeed(owner, fields)
+	case kindForeign:
+		// The fields of a type of another package may have strval tags, which ValidateStruct checks
+		return !typ.stdlib, nil
+	default:
+		return false, nil
+	}
+}
+
+// structNeeds checks if a named struct type has anything to validate
+func (g *generator) structNeeds(typ *fieldType) (bool, error) {
+	if needs, ok := g.needs[typ.name]; ok {
+		return needs, nil
+	}
+
+	// A type being looked at adds nothing to itself
+	g.needs[typ.name] = false
+
+	fields, err := g.structFields(typ.name, typ.fields)
+	if err != nil {
+		return false, err
+	}
+
+	needs, err := g.fieldsNeed(typ.name, fields)
+	if err != nil {
+		return false, err
+	}
+
+	g.needs[typ.name] = needs
+	return needs, nil
+}
+
+// fieldsNeed checks if any of the fields of a struct has anything to validate
+func (g *generator) fieldsNeed(owner string, fields []structField) (bool, error) {
+	for _, field := range fields {
+		needs, err := g.typeNeeds(owner+"."+field.goName, field.typ, field.hasTag)
+		if err != nil || needs {
+			return needs, err
+		}
+	}
+
+	return false, nil
+}
+
+// queue schedules the validateStrval method of a struct type, once
+func (g *generator) queue(name string) {
+	if !g.queued[name] {
+		g.queued[name] = true
+		g.pending = append(g.pending, name)
+	}
+}
+
+// structMethod writes the validateStrval method of a struct type
+func (g *generator) structMethod(name string) error {
+	typ, err := g.resolve(ast.NewIdent(name))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.body, "\n// validateStrval validates the fields of %s, recording their results under prefix\n", name)
+	fmt.Fprintf(&g.body, "// Pointers in visiting are not followed again, like strval.ValidateStruct stops at cyclic values.\n")
+	fmt.Fprintf(&g.body, "func (v *%s) validateStrval(prefix string, result *strval.StructValidationResult, visiting map[any]bool) {\n", name)
+
+	if err := g.fieldsCode(name, typ.fields, "v", "prefix", "strval"+exportedName(name), 0); err != nil {
+		return err
+	}
+
+	g.body.WriteString("}\n")
+	return nil
+}
+
+// fieldsCode writes the validation of the fields of a struct value
+func (g *generator) fieldsCode(owner string, st *ast.StructType, value, path, varName string, depth int) error {
+	fields, err := g.structFields(owner, st)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		fieldOwner := owner + "." + field.goName
+
+		needs, err := g.typeNeeds(fieldOwner, field.typ, field.hasTag)
+		if err != nil {
+			return err
+		}
+		if !needs {
+			continue
+		}
+
+		fieldPath := path
+		if field.pathName != "" {
+			fieldPath = fmt.Sprintf("strvalJoin(%s, %q)", path, field.pathName)
+			g.usesJoin = true
+		}
+
+		fieldVar := varName + exportedName(field.goName)
+
+		options := ""
+		if field.hasTag && hasStrings(field.typ) {
+			if options, err = g.declareOptions(fieldVar, field.rules); err != nil {
+				return fmt.Errorf("%s: %w", fieldOwner, err)
+			}
+		}
+
+		if err := g.valueCode(fieldOwner, field.typ, operand(value)+"."+field.goName, fieldPath, options, fieldVar, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// valueCode writes the validation of a value; options is the variable holding the options of strings, if any
+func (g *generator) valueCode(owner string, typ *fieldType, value, path, options, varName string, depth int) error {
+	switch typ.kind {
+	case kindString:
+		if options == "" {
+			return nil
+		}
+		if typ.name != "" {
+			value = "string(" + value + ")"
+		}
+		fmt.Fprintf(&g.body, "strvalString(result, %s, %s, %s)\n", path, value, options)
+		g.usesString = true
+	case kindPointer:
+		elem := "*" + value
+		if (typ.elem.kind == kindStruct || typ.elem.kind == kindForeign) && !typ.named {
+			// Methods with pointer receivers can be called on the pointer itself
+			elem = value
+		}
+
+		// A struct already being validated is not followed again; a named pointer type is keyed as the pointer it names
+		visited := ""
+		if typ.elem.kind == kindStruct || typ.elem.kind == kindAnonymousStruct {
+			visited = value
+			if typ.named {
+				visited = "&" + elem
+			}
+		}
+
+		switch {
+		case typ.elem.kind == kindString && options != "":
+			// A missing string is validated as an empty one so that notempty still applies
+			fmt.Fprintf(&g.body, "if %s == nil {\n", value)
+			fmt.Fprintf(&g.body, "strvalString(result, %s, \"\", %s)\n", path, options)
+			fmt.Fprintf(&g.body, "} else {\n")
+			g.usesString = true
+		case visited != "":
+			fmt.Fprintf(&g.body, "if %s != nil && !visiting[%s] {\n", value, visited)
+			fmt.Fprintf(&g.body, "visiting[%s] = true\n", visited)
+		default:
+			fmt.Fprintf(&g.body, "if %s != nil {\n", value)
+		}
+
+		if err := g.valueCode(owner, typ.elem, elem, path, options, varName, depth); err != nil {
+			return err
+		}
+		if visited != "" {
+			fmt.Fprintf(&g.body, "delete(visiting, %s)\n", visited)
+		}
+		g.body.WriteString("}\n")
+	case kindList:
+		index := indexName(depth)
+		fmt.Fprintf(&g.body, "for %s := range %s {\n", index, operand(value))
+		if err := g.valueCode(owner, typ.elem, operand(value)+"["+index+"]", "strvalIndex("+path+", "+index+")", options, varName, depth+1); err != nil {
+			return err
+		}
+		g.body.WriteString("}\n")
+		g.usesIndex = true
+	case kindStruct:
+		fmt.Fprintf(&g.body, "%s.validateStrval(%s, result, visiting)\n", operand(value), path)
+		g.queue(typ.name)
+	case kindAnonymousStruct:
+		return g.fieldsCode(owner, typ.fields, value, path, varName, depth)
+	case kindForeign:
+		if !typ.stdlib {
+			fmt.Fprintf(&g.body, "strvalStruct(result, %s, %s)\n", path, value)
+			g.usesStruct = true
+		}
+	}
+
+	return nil
+}
+
+// hasStrings checks if strings are reached from a type without going through a struct
+func hasStrings(typ *fieldType) bool {
+	switch typ.kind {
+	case kindString:
+		return true
+	case kindPointer, kindList:
+		return hasStrings(typ.elem)
+	default:
+		return false
+	}
+}
+
+// declareOptions declares the variable holding the options of a field, returning its name.
+// A number is added to the name when types such as User and user would share it.
+func (g *generator) declareOptions(name string, rules strval.Rules) (string, error) {
+	exprs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		expr, err := g.ruleExpr(rule)
+		if err != nil {
+			return "", err
+		}
+		exprs = append(exprs, expr)
+	}
+
+	unique := name
+	for i := 2; g.varNames[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.varNames[unique] = true
+
+	g.vars = append(g.vars, optionVar{name: unique, exprs: exprs})
+	return unique, nil
+}
+
+// ruleExpr returns the Go expression building the option of a built-in rule
+func (g *generator) ruleExpr(rule strval.Rule) (string, error) {
+	args := rule.Args
+
+	switch rule.Name {
+	case "required", "notempty":
+		return "strval.MustNotBeEmpty()", nil
+	case "alphanum":
+		return "strval.MustBeAlphaNumeric()", nil
+	case "numbers":
+		return "strval.MustContainNumbers()", nil
+	case "upper":
+		return "strval.MustContainUppercaseLetter()", nil
+	case "lower":
+		return "strval.MustContainLowercaseLetter()", nil
+	case "printable":
+		return "strval.MustOnlyContainPrintableCharacters()", nil
+	case "ascii":
+		return "strval.MustOnlyContainASCIICharacters()", nil
+	case "email":
+		if len(args) == 0 {
+			return "strval.MustBeValidEmailFormat()", nil
+		}
+		return fmt.Sprintf("strval.MustBeValidEmail(strval.%s)", map[string]string{
+			strval.EmailHTML5.String():   "EmailHTML5",
+			strval.EmailRFC5322.String(): "EmailRFC5322",
+			strval.EmailRFC6531.String(): "EmailRFC6531",
+		}[args[0]]), nil
+	case "min", "max":
+		n, err := intExpr(rule, 0)
+		if err != nil {
+			return "", err
+		}
+		if rule.Name == "min" {
+			return fmt.Sprintf("strval.MustHaveMinLengthOfIn(%s, %s)", n, unitExpr(args, 1)), nil
+		}
+		return fmt.Sprintf("strval.MustHaveMaxLengthOfIn(%s, %s)", n, unitExpr(args, 1)), nil
+	case "between":
+		min, err := intExpr(rule, 0)
+		if err != nil {
+			return "", err
+		}
+		max, err := intExpr(rule, 1)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("strval.MustHaveLengthBetweenIn(%s, %s, %s)", min, max, unitExpr(args, 2)), nil
+	case "contains":
+		return fmt.Sprintf("strval.MustContainAtLeastOne([]rune(%s))", strconv.Quote(args[0])), nil
+	case "excludes":
+		return fmt.Sprintf("strval.MustNotContainAnyOf([]rune(%s))", strconv.Quote(args[0])), nil
+	case "oneof":
+		return fmt.Sprintf("strval.MustBeOneOf(%s)", quoteList(args)), nil
+	case "regex":
+		g.usesRegexp = true
+		return fmt.Sprintf("strval.MustMatchRegex(regexp.MustCompile(%s))", quote(args[0])), nil
+	case "not_disposable":
+		return "strval.MustNotBeDisposableEmail()", nil
+	case "not_role":
+		return "strval.MustNotBeRoleAccount()", nil
+	case "email_domain":
+		return fmt.Sprintf("strval.MustHaveEmailDomainIn([]string{%s})", quoteList(args)), nil
+	case "password":
+		if len(args) == 1 && args[0] == "nist_privileged" {
+			return "strval.MustSatisfyPasswordPolicy(strval.NISTPrivilegedPolicy())", nil
+		}
+		return "strval.MustSatisfyPasswordPolicy(strval.NISTPolicy())", nil
+	case "not_mixed_script":
+		return "strval.MustNotBeMixedScript()", nil
+	case "script":
+		return fmt.Sprintf("strval.MustBeSingleScript(%s)", quoteList(args)), nil
+	case "not_confusable":
+		return fmt.Sprintf("strval.MustNotBeConfusableWith([]string{%s})", quoteList(args)), nil
+	case "precis":
+		return fmt.Sprintf("strval.MustSatisfyPRECISProfile(strval.%s())", map[string]string{
+			"username_case_mapped":    "UsernameCaseMapped",
+			"username_case_preserved": "UsernameCasePreserved",
+			"opaque_string":           "OpaqueString",
+			"nickname":                "Nickname",
+		}[args[0]]), nil
+	case "url":
+		return "strval.MustBeAbsoluteURL()", nil
+	case "url_scheme":
+		return fmt.Sprintf("strval.MustHaveSchemeIn(%s)", quoteList(args)), nil
+	case "url_host":
+		return fmt.Sprintf("strval.MustHaveHostIn(%s)", quoteList(args)), nil
+	case "not_ip_host":
+		return "strval.MustNotBeIPHost()", nil
+	case "no_userinfo":
+		return "strval.MustNotHaveUserInfo()", nil
+	case "no_fragment":
+		return "strval.MustNotHaveFragment()", nil
+	case "redirect_uri":
+		return fmt.Sprintf("strval.MustBeRegisteredRedirectURI(strval.MustNewRedirectURIProfile(%s))", quoteList(args)), nil
+	default:
+		return "", fmt.Errorf("rule %q cannot be generated", rule.Name)
+	}
+}
+
+// intExpr returns the Go literal of a numeric argument, parsed as the rule does so that 010 stays ten
+func intExpr(rule strval.Rule, i int) (string, error) {
+	n, err := strconv.Atoi(rule.Args[i])
+	if err != nil {
+		return "", fmt.Errorf("rule %s: argument %d: %q is not an integer", rule.Name, i+1, rule.Args[i])
+	}
+
+	return strconv.Itoa(n), nil
+}
+
+// unitExpr returns the LengthUnit constant of an optional unit argument, bytes when absent
+func unitExpr(args []string, i int) string {
+	if i >= len(args) {
+		return "strval.UnitBytes"
+	}
+
+	return map[string]string{
+		strval.UnitBytes.String():     "strval.UnitBytes",
+		strval.UnitRunes.String():     "strval.UnitRunes",
+		strval.UnitGraphemes.String(): "strval.UnitGraphemes",
+	}[args[i]]
+}
+
+// quote returns a Go string literal, raw when that is easier to read
+func quote(s string) string {
+	if strings.Contains(s, `\`) && !strings.ContainsAny(s, "`\r") && strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
+
+// quoteList returns a comma separated list of Go string literals
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// operand wraps a dereference in parentheses so that it can be indexed or have its fields selected
+func operand(value string) string {
+	if strings.HasPrefix(value, "*") {
+		return "(" + value + ")"
+	}
+
+	return value
+}
+
+// indexName returns the name of the index variable of a loop nested depth times
+func indexName(depth int) string {
+	if depth < 3 {
+		return string(rune('i' + depth))
+	}
+
+	return "i" + strconv.Itoa(depth)
+}
+
+// exportedName upper cases the first letter of a name, to build variable names from type and field names
+func exportedName(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// file assembles and formats the generated file
+func (g *generator) file(pkgName string) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by strvalgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkgName)
+
+	b.WriteString("import (\n")
+	if g.usesRegexp {
+		b.WriteString("\"regexp\"\n")
+	}
+	if g.usesIndex {
+		b.WriteString("\"strconv\"\n")
+	}
+	fmt.Fprintf(&b, "\n%q\n)\n", strvalImportPath)
+
+	if len(g.vars) > 0 {
+		b.WriteString("\n// The options of the validated fields\nvar (\n")
+		for _, v := range g.vars {
+			fmt.Fprintf(&b, "%s = []strval.StringValidationOption{", v.name)
+			if len(v.exprs) > 0 {
+				b.WriteString("\n" + strings.Join(v.exprs, ",\n") + ",\n")
+			}
+			b.WriteString("}\n")
+		}
+		b.WriteString(")\n")
+	}
+
+	b.Write(g.body.Bytes())
+
+	if g.usesString {
+		b.WriteString("\n// strvalString validates a string and records its result\n")
+		b.WriteString("func strvalString(result *strval.StructValidationResult, path, str string, options []strval.StringValidationOption) {\n")
+		b.WriteString("result.Add(path, strval.ValidateStringWithName(str, path, options...))\n}\n")
+	}
+
+	if g.usesStruct {
+		b.WriteString("\n// strvalStruct validates a value whose type is declared in another package through reflection, like strval.ValidateStruct,\n")
+		b.WriteString("// panicking if one of its strval tags is malformed\n")
+		b.WriteString("func strvalStruct(result *strval.StructValidationResult, path string, value any) {\n")
+		b.WriteString("if err := result.AddStruct(path, value); err != nil {\npanic(err)\n}\n}\n")
+	}
+
+	if g.usesJoin {
+		b.WriteString("\n// strvalJoin appends a field name to a dotted path\n")
+		b.WriteString("func strvalJoin(prefix, name string) string {\n")
+		b.WriteString("if prefix == \"\" {\nreturn name\n}\nreturn prefix + \".\" + name\n}\n")
+	}
+
+	if g.usesIndex {
+		b.WriteString("\n// strvalIndex appends a slice index to a path\n")
+		b.WriteString("func strvalIndex(path string, i int) string {\n")
+		b.WriteString("return path + \"[\" + strconv.Itoa(i) + \"]\"\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+
+	return src, nil
+}
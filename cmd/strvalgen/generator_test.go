@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dmars8047/strval"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// Tests Generate(dir string, typeNames []string, output string) ([]byte, error) against the golden files in testdata
+func TestGenerateGolden(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name  string
+		dir   string
+		types []string
+	}{
+		{name: "basic", dir: "basic"},
+		{name: "names", dir: "names", types: []string{"Item", "item"}},
+		{name: "numbers", dir: "numbers"},
+		{name: "foreign", dir: "foreign"},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generate(filepath.Join("testdata", tt.dir), tt.types, defaultOutput)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", golden, err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", golden, err)
+			}
+
+			if !bytes.Equal(got, expected) {
+				t.Errorf("Generate() does not match %s, run go test -update to review the changes:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+// Tests Generate(dir string, typeNames []string, output string) ([]byte, error) with packages it cannot generate code for
+func TestGenerateErrors(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name          string
+		src           string
+		types         []string
+		expectedError string
+	}{
+		{
+			name:          "unknown rule",
+			src:           "type User struct {\n\tName string `strval:\"slug\"`\n}\n",
+			expectedError: `User.Name: unknown rule "slug"`,
+		},
+		{
+			name:          "bad argument",
+			src:           "type User struct {\n\tName string `strval:\"min=x\"`\n}\n",
+			expectedError: `User.Name: rule "min=x"`,
+		},
+		{
+			name:          "tagged type of another package",
+			src:           "import \"net/mail\"\n\ntype User struct {\n\tEmail mail.Address `strval:\"email\"`\n}\n",
+			expectedError: "User.Email: type mail.Address is declared in another package",
+		},
+		{
+			name:          "generic type",
+			src:           "type Page[T any] struct {\n\tItems []T\n}\n\ntype User struct {\n\tPage Page[string] `strval:\"notempty\"`\n}\n",
+			expectedError: "generic type Page[string] is not supported",
+		},
+		{
+			name:          "recursive slice type",
+			src:           "type List []List\n\ntype User struct {\n\tItems List `strval:\"notempty\"`\n}\n",
+			expectedError: "type List: recursive type is not supported",
+		},
+		{
+			name:          "recursive pointer type",
+			src:           "type P *P\n\ntype User struct {\n\tName string `strval:\"notempty\"`\n\tNext P\n}\n",
+			expectedError: "type P: recursive type is not supported",
+		},
+		{
+			name:          "unknown type",
+			src:           "type User struct{}\n",
+			types:         []string{"Account"},
+			expectedError: "type Account not found",
+		},
+		{
+			name:          "not a struct",
+			src:           "type Name string\n",
+			types:         []string{"Name"},
+			expectedError: "type Name is not a struct",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte("package models\n\n"+tt.src), 0o644); err != nil {
+				t.Fatalf("failed to write the package: %v", err)
+			}
+
+			_, err := Generate(dir, tt.types, defaultOutput)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Generate() error = %v, want an error containing %q", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+// Tests that the numeric arguments of rules are generated as the decimal numbers the rules parse
+func TestRuleExprNumbers(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		rule        strval.Rule
+		expected    string
+		errExpected bool
+	}{
+		{
+			name:     "leading zero",
+			rule:     strval.Rule{Name: "min", Args: []string{"010"}},
+			expected: "strval.MustHaveMinLengthOfIn(10, strval.UnitBytes)",
+		},
+		{
+			name:     "invalid octal",
+			rule:     strval.Rule{Name: "max", Args: []string{"08", "runes"}},
+			expected: "strval.MustHaveMaxLengthOfIn(8, strval.UnitRunes)",
+		},
+		{
+			name:     "leading plus",
+			rule:     strval.Rule{Name: "between", Args: []string{"+02", "010", "graphemes"}},
+			expected: "strval.MustHaveLengthBetweenIn(2, 10, strval.UnitGraphemes)",
+		},
+		{
+			name:        "not an integer",
+			rule:        strval.Rule{Name: "min", Args: []string{"0x10"}},
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&generator{}).ruleExpr(tt.rule)
+
+			if tt.errExpected {
+				if err == nil {
+					t.Errorf("ruleExpr(%s) = %s, want an error", tt.rule, got)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("ruleExpr(%s) = %s, %v, want %s", tt.rule, got, err, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests that every rule built into strval can be generated
+func TestRuleExprCoversBuiltinRules(t *testing.T) {
+	rules, err := strval.ParseRules(`required|notempty|alphanum|numbers|upper|lower|printable|ascii|email|email:rfc6531|` +
+		`min:1|max:2,runes|between:1,2,graphemes|contains:ab|excludes:"|oneof:a,b|regex:^\d$|not_disposable|not_role|` +
+		`email_domain:example.com|password|password:nist_privileged|not_mixed_script|script:Latin,Greek|not_confusable:admin,root|precis:nickname|` +
+		`url|url_scheme:https|url_host:example.com|not_ip_host|no_userinfo|no_fragment|redirect_uri:https://example.com/cb`)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	covered := map[string]bool{}
+	g := &generator{}
+	for _, rule := range rules {
+		if _, err := g.ruleExpr(rule); err != nil {
+			t.Errorf("ruleExpr(%s) error = %v", rule, err)
+		}
+		covered[rule.Name] = true
+	}
+
+	var missing []string
+	for _, name := range strval.RuleNames() {
+		if !covered[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	if len(missing) > 0 {
+		t.Errorf("rules not covered by this test: %v", missing)
+	}
+}
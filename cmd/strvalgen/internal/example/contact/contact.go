@@ -0,0 +1,6 @@
+// Package contact holds a struct with strval tags used by the example package
+package contact
+
+type Info struct {
+	Phone string `json:"phone" strval:"notempty,numbers"`
+}
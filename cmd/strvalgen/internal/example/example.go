@@ -0,0 +1,32 @@
+// Package example holds structs whose Validate methods are generated by strvalgen
+package example
+
+import "github.com/dmars8047/strval/cmd/strvalgen/internal/example/contact"
+
+//go:generate go run github.com/dmars8047/strval/cmd/strvalgen
+
+// Status is a string type declared in the package
+type Status string
+
+type Address struct {
+	Line1   string `json:"line1" strval:"notempty,max=32"`
+	Country string `json:"country" strval:"oneof=US"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by" strval:"notempty"`
+}
+
+type Signup struct {
+	Audit
+	Username string       `json:"username" strval:"notempty,min=3,max=16,alphanum"`
+	Email    string       `json:"email" strval:"email,not_disposable"`
+	Nickname *string      `json:"nickname" strval:"notempty"`
+	Status   Status       `json:"status" strval:"oneof=active"`
+	Tags     []string     `json:"tags" strval:"lower"`
+	Address  Address      `json:"address"`
+	Previous []*Address   `json:"previous"`
+	Referrer *Signup      `json:"referrer"`
+	Contact  contact.Info `json:"contact"`
+	Password string       `json:"-" strval:"notempty"`
+}
@@ -0,0 +1,99 @@
+package example
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dmars8047/strval"
+	"github.com/dmars8047/strval/cmd/strvalgen/internal/example/contact"
+)
+
+// Tests that the generated Validate methods return the same results as strval.ValidateStruct
+func TestValidateMatchesValidateStruct(t *testing.T) {
+	nickname := ""
+
+	// Test cases
+	tests := []struct {
+		name   string
+		signup Signup
+	}{
+		{
+			name: "valid signup",
+			signup: Signup{
+				Audit:    Audit{CreatedBy: "admin"},
+				Username: "jane",
+				Email:    "jane@example.com",
+				Status:   "active",
+				Tags:     []string{"new"},
+				Address:  Address{Line1: "1 Main St", Country: "US"},
+				Contact:  contact.Info{Phone: "555 0100"},
+			},
+		},
+		{
+			name: "invalid nested fields",
+			signup: Signup{
+				Username: "j!",
+				Email:    "jane@mailinator.com",
+				Nickname: &nickname,
+				Status:   "banned",
+				Tags:     []string{"ok", "NOPE"},
+				Previous: []*Address{nil, {Line1: "", Country: "FR"}},
+				Referrer: &Signup{Username: "x", Address: Address{Country: "CA"}},
+				Contact:  contact.Info{Phone: "call me"},
+			},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := strval.ValidateStruct(&tt.signup)
+			if err != nil {
+				t.Fatalf("ValidateStruct() error = %v", err)
+			}
+
+			if got := tt.signup.Validate(); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Validate() = %+v\nwant %+v", got, expected)
+			}
+		})
+	}
+}
+
+// Tests that the generated Validate methods stop at cyclic values like strval.ValidateStruct
+func TestValidateCycle(t *testing.T) {
+	signup := &Signup{Username: "jane", Previous: []*Address{{Line1: "1 Main St"}}}
+	signup.Referrer = &Signup{Username: "j!", Referrer: signup}
+	signup.Referrer.Previous = signup.Previous
+
+	expected, err := strval.ValidateStruct(signup)
+	if err != nil {
+		t.Fatalf("ValidateStruct() error = %v", err)
+	}
+
+	if got := signup.Validate(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Validate() = %+v\nwant %+v", got, expected)
+	}
+	if _, ok := expected.Fields["referrer.previous[0].line1"]; !ok {
+		t.Errorf("ValidateStruct() fields = %v, want the shared addresses validated under both paths", expected.Fields)
+	}
+	if _, ok := expected.Fields["referrer.referrer.username"]; ok {
+		t.Errorf("ValidateStruct() fields = %v, want the cycle back to the root to stop", expected.Fields)
+	}
+}
+
+// Benchmarks the generated Validate method against strval.ValidateStruct
+func BenchmarkValidate(b *testing.B) {
+	signup := Signup{Username: "jane", Email: "jane@example.com", Status: "active", Tags: []string{"new"}}
+
+	b.Run("generated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			signup.Validate()
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = strval.ValidateStruct(&signup)
+		}
+	})
+}
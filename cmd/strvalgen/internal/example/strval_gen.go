@@ -0,0 +1,136 @@
+// Code generated by strvalgen; DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+
+	"github.com/dmars8047/strval"
+)
+
+// The options of the validated fields
+var (
+	strvalAddressLine1 = []strval.StringValidationOption{
+		strval.MustNotBeEmpty(),
+		strval.MustHaveMaxLengthOfIn(32, strval.UnitBytes),
+	}
+	strvalAddressCountry = []strval.StringValidationOption{
+		strval.MustBeOneOf("US"),
+	}
+	strvalAuditCreatedBy = []strval.StringValidationOption{
+		strval.MustNotBeEmpty(),
+	}
+	strvalSignupUsername = []strval.StringValidationOption{
+		strval.MustNotBeEmpty(),
+		strval.MustHaveMinLengthOfIn(3, strval.UnitBytes),
+		strval.MustHaveMaxLengthOfIn(16, strval.UnitBytes),
+		strval.MustBeAlphaNumeric(),
+	}
+	strvalSignupEmail = []strval.StringValidationOption{
+		strval.MustBeValidEmailFormat(),
+		strval.MustNotBeDisposableEmail(),
+	}
+	strvalSignupNickname = []strval.StringValidationOption{
+		strval.MustNotBeEmpty(),
+	}
+	strvalSignupStatus = []strval.StringValidationOption{
+		strval.MustBeOneOf("active"),
+	}
+	strvalSignupTags = []strval.StringValidationOption{
+		strval.MustContainLowercaseLetter(),
+	}
+)
+
+// Validate validates the strval rules of the fields of Address without reflection,
+// returning the same result as strval.ValidateStruct
+func (v *Address) Validate() strval.StructValidationResult {
+	result := strval.StructValidationResult{Valid: true, Fields: make(map[string]strval.StringValidationResult)}
+	v.validateStrval("", &result, map[any]bool{v: true})
+	return result
+}
+
+// Validate validates the strval rules of the fields of Audit without reflection,
+// returning the same result as strval.ValidateStruct
+func (v *Audit) Validate() strval.StructValidationResult {
+	result := strval.StructValidationResult{Valid: true, Fields: make(map[string]strval.StringValidationResult)}
+	v.validateStrval("", &result, map[any]bool{v: true})
+	return result
+}
+
+// Validate validates the strval rules of the fields of Signup without reflection,
+// returning the same result as strval.ValidateStruct
+func (v *Signup) Validate() strval.StructValidationResult {
+	result := strval.StructValidationResult{Valid: true, Fields: make(map[string]strval.StringValidationResult)}
+	v.validateStrval("", &result, map[any]bool{v: true})
+	return result
+}
+
+// validateStrval validates the fields of Address, recording their results under prefix
+// Pointers in visiting are not followed again, like strval.ValidateStruct stops at cyclic values.
+func (v *Address) validateStrval(prefix string, result *strval.StructValidationResult, visiting map[any]bool) {
+	strvalString(result, strvalJoin(prefix, "line1"), v.Line1, strvalAddressLine1)
+	strvalString(result, strvalJoin(prefix, "country"), v.Country, strvalAddressCountry)
+}
+
+// validateStrval validates the fields of Audit, recording their results under prefix
+// Pointers in visiting are not followed again, like strval.ValidateStruct stops at cyclic values.
+func (v *Audit) validateStrval(prefix string, result *strval.StructValidationResult, visiting map[any]bool) {
+	strvalString(result, strvalJoin(prefix, "created_by"), v.CreatedBy, strvalAuditCreatedBy)
+}
+
+// validateStrval validates the fields of Signup, recording their results under prefix
+// Pointers in visiting are not followed again, like strval.ValidateStruct stops at cyclic values.
+func (v *Signup) validateStrval(prefix string, result *strval.StructValidationResult, visiting map[any]bool) {
+	v.Audit.validateStrval(prefix, result, visiting)
+	strvalString(result, strvalJoin(prefix, "username"), v.Username, strvalSignupUsername)
+	strvalString(result, strvalJoin(prefix, "email"), v.Email, strvalSignupEmail)
+	if v.Nickname == nil {
+		strvalString(result, strvalJoin(prefix, "nickname"), "", strvalSignupNickname)
+	} else {
+		strvalString(result, strvalJoin(prefix, "nickname"), *v.Nickname, strvalSignupNickname)
+	}
+	strvalString(result, strvalJoin(prefix, "status"), string(v.Status), strvalSignupStatus)
+	for i := range v.Tags {
+		strvalString(result, strvalIndex(strvalJoin(prefix, "tags"), i), v.Tags[i], strvalSignupTags)
+	}
+	v.Address.validateStrval(strvalJoin(prefix, "address"), result, visiting)
+	for i := range v.Previous {
+		if v.Previous[i] != nil && !visiting[v.Previous[i]] {
+			visiting[v.Previous[i]] = true
+			v.Previous[i].validateStrval(strvalIndex(strvalJoin(prefix, "previous"), i), result, visiting)
+			delete(visiting, v.Previous[i])
+		}
+	}
+	if v.Referrer != nil && !visiting[v.Referrer] {
+		visiting[v.Referrer] = true
+		v.Referrer.validateStrval(strvalJoin(prefix, "referrer"), result, visiting)
+		delete(visiting, v.Referrer)
+	}
+	strvalStruct(result, strvalJoin(prefix, "contact"), v.Contact)
+}
+
+// strvalString validates a string and records its result
+func strvalString(result *strval.StructValidationResult, path, str string, options []strval.StringValidationOption) {
+	result.Add(path, strval.ValidateStringWithName(str, path, options...))
+}
+
+// strvalStruct validates a value whose type is declared in another package through reflection, like strval.ValidateStruct,
+// panicking if one of its strval tags is malformed
+func strvalStruct(result *strval.StructValidationResult, path string, value any) {
+	if err := result.AddStruct(path, value); err != nil {
+		panic(err)
+	}
+}
+
+// strvalJoin appends a field name to a dotted path
+func strvalJoin(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// strvalIndex appends a slice index to a path
+func strvalIndex(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
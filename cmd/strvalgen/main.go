@@ -0,0 +1,103 @@
+// Command strvalgen generates reflection-free validation methods for the structs of a package.
+//
+// It reads the strval tags of the struct fields and writes, for each struct, a Validate method
+// returning the same strval.StructValidationResult as strval.ValidateStruct, calling the Must*
+// options of the strval package directly. Add a directive to a file of the package:
+//
+//	//go:generate go run github.com/dmars8047/strval/cmd/strvalgen
+//
+// and run go generate. Usage:
+//
+//	strvalgen [-type T,U] [-output file] [-check] [dir]
+//
+// Without -type, every exported struct type of the package with a strval tag, directly or through
+// its fields, gets a Validate method. Only the rules built into strval can be generated, and only
+// struct types declared in the package are walked: fields whose types come from another
+// package are skipped, or rejected when they have a strval tag.
+//
+// With -check, nothing is written and strvalgen exits with status 1 if the generated file is
+// missing or differs from what would be generated, e.g. in CI.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The name of the generated file when -output is not set
+const defaultOutput = "strval_gen.go"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with its arguments and returns the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("strvalgen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	typeNames := flags.String("type", "", "comma separated list of struct types to generate Validate methods for; all exported structs with strval tags when empty")
+	output := flags.String("output", defaultOutput, "name of the generated file, in the package directory")
+	check := flags.Bool("check", false, "report whether the generated file is up to date instead of writing it")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: strvalgen [-type T,U] [-output file] [-check] [dir]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	dir := "."
+	if flags.NArg() == 1 {
+		dir = flags.Arg(0)
+	}
+
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	src, err := Generate(dir, types, *output)
+	if err != nil {
+		fmt.Fprintf(stderr, "strvalgen: %v\n", err)
+		return 1
+	}
+
+	path := filepath.Join(dir, *output)
+
+	if *check {
+		current, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			fmt.Fprintf(stderr, "strvalgen: %s is missing, run go generate\n", path)
+			return 1
+		case err != nil:
+			fmt.Fprintf(stderr, "strvalgen: %v\n", err)
+			return 1
+		case !bytes.Equal(current, src):
+			fmt.Fprintf(stderr, "strvalgen: %s is stale, run go generate\n", path)
+			return 1
+		}
+
+		fmt.Fprintf(stdout, "%s is up to date\n", path)
+		return 0
+	}
+
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		fmt.Fprintf(stderr, "strvalgen: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Tests run(args []string, stdout, stderr io.Writer) int with -check
+func TestRunCheck(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	// The generated file of the example package is kept up to date
+	if status := run([]string{"-check", "internal/example"}, &stdout, &stderr); status != 0 {
+		t.Fatalf("run(-check) = %d, %s", status, stderr.String())
+	}
+
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("internal", "example", "example.go"))
+	if err != nil {
+		t.Fatalf("failed to read the example package: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.go"), src, 0o644); err != nil {
+		t.Fatalf("failed to copy the example package: %v", err)
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		args           []string
+		expectedStatus int
+		expectedOutput string
+	}{
+		{
+			name:           "missing file",
+			args:           []string{"-check", dir},
+			expectedStatus: 1,
+			expectedOutput: "is missing",
+		},
+		{
+			name:           "generate",
+			args:           []string{dir},
+			expectedStatus: 0,
+		},
+		{
+			name:           "up to date",
+			args:           []string{"-check", dir},
+			expectedStatus: 0,
+			expectedOutput: "is up to date",
+		},
+		{
+			name:           "stale after the types change",
+			args:           []string{"-check", "-type", "Address", dir},
+			expectedStatus: 1,
+			expectedOutput: "is stale",
+		},
+		{
+			name:           "too many arguments",
+			args:           []string{dir, dir},
+			expectedStatus: 2,
+			expectedOutput: "usage",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			status := run(tt.args, &stdout, &stderr)
+			if status != tt.expectedStatus {
+				t.Errorf("run() = %d, want %d: %s", status, tt.expectedStatus, stderr.String())
+			}
+
+			if output := stdout.String() + stderr.String(); !strings.Contains(output, tt.expectedOutput) {
+				t.Errorf("run() output = %q, want it to contain %q", output, tt.expectedOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+//go:generate go run github.com/dmars8047/strval/cmd/strvalgen
+
+type Email string
+
+type Address struct {
+	Line1   string `json:"line1" strval:"notempty,max=32"`
+	Line2   string `json:"line2,omitempty" strval:"max=32"`
+	Country string `json:"country" strval:"oneof=US"`
+}
+
+type Audit struct {
+	CreatedBy string    `json:"created_by" strval:"notempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type audit struct {
+	UpdatedBy string `json:"updated_by" strval:"notempty"`
+}
+
+type User struct {
+	Audit
+	*audit
+	Username string            `json:"username" strval:"notempty,min=3,max=16,alphanum"`
+	Email    Email             `json:"email" strval:"email=rfc5322,not_disposable"`
+	Nickname *string           `json:"nickname" strval:"notempty"`
+	Code     string            `json:"code" strval:"regex=^[A-Z]{2}\\d+$"`
+	Tags     []string          `json:"tags" strval:"notempty,lower"`
+	Matrix   [][2]string       `json:"matrix" strval:"ascii"`
+	Address  Address           `json:"address"`
+	Previous []*Address        `json:"previous"`
+	Manager  *User             `json:"manager"`
+	Labels   map[string]string `json:"labels" strval:"notempty"`
+	Ignored  string            `json:"ignored" strval:"-"`
+	Plain    string            `json:"plain"`
+	Meta     struct {
+		Source string `json:"source" strval:"excludes=<>"`
+	} `json:"meta"`
+	password string `strval:"password"`
+}
+
+// Nothing to validate, no method
+type Point struct {
+	X, Y int
+}
+
+// Recursive types that no field uses do not fail the generation
+type Tree []Tree
+
+type Loop *Loop
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"example.com/shared"
+)
+
+type Order struct {
+	shared.Audit
+	ID       string          `json:"id" strval:"notempty"`
+	Created  time.Time       `json:"created"`
+	Customer shared.Customer `json:"customer"`
+	Billing  *shared.Address `json:"billing"`
+	Items    []shared.Item   `json:"items"`
+}
+
+// Only a type of another package to validate
+type Note struct {
+	Author shared.Customer `json:"author"`
+}
+
+// Types of the standard library have nothing to validate, no method
+type Stamp struct {
+	At time.Time
+}
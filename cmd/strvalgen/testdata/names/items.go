@@ -0,0 +1,15 @@
+package names
+
+type Item struct {
+	Name string `strval:"required,contains=-_"`
+	Kind Kind   `json:"kind"`
+}
+
+// Shares the names of its option variables with Item
+type item struct {
+	Name string `strval:"email_domain=example.com"`
+}
+
+type Kind struct {
+	Code string `json:"code" strval:"upper,password=nist_privileged"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type Limits struct {
+	Ten   string `json:"ten" strval:"min=010"`
+	Eight string `json:"eight" strval:"max=08"`
+	Plus  string `json:"plus" strval:"min=+3,max=+016"`
+}
@@ -0,0 +1,150 @@
+package strval
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This option will validate that the slice has at least min items
+// The item type cannot be inferred, e.g. MustHaveMinItems[string](1).
+func MustHaveMinItems[E any](min int) Option[[]E] {
+	return func(value []E, name string) error {
+		if len(value) < min {
+			return newFieldError(name, fmt.Sprint(value), RuleMinItems, map[string]any{"min": min})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the slice has at most max items
+// The item type cannot be inferred, e.g. MustHaveMaxItems[string](10).
+func MustHaveMaxItems[E any](max int) Option[[]E] {
+	return func(value []E, name string) error {
+		if len(value) > max {
+			return newFieldError(name, fmt.Sprint(value), RuleMaxItems, map[string]any{"max": max})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that no item of the slice is repeated
+// The error names the first repeated item. The item type cannot be inferred, e.g. MustHaveUniqueItems[string]().
+func MustHaveUniqueItems[E comparable]() Option[[]E] {
+	return func(value []E, name string) error {
+		seen := make(map[E]struct{}, len(value))
+
+		for _, item := range value {
+			if _, ok := seen[item]; ok {
+				return newFieldError(name, fmt.Sprint(value), RuleUniqueItems, map[string]any{"duplicate": fmt.Sprint(item)})
+			}
+			seen[item] = struct{}{}
+		}
+
+		return nil
+	}
+}
+
+// This option will validate every item of a slice of strings against the provided options
+// The items are named after the slice and their index, e.g. tags[2], and the error lists the failures of every item.
+func Each(options ...StringValidationOption) Option[[]string] {
+	return EachItem(StringOptions(options...)...)
+}
+
+// This option will validate every item of a slice against the provided options, see Each
+func EachItem[E any](options ...Option[E]) Option[[]E] {
+	return func(value []E, name string) error {
+		var causes []*FieldError
+
+		for i, item := range value {
+			causes = append(causes, failuresOf(item, name+"["+strconv.Itoa(i)+"]", options)...)
+		}
+
+		return eachError(name, fmt.Sprint(value), causes)
+	}
+}
+
+// This option will validate that the map has every one of the provided keys
+// The error lists the missing keys. The value type cannot be inferred, e.g. MustHaveKeys[string, int]("id").
+func MustHaveKeys[K comparable, V any](keys ...K) Option[map[K]V] {
+	return func(value map[K]V, name string) error {
+		var missing []string
+
+		for _, key := range keys {
+			if _, ok := value[key]; !ok {
+				missing = append(missing, fmt.Sprint(key))
+			}
+		}
+
+		if len(missing) > 0 {
+			return newFieldError(name, fmt.Sprint(value), RuleRequiredKeys, map[string]any{"keys": strings.Join(missing, ", ")})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate every key of a map against the provided options
+// The keys are named after the map, e.g. labels[env], and validated in sorted order.
+// The value type cannot be inferred, e.g. EachKey[int](strval.MustBeAlphaNumeric()).
+func EachKey[V any](options ...StringValidationOption) Option[map[string]V] {
+	return func(value map[string]V, name string) error {
+		var causes []*FieldError
+
+		for _, key := range sortedKeys(value) {
+			causes = append(causes, failuresOf(key, name+"["+key+"]", StringOptions(options...))...)
+		}
+
+		return eachError(name, fmt.Sprint(value), causes)
+	}
+}
+
+// This option will validate every value of a map against the provided options
+// The values are named after the map and their key, e.g. labels[env], and validated in the order of their keys.
+func EachValue[K comparable, V any](options ...Option[V]) Option[map[K]V] {
+	return func(value map[K]V, name string) error {
+		var causes []*FieldError
+
+		for _, key := range sortedKeys(value) {
+			causes = append(causes, failuresOf(value[key], name+"["+fmt.Sprint(key)+"]", options)...)
+		}
+
+		return eachError(name, fmt.Sprint(value), causes)
+	}
+}
+
+// failuresOf validates a value against the options, returning its failures of every severity
+func failuresOf[T any](value T, name string, options []Option[T]) []*FieldError {
+	result := Validate(value, name, options...)
+
+	return append(append(result.Errors, result.Warnings...), result.Infos...)
+}
+
+// eachError combines the failures of the items of a collection, with the most serious severity of the failures
+func eachError(name, value string, causes []*FieldError) error {
+	if len(causes) == 0 {
+		return nil
+	}
+
+	fieldErr := newCompositeError(name, value, RuleEach, causes)
+	fieldErr.Severity = mostSeriousSeverity(causes)
+
+	return fieldErr
+}
+
+// sortedKeys returns the keys of a map sorted by their formatted value, so that errors are reported in a stable order
+func sortedKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	return keys
+}
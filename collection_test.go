@@ -0,0 +1,146 @@
+package strval
+
+import "testing"
+
+// Tests the slice options with Validate()
+func TestSliceOptions(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		value           []string
+		options         []Option[[]string]
+		expectedMessage string
+		expectedCauses  []string
+	}{
+		{
+			name:    "valid",
+			value:   []string{"go", "api"},
+			options: []Option[[]string]{MustHaveMinItems[string](1), MustHaveMaxItems[string](3), MustHaveUniqueItems[string]()},
+		},
+		{
+			name:            "too few items",
+			value:           nil,
+			options:         []Option[[]string]{MustHaveMinItems[string](1)},
+			expectedMessage: "tags must have at least 1 items",
+		},
+		{
+			name:            "too many items",
+			value:           []string{"a", "b", "c"},
+			options:         []Option[[]string]{MustHaveMaxItems[string](2)},
+			expectedMessage: "tags must have at most 2 items",
+		},
+		{
+			name:            "duplicates",
+			value:           []string{"go", "api", "go"},
+			options:         []Option[[]string]{MustHaveUniqueItems[string]()},
+			expectedMessage: "tags must not contain duplicates: go",
+		},
+		{
+			name:            "invalid items",
+			value:           []string{"go", "", "a b"},
+			options:         []Option[[]string]{Each(MustNotBeEmpty(), MustBeAlphaNumeric())},
+			expectedMessage: "tags has invalid items: tags[1] must not be empty; tags[1] must be alphanumeric; tags[2] must be alphanumeric",
+			expectedCauses:  []string{"tags[1]", "tags[1]", "tags[2]"},
+		},
+		{
+			name:            "bail on items",
+			value:           []string{"", "a b"},
+			options:         []Option[[]string]{Each(Bail(MustNotBeEmpty()), MustBeAlphaNumeric())},
+			expectedMessage: "tags has invalid items: tags[0] must not be empty; tags[1] must be alphanumeric",
+			expectedCauses:  []string{"tags[0]", "tags[1]"},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Validate(tt.value, "tags", tt.options...)
+
+			if result.Valid != (tt.expectedMessage == "") {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.expectedMessage == "", result.Messages)
+			}
+			if tt.expectedMessage == "" {
+				return
+			}
+
+			if result.Messages[0] != tt.expectedMessage {
+				t.Errorf("Messages[0] = %q, want %q", result.Messages[0], tt.expectedMessage)
+			}
+			causes := result.Errors[0].Causes
+			if len(causes) != len(tt.expectedCauses) {
+				t.Fatalf("%d causes, want %d", len(causes), len(tt.expectedCauses))
+			}
+			for i, field := range tt.expectedCauses {
+				if causes[i].Field != field {
+					t.Errorf("Causes[%d].Field = %q, want %q", i, causes[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+// Tests EachItem() with numbers and warnings
+func TestEachItem(t *testing.T) {
+	result := Validate([]int{5, -1, 10}, "scores", EachItem(MustBePositive[int]()))
+	if result.Valid || result.Messages[0] != "scores has invalid items: scores[1] must be positive" {
+		t.Errorf("Validate() = %+v, want scores[1] to fail", result)
+	}
+
+	result = Validate([]string{"ok", ""}, "tags", Each(WithSeverity(SeverityWarning, MustNotBeEmpty())))
+	if !result.Valid || len(result.Warnings) != 1 || result.Warnings[0].Rule != RuleEach {
+		t.Errorf("Validate() = %+v, want a warning", result)
+	}
+}
+
+// Tests the map options with Validate()
+func TestMapOptions(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		value           map[string]string
+		options         []Option[map[string]string]
+		expectedMessage string
+	}{
+		{
+			name:  "valid",
+			value: map[string]string{"env": "prod", "team": "api"},
+			options: []Option[map[string]string]{
+				MustHaveKeys[string, string]("env"),
+				EachKey[string](MustBeAlphaNumeric()),
+				EachValue[string](StringOptions(MustNotBeEmpty())...),
+			},
+		},
+		{
+			name:            "missing keys",
+			value:           map[string]string{"team": "api"},
+			options:         []Option[map[string]string]{MustHaveKeys[string, string]("env", "team", "owner")},
+			expectedMessage: "labels is missing the following keys: env, owner",
+		},
+		{
+			name:            "invalid keys",
+			value:           map[string]string{"team-name": "api", "env": "prod", "a b": "c"},
+			options:         []Option[map[string]string]{EachKey[string](MustBeAlphaNumeric())},
+			expectedMessage: "labels has invalid items: labels[a b] must be alphanumeric; labels[team-name] must be alphanumeric",
+		},
+		{
+			name:            "invalid values",
+			value:           map[string]string{"team": "", "env": "prod"},
+			options:         []Option[map[string]string]{EachValue[string](StringOptions(MustNotBeEmpty())...)},
+			expectedMessage: "labels has invalid items: labels[team] must not be empty",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Validate(tt.value, "labels", tt.options...)
+
+			if result.Valid != (tt.expectedMessage == "") {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.expectedMessage == "", result.Messages)
+			}
+			if tt.expectedMessage != "" && result.Messages[0] != tt.expectedMessage {
+				t.Errorf("Messages[0] = %q, want %q", result.Messages[0], tt.expectedMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package strval
+
+import "strings"
+
+// This option will validate that the string satisfies at least one of the provided options
+// When every option fails, the error lists the failure of each option
+func AnyOf(options ...StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		if len(options) == 0 {
+			return nil
+		}
+
+		causes := make([]*FieldError, 0, len(options))
+
+		for _, option := range options {
+			err := option(str, strName)
+			if err == nil {
+				return nil
+			}
+
+			causes = append(causes, asFieldError(err, strName, str))
+		}
+
+		// The string satisfies the options as well as its least serious failure allows
+		fieldErr := newCompositeError(strName, str, RuleAnyOf, causes)
+		for _, cause := range causes {
+			if cause.Severity > fieldErr.Severity {
+				fieldErr.Severity = cause.Severity
+			}
+		}
+		return fieldErr
+	}
+}
+
+// This option will validate that the string satisfies all of the provided options
+// When a single option fails its error is returned as is, otherwise the error lists every failure
+func AllOf(options ...StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		return allOf(str, strName, options)
+	}
+}
+
+// This option will validate that the string does not satisfy the provided option
+// message is used as the error message and may reference the name of the string as {field}.
+// If message is empty a generic message is used.
+func Not(option StringValidationOption, message string) StringValidationOption {
+	return func(str, strName string) error {
+		if option(str, strName) != nil {
+			return nil
+		}
+
+		fieldErr := newFieldError(strName, str, RuleNot, nil)
+		if message != "" {
+			withMessage(fieldErr, message)
+		}
+
+		return fieldErr
+	}
+}
+
+// This option will only apply the provided options when predicate reports true for the string
+func When(predicate func(string) bool, options ...StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		if !predicate(str) {
+			return nil
+		}
+
+		return allOf(str, strName, options)
+	}
+}
+
+// This option will only apply the provided options when predicate reports false for the string
+func Unless(predicate func(string) bool, options ...StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		if predicate(str) {
+			return nil
+		}
+
+		return allOf(str, strName, options)
+	}
+}
+
+// IsEmpty reports whether a string is empty or all whitespace, for use with When and Unless
+func IsEmpty(str string) bool {
+	return isEmpty(str)
+}
+
+// allOf applies every option, returning a single failure unchanged and combining several failures
+// The evaluation stops after a failed option marked with Bail; the mark does not stop the enclosing options.
+// Combined failures have the most serious severity of their causes.
+func allOf(str, strName string, options []StringValidationOption) error {
+	var causes []*FieldError
+
+	for _, option := range options {
+		if err := option(str, strName); err != nil {
+			cause := asFieldError(err, strName, str)
+			causes = append(causes, cause)
+
+			if cause.stopsEvaluation() {
+				cause.bail = false
+				break
+			}
+		}
+	}
+
+	switch len(causes) {
+	case 0:
+		return nil
+	case 1:
+		return causes[0]
+	default:
+		fieldErr := newCompositeError(strName, str, RuleAllOf, causes)
+		fieldErr.Severity = mostSeriousSeverity(causes)
+		return fieldErr
+	}
+}
+
+// newCompositeError creates a FieldError for a rule combining the failures of several options
+func newCompositeError(field, value, rule string, causes []*FieldError) *FieldError {
+	fieldErr := newFieldError(field, value, rule, map[string]any{"errors": joinCauses(causes, nil)})
+	fieldErr.Causes = causes
+
+	return fieldErr
+}
+
+// joinCauses joins the messages of the failed options, rendering each one with render when it is not nil
+func joinCauses(causes []*FieldError, render func(*FieldError) string) string {
+	messages := make([]string, len(causes))
+
+	for i, cause := range causes {
+		if render != nil {
+			messages[i] = render(cause)
+		} else {
+			messages[i] = cause.Message
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
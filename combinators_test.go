@@ -0,0 +1,239 @@
+package strval
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Tests StringValidationOption AnyOf()
+func TestAnyOf(t *testing.T) {
+	phone := func(str, strName string) error {
+		if len(str) != 10 || !isAllDigits(str) {
+			return errors.New(strName + " must be a 10-digit phone number")
+		}
+		return nil
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		str            string
+		errExpected    bool
+		expectedCauses int
+	}{
+		{
+			name:        "valid email",
+			str:         "test@email.com",
+			errExpected: false,
+		},
+		{
+			name:        "valid phone number",
+			str:         "5551234567",
+			errExpected: false,
+		},
+		{
+			name:           "neither",
+			str:            "abc",
+			errExpected:    true,
+			expectedCauses: 2,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AnyOf(MustBeValidEmailFormat(), phone)(tt.str, "contact")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Fatalf("AnyOf() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			if !errFound {
+				return
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Rule != RuleAnyOf || len(fieldErr.Causes) != tt.expectedCauses {
+				t.Fatalf("AnyOf() error = %+v, want any_of error with %d causes", err, tt.expectedCauses)
+			}
+
+			// Make sure every failed branch is explained in the message
+			for _, expected := range []string{"contact must be a valid email format", "contact must be a 10-digit phone number"} {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("AnyOf() error = %v, expected to contain %q", err, expected)
+				}
+			}
+		})
+	}
+
+	if err := AnyOf()("anything", "str"); err != nil {
+		t.Errorf("AnyOf() with no options error = %v, want nil", err)
+	}
+}
+
+// Tests StringValidationOption AllOf()
+func TestAllOf(t *testing.T) {
+	option := AllOf(MustHaveMinLengthOf(3), MustBeAlphaNumeric())
+
+	// Test cases
+	tests := []struct {
+		name         string
+		str          string
+		errExpected  bool
+		expectedRule string
+	}{
+		{
+			name:        "satisfies all",
+			str:         "abc123",
+			errExpected: false,
+		},
+		{
+			name:         "single failure is returned unchanged",
+			str:          "ab",
+			errExpected:  true,
+			expectedRule: RuleMinLength,
+		},
+		{
+			name:         "multiple failures are combined",
+			str:          "a!",
+			errExpected:  true,
+			expectedRule: RuleAllOf,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := option(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Fatalf("AllOf() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			var fieldErr *FieldError
+			if errFound && (!errors.As(err, &fieldErr) || fieldErr.Rule != tt.expectedRule) {
+				t.Errorf("AllOf() error = %+v, want rule %s", err, tt.expectedRule)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption Not()
+func TestNot(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		message         string
+		str             string
+		errExpected     bool
+		expectedMessage string
+	}{
+		{
+			name:        "inner option fails",
+			str:         "abc!",
+			errExpected: false,
+		},
+		{
+			name:            "inner option passes with custom message",
+			message:         "{field} must contain a special character",
+			str:             "abc",
+			errExpected:     true,
+			expectedMessage: "str must contain a special character",
+		},
+		{
+			name:            "inner option passes with default message",
+			str:             "abc",
+			errExpected:     true,
+			expectedMessage: "str is not allowed",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Not(MustBeAlphaNumeric(), tt.message)(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Fatalf("Not() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			if errFound && err.Error() != tt.expectedMessage {
+				t.Errorf("Not() error = %v, want %q", err, tt.expectedMessage)
+			}
+		})
+	}
+
+	// Custom messages are not replaced by translations
+	result := ValidateStringWithLocale("abc", "str", "de", Not(MustBeAlphaNumeric(), "{field} must contain a special character"))
+	if result.Messages[0] != "str must contain a special character" {
+		t.Errorf("Not() localized message = %q, want the custom message", result.Messages[0])
+	}
+}
+
+// Tests StringValidationOption When() and Unless()
+func TestWhenAndUnless(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		option      StringValidationOption
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "when predicate false skips options",
+			option:      When(func(s string) bool { return strings.HasPrefix(s, "+") }, MustHaveMaxLengthOf(3)),
+			str:         "12345",
+			errExpected: false,
+		},
+		{
+			name:        "when predicate true applies options",
+			option:      When(func(s string) bool { return strings.HasPrefix(s, "+") }, MustHaveMaxLengthOf(3)),
+			str:         "+12345",
+			errExpected: true,
+		},
+		{
+			name:        "unless empty skips empty string",
+			option:      Unless(IsEmpty, MustHaveMinLengthOf(3)),
+			str:         "",
+			errExpected: false,
+		},
+		{
+			name:        "unless empty applies to non-empty string",
+			option:      Unless(IsEmpty, MustHaveMinLengthOf(3)),
+			str:         "ab",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option(tt.str, "str")
+
+			if (err != nil) != tt.errExpected {
+				t.Errorf("option error = %v, wantErr %v", err, tt.errExpected)
+			}
+		})
+	}
+}
+
+// Tests that combined errors are translated including their causes
+func TestCombinatorLocalization(t *testing.T) {
+	result := ValidateStringWithLocale("a!", "name", "de", AllOf(MustHaveMinLengthOf(3), MustBeAlphaNumeric()))
+
+	expected := "name muss alle folgenden Bedingungen erfüllen: name muss mindestens 3 Zeichen lang sein; name darf nur Buchstaben und Ziffern enthalten"
+	if result.Messages[0] != expected {
+		t.Errorf("localized message = %q, want %q", result.Messages[0], expected)
+	}
+
+	if cause := result.Errors[0].Causes[0]; cause.Message != "name muss mindestens 3 Zeichen lang sein" {
+		t.Errorf("localized cause = %q, want German message", cause.Message)
+	}
+}
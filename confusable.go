@@ -0,0 +1,98 @@
+package strval
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A confusable code point and its prototype
+type confusablePrototype struct {
+	r         rune
+	prototype string
+}
+
+// Skeleton returns the skeleton of a string as defined by UTS #39, section 4: two strings are visually confusable
+// when their skeletons are equal, e.g. "paypal" and "pаypal" with a Cyrillic a.
+// Skeletons are meant for comparison only, not for display or storage as a normalized form.
+// The comparison is case sensitive; lower both strings first for a case insensitive one.
+func Skeleton(str string) string {
+	var b strings.Builder
+	b.Grow(len(str))
+
+	for _, r := range decompose(str, false) {
+		if isDefaultIgnorable(r) {
+			continue
+		}
+
+		if prototype, ok := lookupPrototype(r); ok {
+			b.WriteString(prototype)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return string(decompose(b.String(), false))
+}
+
+// AreConfusable reports whether two strings are visually confusable, that is whether they have the same skeleton
+func AreConfusable(a, b string) bool {
+	return Skeleton(a) == Skeleton(b)
+}
+
+// This option will validate that the string is not visually confusable with any of the existing strings,
+// such as the usernames already taken. A string equal to an existing string is also confusable with it.
+// The skeletons of the existing strings are computed once, when the option is created.
+func MustNotBeConfusableWith(existing []string) StringValidationOption {
+	skeletons := make(map[string]string, len(existing))
+	for _, s := range existing {
+		skeleton := Skeleton(s)
+		if _, ok := skeletons[skeleton]; !ok {
+			skeletons[skeleton] = s
+		}
+	}
+
+	return func(str, strName string) error {
+		if match, ok := skeletons[Skeleton(str)]; ok {
+			return newFieldError(strName, str, RuleConfusable, map[string]any{"confusable": match})
+		}
+
+		return nil
+	}
+}
+
+// isDefaultIgnorable reports whether a code point has the Default_Ignorable_Code_Point property,
+// derived as in DerivedCoreProperties.txt
+func isDefaultIgnorable(r rune) bool {
+	if r < 0xAD {
+		return false
+	}
+
+	if unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) || unicode.Is(unicode.Variation_Selector, r) {
+		return true
+	}
+
+	return unicode.Is(unicode.Cf, r) &&
+		!unicode.Is(unicode.White_Space, r) &&
+		!unicode.Is(unicode.Prepended_Concatenation_Mark, r) &&
+		!(r >= 0xFFF9 && r <= 0xFFFB) &&
+		!(r >= 0x13430 && r <= 0x1345F)
+}
+
+// lookupPrototype returns the prototype of a confusable code point
+func lookupPrototype(r rune) (string, bool) {
+	lo, hi := 0, len(confusablePrototypes)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < confusablePrototypes[mid].r:
+			hi = mid
+		case r > confusablePrototypes[mid].r:
+			lo = mid + 1
+		default:
+			return confusablePrototypes[mid].prototype, true
+		}
+	}
+
+	return "", false
+}
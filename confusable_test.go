@@ -0,0 +1,129 @@
+package strval
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests Skeleton(str string) string and AreConfusable(a, b string) bool
+func TestSkeleton(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		a            string
+		b            string
+		expectedSame bool
+	}{
+		{
+			name:         "cyrillic a",
+			a:            "paypal",
+			b:            "p\u0430ypal",
+			expectedSame: true,
+		},
+		{
+			name:         "digit one and small l",
+			a:            "paypal",
+			b:            "paypa1",
+			expectedSame: true,
+		},
+		{
+			name:         "rn and m",
+			a:            "modern",
+			b:            "rnodern",
+			expectedSame: true,
+		},
+		{
+			name:         "zero width space",
+			a:            "admin",
+			b:            "ad\u200Bmin",
+			expectedSame: true,
+		},
+		{
+			name:         "precomposed and combining",
+			a:            "José",
+			b:            "Jose\u0301",
+			expectedSame: true,
+		},
+		{
+			name:         "greek omicron",
+			a:            "google",
+			b:            "g\u03BF\u03BFgle",
+			expectedSame: true,
+		},
+		{
+			name:         "different words",
+			a:            "paypal",
+			b:            "paypak",
+			expectedSame: false,
+		},
+		{
+			name:         "case differs",
+			a:            "paypal",
+			b:            "PayPal",
+			expectedSame: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AreConfusable(tt.a, tt.b); got != tt.expectedSame {
+				t.Errorf("AreConfusable(%+q, %+q) = %v, want %v (skeletons %+q and %+q)",
+					tt.a, tt.b, got, tt.expectedSame, Skeleton(tt.a), Skeleton(tt.b))
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustNotBeConfusableWith()
+func TestMustNotBeConfusableWith(t *testing.T) {
+	option := MustNotBeConfusableWith([]string{"admin", "paypal", "support"})
+
+	// Test cases
+	tests := []struct {
+		name               string
+		str                string
+		errExpected        bool
+		expectedConfusable string
+	}{
+		{
+			name:        "new name",
+			str:         "jane",
+			errExpected: false,
+		},
+		{
+			name:               "lookalike",
+			str:                "p\u0430yp\u0430l",
+			errExpected:        true,
+			expectedConfusable: "paypal",
+		},
+		{
+			name:               "same name",
+			str:                "admin",
+			errExpected:        true,
+			expectedConfusable: "admin",
+		},
+		{
+			name:               "digit lookalike",
+			str:                "adrnin",
+			errExpected:        true,
+			expectedConfusable: "admin",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := option(tt.str, "username")
+
+			if (err != nil) != tt.errExpected {
+				t.Fatalf("MustNotBeConfusableWith() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			var fieldErr *FieldError
+			if err != nil && (!errors.As(err, &fieldErr) || fieldErr.Rule != RuleConfusable || fieldErr.Params["confusable"] != tt.expectedConfusable) {
+				t.Errorf("MustNotBeConfusableWith() error = %+v, want confusable error with %q", err, tt.expectedConfusable)
+			}
+		})
+	}
+}
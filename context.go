@@ -0,0 +1,138 @@
+package strval
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// The number of ContextOption checks ValidateStringContext runs at the same time, unless MaxConcurrency is provided
+const defaultMaxConcurrency = 8
+
+// A ContextOption validates a string like a StringValidationOption, for checks that need I/O such as
+// a database lookup. It should stop and return the error of the context when the context is done.
+type ContextOption func(ctx context.Context, str, strName string) error
+
+// A ValidationOption is an option accepted by ValidateStringContext:
+// a StringValidationOption, a ContextOption or MaxConcurrency
+type ValidationOption interface {
+	validationOption()
+}
+
+func (StringValidationOption) validationOption() {}
+func (ContextOption) validationOption()          {}
+
+// The option set by MaxConcurrency
+type maxConcurrency int
+
+func (maxConcurrency) validationOption() {}
+
+// MaxConcurrency sets the number of ContextOption checks ValidateStringContext runs at the same time, 8 by default
+// MaxConcurrency panics if n is less than 1.
+func MaxConcurrency(n int) ValidationOption {
+	if n < 1 {
+		panic("strval: MaxConcurrency: n must be at least 1")
+	}
+
+	return maxConcurrency(n)
+}
+
+// ValidateStringContext validates a string against the provided options, running the ContextOption checks concurrently
+// The StringValidationOption options run first, in order, and errors are reported in the order of the options.
+// When a StringValidationOption marked with Bail fails, no ContextOption check runs.
+// str: The string to validate
+// strName: The name of the string to validate (used in error messages)
+// options: StringValidationOption and ContextOption options, and optionally MaxConcurrency
+// Returns a StringValidationResult, and the error of the context when it is done before every check completed.
+// The result is then incomplete and not valid.
+func ValidateStringContext(ctx context.Context, str, strName string, options ...ValidationOption) (StringValidationResult, error) {
+	limit := defaultMaxConcurrency
+	for _, option := range options {
+		if n, ok := option.(maxConcurrency); ok {
+			limit = int(n)
+		}
+	}
+
+	errs := make([]error, len(options))
+
+	for i, option := range options {
+		option, ok := option.(StringValidationOption)
+		if !ok {
+			continue
+		}
+
+		errs[i] = option(str, strName)
+
+		// A failed prerequisite also skips the ContextOption checks
+		if errs[i] != nil && asFieldError(errs[i], strName, str).stopsEvaluation() {
+			return resultOf(errs, str, strName, nil), nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, limit)
+	incomplete := false
+
+start:
+	for i, option := range options {
+		option, ok := option.(ContextOption)
+		if !ok {
+			continue
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			incomplete = true
+			break start
+		}
+
+		// A slot may have been free although the context is done
+		if ctx.Err() != nil {
+			incomplete = true
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, option ContextOption) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			errs[i] = option(ctx, str, strName)
+		}(i, option)
+	}
+
+	wg.Wait()
+
+	ctxErr := ctx.Err()
+	result := resultOf(errs, str, strName, func(err error) bool {
+		// A check stopped by the context did not complete, it did not fail
+		if ctxErr != nil && errors.Is(err, ctxErr) {
+			incomplete = true
+			return true
+		}
+		return false
+	})
+
+	if incomplete {
+		result.Valid = false
+		return result, ctxErr
+	}
+
+	return result, nil
+}
+
+// resultOf builds the result of the errors of the options, in order, leaving out the errors skip reports true for
+func resultOf(errs []error, str, strName string, skip func(error) bool) StringValidationResult {
+	result := StringValidationResult{Valid: true}
+
+	for _, err := range errs {
+		if err == nil || (skip != nil && skip(err)) {
+			continue
+		}
+
+		result.add(asFieldError(err, strName, str))
+	}
+
+	return result
+}
@@ -0,0 +1,216 @@
+package strval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// usernameTaken returns a ContextOption reporting the taken usernames after a delay, stopped by the context
+func usernameTaken(delay time.Duration, taken ...string) ContextOption {
+	return func(ctx context.Context, str, strName string) error {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		for _, name := range taken {
+			if str == name {
+				return errors.New(strName + " is already taken")
+			}
+		}
+
+		return nil
+	}
+}
+
+// Tests ValidateStringContext(ctx context.Context, str, strName string, options ...ValidationOption)
+func TestValidateStringContext(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name             string
+		str              string
+		options          []ValidationOption
+		expectedValid    bool
+		expectedMessages []string
+	}{
+		{
+			name:          "valid",
+			str:           "juliet",
+			options:       []ValidationOption{MustNotBeEmpty(), usernameTaken(0, "romeo")},
+			expectedValid: true,
+		},
+		{
+			name:             "failing context option",
+			str:              "romeo",
+			options:          []ValidationOption{MustNotBeEmpty(), usernameTaken(0, "romeo")},
+			expectedValid:    false,
+			expectedMessages: []string{"username is already taken"},
+		},
+		{
+			name: "errors in the order of the options",
+			str:  "ro",
+			options: []ValidationOption{
+				usernameTaken(10*time.Millisecond, "ro"),
+				MustHaveMinLengthOf(3),
+				ContextOption(func(ctx context.Context, str, strName string) error {
+					return newFieldError(strName, str, RuleAlphaNumeric, nil)
+				}),
+			},
+			expectedValid: false,
+			expectedMessages: []string{
+				"username is already taken",
+				"username must have a minimum length of 3",
+				"username must be alphanumeric",
+			},
+		},
+		{
+			name:          "synchronous options only",
+			str:           "juliet",
+			options:       []ValidationOption{MustNotBeEmpty(), MustBeAlphaNumeric()},
+			expectedValid: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateStringContext(context.Background(), tt.str, "username", tt.options...)
+			if err != nil {
+				t.Fatalf("ValidateStringContext() returned error: %v", err)
+			}
+
+			if result.Valid != tt.expectedValid {
+				t.Errorf("Valid = %v, want %v", result.Valid, tt.expectedValid)
+			}
+			if len(result.Messages) != len(tt.expectedMessages) {
+				t.Fatalf("Messages = %q, want %q", result.Messages, tt.expectedMessages)
+			}
+			for i, message := range tt.expectedMessages {
+				if result.Messages[i] != message {
+					t.Errorf("Messages[%d] = %q, want %q", i, result.Messages[i], message)
+				}
+			}
+		})
+	}
+}
+
+// Tests that ValidateStringContext stops when the context is done
+func TestValidateStringContextCancellation(t *testing.T) {
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		result, err := ValidateStringContext(ctx, "ab", "username",
+			MustHaveMinLengthOf(3),
+			usernameTaken(time.Minute),
+		)
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if result.Valid || len(result.Errors) != 1 || result.Errors[0].Rule != RuleMinLength {
+			t.Errorf("result = %+v, want the failure of the completed option only", result)
+		}
+	})
+
+	t.Run("already canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called atomic.Bool
+		result, err := ValidateStringContext(ctx, "juliet", "username",
+			ContextOption(func(ctx context.Context, str, strName string) error {
+				called.Store(true)
+				return nil
+			}),
+		)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("error = %v, want %v", err, context.Canceled)
+		}
+		if result.Valid {
+			t.Error("an incomplete result is valid")
+		}
+		if called.Load() {
+			t.Error("a ContextOption ran with a canceled context")
+		}
+	})
+}
+
+// Tests MaxConcurrency(n int) ValidationOption
+func TestMaxConcurrency(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		limit    ValidationOption
+		expected int32
+	}{
+		{
+			name:     "default",
+			limit:    nil,
+			expected: defaultMaxConcurrency,
+		},
+		{
+			name:     "one at a time",
+			limit:    MaxConcurrency(1),
+			expected: 1,
+		},
+		{
+			name:     "three at a time",
+			limit:    MaxConcurrency(3),
+			expected: 3,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, highest int32
+			var mu sync.Mutex
+
+			check := ContextOption(func(ctx context.Context, str, strName string) error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+
+				mu.Lock()
+				if n > highest {
+					highest = n
+				}
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			})
+
+			options := []ValidationOption{}
+			if tt.limit != nil {
+				options = append(options, tt.limit)
+			}
+			for i := 0; i < 20; i++ {
+				options = append(options, check)
+			}
+
+			if _, err := ValidateStringContext(context.Background(), "juliet", "username", options...); err != nil {
+				t.Fatalf("ValidateStringContext() returned error: %v", err)
+			}
+			if highest > tt.expected {
+				t.Errorf("%d checks ran at the same time, want at most %d", highest, tt.expected)
+			}
+			if highest < 1 {
+				t.Error("no check ran")
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MaxConcurrency(0) did not panic")
+		}
+	}()
+	MaxConcurrency(0)
+}
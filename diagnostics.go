@@ -0,0 +1,224 @@
+package strval
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The number of characters of context kept before the first and after the last offending character of a snippet
+const snippetContext = 24
+
+// A code point and its name, used to describe offending characters
+type characterName struct {
+	r    rune
+	name string
+}
+
+// This represents a character that made a rule fail, and where it is in the validated string
+type OffendingCharacter struct {
+	// The character
+	Rune rune `json:"rune"`
+	// The offset of the first byte of the character in the string
+	ByteOffset int `json:"byte_offset"`
+	// The index of the character in the string, counted in runes from 0
+	RuneIndex int `json:"rune_index"`
+	// The escaped form of the character, e.g. U+200B ZERO WIDTH SPACE
+	Escaped string `json:"escaped"`
+}
+
+// String returns the escaped form of the character and its position, e.g. U+200B ZERO WIDTH SPACE at byte 5 (rune 5)
+func (c OffendingCharacter) String() string {
+	return fmt.Sprintf("%s at byte %d (rune %d)", c.Escaped, c.ByteOffset, c.RuneIndex)
+}
+
+// EscapeRune returns the code point of a character followed by its name, e.g. U+200B ZERO WIDTH SPACE,
+// for invisible characters, separators, Latin-1 and General Punctuation characters, or by the quoted character
+// when it is printable, e.g. U+4E2D '中'
+// r: The character to escape
+// Returns the escaped form of the character
+func EscapeRune(r rune) string {
+	if name := lookupCharacterName(r); name != "" {
+		return fmt.Sprintf("U+%04X %s", r, name)
+	}
+
+	return fmt.Sprintf("%#U", r)
+}
+
+// Snippet renders a string on one line with a line of carets under the offending characters, for command line
+// and log output. Invisible characters are escaped so that they can be seen, long strings are shortened around
+// the offending characters, and wide characters are assumed to take two columns.
+// str: The validated string
+// characters: The offending characters of the string, e.g. the Characters of a FieldError
+// Returns the two lines separated by a newline, or the rendered string alone when there are no offending characters
+func Snippet(str string, characters []OffendingCharacter) string {
+	offending := make(map[int]bool, len(characters))
+	first, last := len(str), -1
+	for _, c := range characters {
+		offending[c.ByteOffset] = true
+		if c.ByteOffset < first {
+			first = c.ByteOffset
+		}
+		if c.ByteOffset > last {
+			last = c.ByteOffset
+		}
+	}
+
+	// The window of characters kept around the offending characters
+	start, end := 0, len(str)
+	if last >= 0 {
+		start = backCharacters(str, first, snippetContext)
+		end = last
+		if end < len(str) {
+			_, size := utf8.DecodeRuneInString(str[end:])
+			end = forwardCharacters(str, end+size, snippetContext)
+		}
+	}
+
+	var text, carets strings.Builder
+	if start > 0 {
+		text.WriteString("...")
+		carets.WriteString("   ")
+	}
+
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(str[i:])
+
+		var rendered string
+		var width int
+		switch {
+		case r == utf8.RuneError && size == 1:
+			rendered = fmt.Sprintf(`\x%02x`, str[i])
+			width = len(rendered)
+		case !unicode.IsPrint(r) || (offending[i] && unicode.In(r, unicode.Mn, unicode.Me)):
+			rendered = escapeForSnippet(r)
+			width = len(rendered)
+		default:
+			rendered = string(r)
+			width = displayWidth(r)
+		}
+
+		text.WriteString(rendered)
+		mark := " "
+		if offending[i] {
+			mark = "^"
+		}
+		carets.WriteString(strings.Repeat(mark, width))
+
+		i += size
+	}
+
+	if end < len(str) {
+		text.WriteString("...")
+	}
+
+	if last < 0 {
+		return text.String()
+	}
+
+	return text.String() + "\n" + strings.TrimRight(carets.String(), " ")
+}
+
+// Snippet renders the value of the error with carets under its offending characters
+// See Snippet for the format
+func (e *FieldError) Snippet() string {
+	return Snippet(e.Value, e.Characters)
+}
+
+// findOffendingCharacters returns the characters of a string for which offends returns true
+func findOffendingCharacters(str string, offends func(r rune) bool) []OffendingCharacter {
+	var characters []OffendingCharacter
+
+	runeIndex := 0
+	for i, r := range str {
+		if offends(r) {
+			characters = append(characters, OffendingCharacter{
+				Rune:       r,
+				ByteOffset: i,
+				RuneIndex:  runeIndex,
+				Escaped:    EscapeRune(r),
+			})
+		}
+		runeIndex++
+	}
+
+	return characters
+}
+
+// withOffendingCharacters sets the offending characters of a FieldError for the string it was created for
+func withOffendingCharacters(fieldErr *FieldError, offends func(r rune) bool) *FieldError {
+	fieldErr.Characters = findOffendingCharacters(fieldErr.Value, offends)
+
+	return fieldErr
+}
+
+// escapeForSnippet returns the Go escape sequence of a character
+func escapeForSnippet(r rune) string {
+	switch r {
+	case '\t':
+		return `\t`
+	case '\n':
+		return `\n`
+	case '\r':
+		return `\r`
+	}
+
+	if r > 0xFFFF {
+		return fmt.Sprintf(`\U%08x`, r)
+	}
+
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+// displayWidth returns the approximate number of columns a printable character takes in a terminal
+func displayWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return 0
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul),
+		r >= 0xFF01 && r <= 0xFF60, r >= 0xFFE0 && r <= 0xFFE6, r >= 0x3000 && r <= 0x303F:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// backCharacters returns the byte offset n characters before offset, or 0
+func backCharacters(str string, offset, n int) int {
+	for ; n > 0 && offset > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(str[:offset])
+		offset -= size
+	}
+
+	return offset
+}
+
+// forwardCharacters returns the byte offset n characters after offset, or the length of the string
+func forwardCharacters(str string, offset, n int) int {
+	for ; n > 0 && offset < len(str); n-- {
+		_, size := utf8.DecodeRuneInString(str[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// lookupCharacterName returns the name of a code point of characterNames, or an empty string
+func lookupCharacterName(r rune) string {
+	lo, hi := 0, len(characterNames)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < characterNames[mid].r:
+			hi = mid
+		case r > characterNames[mid].r:
+			lo = mid + 1
+		default:
+			return characterNames[mid].name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,161 @@
+package strval
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests EscapeRune(r rune) string
+func TestEscapeRune(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		r        rune
+		expected string
+	}{
+		{r: 0x200B, expected: "U+200B ZERO WIDTH SPACE"},
+		{r: '\t', expected: "U+0009 CHARACTER TABULATION"},
+		{r: 0x00A0, expected: "U+00A0 NO-BREAK SPACE"},
+		{r: '<', expected: "U+003C LESS-THAN SIGN"},
+		{r: 0xFEFF, expected: "U+FEFF ZERO WIDTH NO-BREAK SPACE"},
+		{r: 0xE0041, expected: "U+E0041 TAG LATIN CAPITAL LETTER A"},
+		{r: 0x1343C, expected: "U+1343C EGYPTIAN HIEROGLYPH BEGIN ENCLOSURE"},
+		{r: '中', expected: "U+4E2D '中'"},
+		{r: 0xE000, expected: "U+E000"},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := EscapeRune(tt.r); got != tt.expected {
+				t.Errorf("EscapeRune(%U) = %q, want %q", tt.r, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests the offending characters reported by the character class options
+func TestOffendingCharacters(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		option   StringValidationOption
+		str      string
+		expected []OffendingCharacter
+	}{
+		{
+			name:   "not printable",
+			option: MustOnlyContainPrintableCharacters(),
+			str:    "é\u200bx\t",
+			expected: []OffendingCharacter{
+				{Rune: 0x200B, ByteOffset: 2, RuneIndex: 1, Escaped: "U+200B ZERO WIDTH SPACE"},
+				{Rune: '\t', ByteOffset: 6, RuneIndex: 3, Escaped: "U+0009 CHARACTER TABULATION"},
+			},
+		},
+		{
+			name:   "not ascii",
+			option: MustOnlyContainASCIICharacters(),
+			str:    "naïve",
+			expected: []OffendingCharacter{
+				{Rune: 'ï', ByteOffset: 2, RuneIndex: 2, Escaped: "U+00EF LATIN SMALL LETTER I WITH DIAERESIS"},
+			},
+		},
+		{
+			name:   "disallowed characters",
+			option: MustNotContainAnyOf([]rune("<>")),
+			str:    "<b>",
+			expected: []OffendingCharacter{
+				{Rune: '<', ByteOffset: 0, RuneIndex: 0, Escaped: "U+003C LESS-THAN SIGN"},
+				{Rune: '>', ByteOffset: 2, RuneIndex: 2, Escaped: "U+003E GREATER-THAN SIGN"},
+			},
+		},
+		{
+			name:   "not alphanumeric",
+			option: MustBeAlphaNumeric(),
+			str:    "user_1",
+			expected: []OffendingCharacter{
+				{Rune: '_', ByteOffset: 4, RuneIndex: 4, Escaped: "U+005F LOW LINE"},
+			},
+		},
+		{
+			name:     "empty string is not alphanumeric",
+			option:   MustBeAlphaNumeric(),
+			str:      "",
+			expected: nil,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateString(tt.str, tt.option)
+			if result.Valid {
+				t.Fatalf("ValidateString(%q) is valid, want an error", tt.str)
+			}
+
+			if got := result.Errors[0].Characters; !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Characters = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests Snippet(str string, characters []OffendingCharacter) string
+func TestSnippet(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		str      string
+		option   StringValidationOption
+		expected string
+	}{
+		{
+			name:     "invisible character",
+			str:      "ab\u200bcd",
+			option:   MustOnlyContainPrintableCharacters(),
+			expected: "ab\\u200bcd\n  ^^^^^^",
+		},
+		{
+			name:     "visible characters",
+			str:      "a<b>c",
+			option:   MustNotContainAnyOf([]rune("<>")),
+			expected: "a<b>c\n ^ ^",
+		},
+		{
+			name:     "wide characters before the offending character",
+			str:      "東京\tx",
+			option:   MustOnlyContainPrintableCharacters(),
+			expected: "東京\\tx\n    ^^",
+		},
+		{
+			name:     "combining mark",
+			str:      "e\u0301!",
+			option:   MustOnlyContainASCIICharacters(),
+			expected: "e\\u0301!\n ^^^^^^",
+		},
+		{
+			name:     "long string",
+			str:      strings.Repeat("a", 40) + "\x00" + strings.Repeat("b", 40),
+			option:   MustOnlyContainPrintableCharacters(),
+			expected: "..." + strings.Repeat("a", 24) + "\\u0000" + strings.Repeat("b", 24) + "...\n" + strings.Repeat(" ", 27) + "^^^^^^",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateString(tt.str, tt.option)
+			if result.Valid {
+				t.Fatalf("ValidateString(%q) is valid, want an error", tt.str)
+			}
+
+			if got := result.Errors[0].Snippet(); got != tt.expected {
+				t.Errorf("Snippet() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+
+	if got := Snippet("a\nb", nil); got != "a\\nb" {
+		t.Errorf("Snippet() without characters = %q, want %q", got, "a\\nb")
+	}
+}
@@ -0,0 +1,30 @@
+// Package strval validates strings, typically the text fields of request objects in a Web API.
+//
+// A string is validated against a list of StringValidationOption values:
+//
+//	result := strval.ValidateStringWithName(req.Username, "username",
+//		strval.MustNotBeEmpty(),
+//		strval.MustHaveMaxLengthOf(32),
+//		strval.MustBeAlphaNumeric(),
+//	)
+//
+// # Performance
+//
+// None of the built-in options compile regular expressions while validating, and
+// validating a string that passes every option does not allocate. To stay on the
+// allocation-free path, build the options once (for example in a package level
+// variable) and reuse them, since each option constructor allocates a closure:
+//
+//	var usernameOptions = []strval.StringValidationOption{
+//		strval.MustNotBeEmpty(),
+//		strval.MustHaveMaxLengthOf(32),
+//		strval.MustBeAlphaNumeric(),
+//	}
+//
+//	result := strval.ValidateStringWithName(req.Username, "username", usernameOptions...)
+//
+// Allocations only happen when an option fails, to build its FieldError and message.
+// The benchmarks in benchmark_test.go cover every built-in option:
+//
+//	go test -run NONE -bench . -benchmem
+package strval
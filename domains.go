@@ -0,0 +1,212 @@
+package strval
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+//go:embed data/disposable_domains.txt
+var disposableDomainsData string
+
+//go:embed data/role_accounts.txt
+var roleAccountsData string
+
+// DisposableDomains is the list of throwaway email domains used by MustNotBeDisposableEmail.
+// It starts out with the list embedded in the package and can be replaced at runtime with Load or LoadFile.
+var DisposableDomains = mustParseNameList(disposableDomainsData, true)
+
+// RoleAccounts is the list of role account local parts (admin, support, ...) used by MustNotBeRoleAccount.
+// It starts out with the list embedded in the package and can be replaced at runtime with Load or LoadFile.
+var RoleAccounts = mustParseNameList(roleAccountsData, false)
+
+// A NameList is a set of domains or email local parts that is safe for concurrent use.
+// Names are matched case-insensitively. A domain list also matches the subdomains of its entries.
+// The zero value is an empty list of local parts, use NewDomainList for domains.
+type NameList struct {
+	mu         sync.RWMutex
+	names      map[string]struct{}
+	subdomains bool
+}
+
+// NewDomainList creates a list of domains which also matches the subdomains of its entries
+func NewDomainList(domains ...string) *NameList {
+	return newNameList(domains, true)
+}
+
+// NewLocalPartList creates a list of email local parts
+func NewLocalPartList(localParts ...string) *NameList {
+	return newNameList(localParts, false)
+}
+
+// newNameList creates a NameList holding the given names
+func newNameList(names []string, subdomains bool) *NameList {
+	l := &NameList{names: make(map[string]struct{}, len(names)), subdomains: subdomains}
+	for _, name := range names {
+		if name = normalizeName(name); name != "" {
+			l.names[name] = struct{}{}
+		}
+	}
+	return l
+}
+
+// mustParseNameList creates a NameList from one of the embedded lists
+func mustParseNameList(data string, subdomains bool) *NameList {
+	l := &NameList{subdomains: subdomains}
+	if err := l.Load(strings.NewReader(data)); err != nil {
+		panic("strval: invalid embedded list: " + err.Error())
+	}
+	return l
+}
+
+// Load replaces the contents of the list with the names read from r.
+// The input holds one name per line; blank lines and lines starting with # are ignored.
+func (l *NameList) Load(r io.Reader) error {
+	names := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names[normalizeName(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.names = names
+	l.mu.Unlock()
+
+	return nil
+}
+
+// LoadFile replaces the contents of the list with the names in a local file, see Load for the format
+func (l *NameList) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return l.Load(f)
+}
+
+// Add adds names to the list
+func (l *NameList) Add(names ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.names == nil {
+		l.names = make(map[string]struct{}, len(names))
+	}
+
+	for _, name := range names {
+		if name = normalizeName(name); name != "" {
+			l.names[name] = struct{}{}
+		}
+	}
+}
+
+// Len returns the number of names in the list
+func (l *NameList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.names)
+}
+
+// Contains reports whether name is in the list, or for a domain list whether name is a subdomain of a listed domain
+func (l *NameList) Contains(name string) bool {
+	name = normalizeName(name)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for name != "" {
+		if _, ok := l.names[name]; ok {
+			return true
+		}
+
+		if !l.subdomains {
+			return false
+		}
+
+		// Strip the leftmost label and try the parent domain
+		i := strings.IndexByte(name, '.')
+		if i < 0 {
+			return false
+		}
+		name = name[i+1:]
+	}
+
+	return false
+}
+
+// This option will validate that the string is not an address at a disposable email domain
+// The domain is everything after the last @; strings without an @ are left to the email format options
+func MustNotBeDisposableEmail() StringValidationOption {
+	return func(str, strName string) error {
+		if _, domain, ok := splitEmail(str); ok && DisposableDomains.Contains(domain) {
+			return newFieldError(strName, str, RuleDisposableEmail, map[string]any{"domain": domain})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the string is not a role account address such as admin@ or support@
+// Any +tag in the local part is ignored; strings without an @ are left to the email format options
+func MustNotBeRoleAccount() StringValidationOption {
+	return func(str, strName string) error {
+		local, _, ok := splitEmail(str)
+		if !ok {
+			return nil
+		}
+
+		if tag := strings.IndexByte(local, '+'); tag >= 0 {
+			local = local[:tag]
+		}
+
+		if RoleAccounts.Contains(local) {
+			return newFieldError(strName, str, RuleRoleAccount, map[string]any{"local": local})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the string is an address at one of the allowed domains or their subdomains
+func MustHaveEmailDomainIn(allowlist []string) StringValidationOption {
+	allowed := NewDomainList(allowlist...)
+	domains := strings.Join(allowlist, ", ")
+
+	return func(str, strName string) error {
+		if _, domain, ok := splitEmail(str); !ok || !allowed.Contains(domain) {
+			return newFieldError(strName, str, RuleEmailDomain, map[string]any{"domains": domains})
+		}
+
+		return nil
+	}
+}
+
+// splitEmail splits an address into its local part and domain at the last @
+func splitEmail(str string) (string, string, bool) {
+	at := strings.LastIndexByte(str, '@')
+	if at < 0 {
+		return "", "", false
+	}
+
+	return str[:at], str[at+1:], true
+}
+
+// normalizeName lowercases a name and removes surrounding whitespace and the trailing dot of a fully qualified domain
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
@@ -0,0 +1,294 @@
+package strval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Tests NameList.Contains(name string) bool
+func TestNameListContains(t *testing.T) {
+	domains := NewDomainList("example.com", "Throwaway.Email.")
+	localParts := NewLocalPartList("admin")
+
+	// Test cases
+	tests := []struct {
+		name     string
+		list     *NameList
+		query    string
+		expected bool
+	}{
+		{
+			name:     "exact domain",
+			list:     domains,
+			query:    "example.com",
+			expected: true,
+		},
+		{
+			name:     "subdomain",
+			list:     domains,
+			query:    "mail.eu.example.com",
+			expected: true,
+		},
+		{
+			name:     "case and trailing dot are ignored",
+			list:     domains,
+			query:    "MAIL.throwaway.email.",
+			expected: true,
+		},
+		{
+			name:     "suffix that is not a subdomain",
+			list:     domains,
+			query:    "notexample.com",
+			expected: false,
+		},
+		{
+			name:     "parent domain",
+			list:     domains,
+			query:    "com",
+			expected: false,
+		},
+		{
+			name:     "local part",
+			list:     localParts,
+			query:    "ADMIN",
+			expected: true,
+		},
+		{
+			name:     "local parts do not match by suffix",
+			list:     localParts,
+			query:    "site.admin",
+			expected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.list.Contains(tt.query); got != tt.expected {
+				t.Errorf("Contains(%q) = %v, want %v", tt.query, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests NameList.Load(r io.Reader) error and NameList.LoadFile(path string) error
+func TestNameListLoad(t *testing.T) {
+	list := NewDomainList("old.example")
+
+	if err := list.Load(strings.NewReader("# comment\n\nnew.example\n  Other.Example  \n")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if list.Contains("old.example") || !list.Contains("new.example") || !list.Contains("other.example") || list.Len() != 2 {
+		t.Errorf("Load() did not replace the list contents")
+	}
+
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	if err := os.WriteFile(path, []byte("file.example\n"), 0o600); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+
+	if err := list.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	if !list.Contains("sub.file.example") || list.Len() != 1 {
+		t.Errorf("LoadFile() did not replace the list contents")
+	}
+
+	if err := list.LoadFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("LoadFile() expected an error for a missing file")
+	}
+
+	if !list.Contains("file.example") {
+		t.Errorf("LoadFile() failure should leave the list unchanged")
+	}
+}
+
+// Tests that the zero value NameList is an empty list that names can be added to
+func TestNameListZeroValue(t *testing.T) {
+	var list NameList
+
+	if list.Contains("admin") || list.Len() != 0 {
+		t.Fatalf("zero value NameList is not empty")
+	}
+
+	list.Add("Admin", "")
+
+	if !list.Contains("admin") || list.Len() != 1 {
+		t.Errorf("Add() on the zero value did not add the name")
+	}
+}
+
+// Tests StringValidationOption MustNotBeDisposableEmail()
+func TestMustNotBeDisposableEmail(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "regular domain",
+			str:         "test@email.com",
+			errExpected: false,
+		},
+		{
+			name:        "disposable domain",
+			str:         "test@mailinator.com",
+			errExpected: true,
+		},
+		{
+			name:        "disposable subdomain in upper case",
+			str:         "test@Inbox.YOPMAIL.com",
+			errExpected: true,
+		},
+		{
+			name:        "not an email",
+			str:         "mailinator.com",
+			errExpected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustNotBeDisposableEmail()(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Errorf("MustNotBeDisposableEmail() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			// Make sure the strName is in the error message
+			if errFound && !strings.Contains(err.Error(), "str") {
+				t.Errorf("MustNotBeDisposableEmail() strName error = %v, expected to contain strName str", err)
+			}
+		})
+	}
+}
+
+// Tests that the disposable domain list can be replaced at runtime
+func TestMustNotBeDisposableEmailReload(t *testing.T) {
+	original := DisposableDomains.names
+	defer func() {
+		DisposableDomains.mu.Lock()
+		DisposableDomains.names = original
+		DisposableDomains.mu.Unlock()
+	}()
+
+	option := MustNotBeDisposableEmail()
+
+	if err := DisposableDomains.Load(strings.NewReader("burner.example\n")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if err := option("test@burner.example", "str"); err == nil {
+		t.Errorf("MustNotBeDisposableEmail() expected the reloaded list to be used")
+	}
+
+	if err := option("test@mailinator.com", "str"); err != nil {
+		t.Errorf("MustNotBeDisposableEmail() error = %v, expected the embedded list to be replaced", err)
+	}
+}
+
+// Tests StringValidationOption MustNotBeRoleAccount()
+func TestMustNotBeRoleAccount(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "personal address",
+			str:         "jane.doe@example.com",
+			errExpected: false,
+		},
+		{
+			name:        "role account",
+			str:         "admin@example.com",
+			errExpected: true,
+		},
+		{
+			name:        "role account with tag and upper case",
+			str:         "Support+tickets@example.com",
+			errExpected: true,
+		},
+		{
+			name:        "role name as part of a personal address",
+			str:         "admin.jane@example.com",
+			errExpected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustNotBeRoleAccount()(tt.str, "str")
+
+			if (err != nil) != tt.errExpected {
+				t.Errorf("MustNotBeRoleAccount() error = %v, wantErr %v", err, tt.errExpected)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustHaveEmailDomainIn()
+func TestMustHaveEmailDomainIn(t *testing.T) {
+	option := MustHaveEmailDomainIn([]string{"example.com", "example.org"})
+
+	// Test cases
+	tests := []struct {
+		name        string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "allowed domain",
+			str:         "jane@example.com",
+			errExpected: false,
+		},
+		{
+			name:        "allowed subdomain",
+			str:         "jane@eu.example.org",
+			errExpected: false,
+		},
+		{
+			name:        "other domain",
+			str:         "jane@gmail.com",
+			errExpected: true,
+		},
+		{
+			name:        "lookalike domain",
+			str:         "jane@badexample.com",
+			errExpected: true,
+		},
+		{
+			name:        "not an email",
+			str:         "example.com",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := option(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Errorf("MustHaveEmailDomainIn() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			// Make sure the allowed domains are in the error message
+			if errFound && !strings.Contains(err.Error(), "example.com, example.org") {
+				t.Errorf("MustHaveEmailDomainIn() error = %v, expected to list the allowed domains", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package strval
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// An EmailMode selects how strictly email addresses are parsed
+type EmailMode int
+
+const (
+	// EmailHTML5 accepts the addresses accepted by an HTML5 <input type=email>:
+	// an unquoted ASCII local part and an ASCII host name domain
+	EmailHTML5 EmailMode = iota
+	// EmailRFC5322 accepts an RFC 5322 addr-spec without the obsolete syntax: dot-atom or quoted local
+	// parts and host name or address literal domains as RFC 5321 allows them for delivery
+	EmailRFC5322
+	// EmailRFC6531 extends EmailRFC5322 with the UTF-8 local parts and domains of internationalized email (SMTPUTF8)
+	EmailRFC6531
+)
+
+// The length limits of RFC 5321 section 4.5.3.1, in octets
+const (
+	maxEmailLocalPartLength = 64
+	maxEmailDomainLength    = 255
+	maxEmailAddressLength   = 254
+	maxDomainLabelLength    = 63
+)
+
+// String returns the name of the mode
+func (m EmailMode) String() string {
+	switch m {
+	case EmailHTML5:
+		return "html5"
+	case EmailRFC5322:
+		return "rfc5322"
+	case EmailRFC6531:
+		return "rfc6531"
+	default:
+		return fmt.Sprintf("EmailMode(%d)", int(m))
+	}
+}
+
+// This represents a parsed email address
+type EmailAddress struct {
+	// The local part as written, including quotes for a quoted local part
+	LocalPart string
+	// The domain as written, including brackets for an address literal
+	Domain string
+	// The address of an address literal domain such as [192.0.2.1], the zero Addr otherwise
+	Literal netip.Addr
+}
+
+// String returns the address in local@domain form
+func (a EmailAddress) String() string {
+	return a.LocalPart + "@" + a.Domain
+}
+
+// This represents the reason an email address was rejected by ParseEmail
+type EmailError struct {
+	Address string
+	Reason  string
+}
+
+// Error returns the reason the address was rejected
+func (e *EmailError) Error() string {
+	return fmt.Sprintf("invalid email address %q: %s", e.Address, e.Reason)
+}
+
+// ParseEmail parses an email address using the given mode
+// str: The address to parse, without display name or angle brackets
+// mode: How strictly the address is parsed
+// Returns the parsed address, or an *EmailError explaining why the address was rejected
+//
+// The RFC 5321 length limits (64 octets for the local part, 255 for the domain and 254 for
+// the whole address) are enforced in every mode.
+func ParseEmail(str string, mode EmailMode) (EmailAddress, error) {
+	fail := func(format string, args ...any) (EmailAddress, error) {
+		return EmailAddress{}, &EmailError{Address: str, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if str == "" {
+		return fail("address is empty")
+	}
+
+	if !utf8.ValidString(str) {
+		return fail("address is not valid UTF-8")
+	}
+
+	at := strings.LastIndexByte(str, '@')
+	if at < 0 {
+		return fail("missing @ separator")
+	}
+
+	local, domain := str[:at], str[at+1:]
+
+	if local == "" {
+		return fail("local part is empty")
+	}
+
+	if domain == "" {
+		return fail("domain is empty")
+	}
+
+	if len(local) > maxEmailLocalPartLength {
+		return fail("local part is %d octets long, the maximum is %d", len(local), maxEmailLocalPartLength)
+	}
+
+	if len(domain) > maxEmailDomainLength {
+		return fail("domain is %d octets long, the maximum is %d", len(domain), maxEmailDomainLength)
+	}
+
+	if len(str) > maxEmailAddressLength {
+		return fail("address is %d octets long, the maximum is %d", len(str), maxEmailAddressLength)
+	}
+
+	if reason := checkEmailLocalPart(local, mode); reason != "" {
+		return fail("%s", reason)
+	}
+
+	address := EmailAddress{LocalPart: local, Domain: domain}
+
+	if strings.HasPrefix(domain, "[") {
+		if mode == EmailHTML5 {
+			return fail("address literal domains are not allowed")
+		}
+
+		literal, reason := parseAddressLiteral(domain)
+		if reason != "" {
+			return fail("%s", reason)
+		}
+		address.Literal = literal
+
+		return address, nil
+	}
+
+	if reason := checkEmailDomain(domain, mode); reason != "" {
+		return fail("%s", reason)
+	}
+
+	return address, nil
+}
+
+// This option will validate that the string is an email address, parsed with the given mode
+// Unlike MustBeValidEmailFormat, the error explains why the address was rejected
+func MustBeValidEmail(mode EmailMode) StringValidationOption {
+	return func(str, strName string) error {
+		if _, err := ParseEmail(str, mode); err != nil {
+			return newFieldError(strName, str, RuleEmailAddress, map[string]any{"mode": mode.String(), "reason": err.(*EmailError).Reason})
+		}
+
+		return nil
+	}
+}
+
+// checkEmailLocalPart returns the reason a local part is invalid, or an empty string if it is valid
+func checkEmailLocalPart(local string, mode EmailMode) string {
+	if strings.HasPrefix(local, `"`) {
+		if mode == EmailHTML5 {
+			return "quoted local parts are not allowed"
+		}
+		return checkQuotedLocalPart(local, mode)
+	}
+
+	for i, char := range local {
+		switch {
+		case char == '.':
+			if mode == EmailHTML5 {
+				// HTML5 accepts dots anywhere in the local part
+				continue
+			}
+			if i == 0 {
+				return "local part must not start with a dot"
+			}
+			if i == len(local)-1 {
+				return "local part must not end with a dot"
+			}
+			if local[i+1] == '.' {
+				return "local part must not contain consecutive dots"
+			}
+		case isAtext(char):
+		case char > unicode.MaxASCII && mode == EmailRFC6531:
+			if !unicode.IsGraphic(char) {
+				return fmt.Sprintf("local part contains the non-graphic character %U", char)
+			}
+		case char > unicode.MaxASCII:
+			return fmt.Sprintf("local part contains the non-ASCII character %q, which requires RFC 6531 mode", char)
+		default:
+			return fmt.Sprintf("local part contains the invalid character %q", char)
+		}
+	}
+
+	return ""
+}
+
+// checkQuotedLocalPart returns the reason a quoted local part is invalid, or an empty string if it is valid
+func checkQuotedLocalPart(local string, mode EmailMode) string {
+	if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+		return "quoted local part is not terminated"
+	}
+
+	content := local[1 : len(local)-1]
+	escaped := false
+
+	for _, char := range content {
+		switch {
+		case escaped:
+			// quoted-pair = "\" (VCHAR / WSP)
+			if char != ' ' && char != '\t' && (char < '!' || char > '~') && !(char > unicode.MaxASCII && mode == EmailRFC6531) {
+				return fmt.Sprintf("quoted local part escapes the invalid character %q", char)
+			}
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			return "quoted local part contains an unescaped quote"
+		case char == ' ' || char == '\t' || (char >= '!' && char <= '~'):
+		case char > unicode.MaxASCII && mode == EmailRFC6531:
+			if !unicode.IsGraphic(char) {
+				return fmt.Sprintf("quoted local part contains the non-graphic character %U", char)
+			}
+		case char > unicode.MaxASCII:
+			return fmt.Sprintf("quoted local part contains the non-ASCII character %q, which requires RFC 6531 mode", char)
+		default:
+			return fmt.Sprintf("quoted local part contains the invalid character %q", char)
+		}
+	}
+
+	if escaped {
+		return "quoted local part ends with an incomplete escape"
+	}
+
+	return ""
+}
+
+// checkEmailDomain returns the reason a host name domain is invalid, or an empty string if it is valid
+func checkEmailDomain(domain string, mode EmailMode) string {
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" {
+			return "domain contains an empty label"
+		}
+
+		if len(label) > maxDomainLabelLength {
+			return fmt.Sprintf("domain label %q is %d octets long, the maximum is %d", label, len(label), maxDomainLabelLength)
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Sprintf("domain label %q must not start or end with a hyphen", label)
+		}
+
+		for _, char := range label {
+			switch {
+			case char == '-', isASCIIAlphaNumeric(char):
+			case char > unicode.MaxASCII && mode == EmailRFC6531:
+				if !unicode.IsLetter(char) && !unicode.IsMark(char) && !unicode.IsDigit(char) {
+					return fmt.Sprintf("domain label %q contains the invalid character %q", label, char)
+				}
+			case char > unicode.MaxASCII:
+				return fmt.Sprintf("domain contains the non-ASCII character %q, which requires RFC 6531 mode", char)
+			default:
+				return fmt.Sprintf("domain label %q contains the invalid character %q", label, char)
+			}
+		}
+	}
+
+	return ""
+}
+
+// parseAddressLiteral parses an RFC 5321 address literal such as [192.0.2.1] or [IPv6:2001:db8::1]
+func parseAddressLiteral(domain string) (netip.Addr, string) {
+	if !strings.HasSuffix(domain, "]") {
+		return netip.Addr{}, "address literal is not terminated"
+	}
+
+	literal := domain[1 : len(domain)-1]
+
+	if len(literal) > 5 && strings.EqualFold(literal[:5], "IPv6:") {
+		addr, err := netip.ParseAddr(literal[5:])
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return netip.Addr{}, fmt.Sprintf("address literal %q is not a valid IPv6 address", literal)
+		}
+		return addr, ""
+	}
+
+	addr, err := netip.ParseAddr(literal)
+	if err != nil || !addr.Is4() {
+		return netip.Addr{}, fmt.Sprintf("address literal %q is not a valid IPv4 address", literal)
+	}
+
+	return addr, ""
+}
+
+// isAtext checks if a character is an RFC 5322 atext character
+func isAtext(char rune) bool {
+	if isASCIIAlphaNumeric(char) {
+		return true
+	}
+
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", char)
+}
+
+// isASCIIAlphaNumeric checks if a character is an ASCII letter or digit
+func isASCIIAlphaNumeric(char rune) bool {
+	if char > unicode.MaxASCII {
+		return false
+	}
+
+	c := byte(char)
+	return isASCIIDigit(c) || isASCIIUpper(c) || isASCIILower(c)
+}
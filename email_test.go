@@ -0,0 +1,304 @@
+package strval
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Tests ParseEmail(str string, mode EmailMode) (EmailAddress, error)
+func TestParseEmail(t *testing.T) {
+	long := strings.Repeat("a", 64)
+
+	// Test cases
+	tests := []struct {
+		name   string
+		str    string
+		html5  bool
+		rfc    bool
+		smtp8  bool
+		reason string
+	}{
+		{
+			name:  "simple address",
+			str:   "test@email.com",
+			html5: true, rfc: true, smtp8: true,
+		},
+		{
+			name:  "special characters in local part",
+			str:   "first.last+tag!#$%&'*/=?^_`{|}~-@example.co.uk",
+			html5: true, rfc: true, smtp8: true,
+		},
+		{
+			name:  "single label domain",
+			str:   "admin@localhost",
+			html5: true, rfc: true, smtp8: true,
+		},
+		{
+			name:   "consecutive dots",
+			str:    "a..b@x.com",
+			html5:  true,
+			reason: "consecutive dots",
+		},
+		{
+			name:   "leading dot",
+			str:    ".ab@x.com",
+			html5:  true,
+			reason: "must not start with a dot",
+		},
+		{
+			name:   "trailing dot",
+			str:    "ab.@x.com",
+			html5:  true,
+			reason: "must not end with a dot",
+		},
+		{
+			name:   "domain label starting with hyphen",
+			str:    "foo@-bar.com",
+			reason: "must not start or end with a hyphen",
+		},
+		{
+			name:   "domain label ending with hyphen",
+			str:    "foo@bar-.com",
+			reason: "must not start or end with a hyphen",
+		},
+		{
+			name:   "empty domain label",
+			str:    "foo@bar..com",
+			reason: "empty label",
+		},
+		{
+			name:   "trailing dot in domain",
+			str:    "foo@bar.com.",
+			reason: "empty label",
+		},
+		{
+			name: "quoted local part",
+			str:  `"john doe"@example.com`,
+			rfc:  true, smtp8: true,
+			reason: "quoted local parts are not allowed",
+		},
+		{
+			name: "quoted local part with escapes and @",
+			str:  `"a\"b@c"@example.com`,
+			rfc:  true, smtp8: true,
+			reason: "quoted local parts are not allowed",
+		},
+		{
+			name:   "unterminated quoted local part",
+			str:    `"abc@example.com`,
+			reason: "quoted local part",
+		},
+		{
+			name:   "unescaped quote in quoted local part",
+			str:    `"a"b"@example.com`,
+			reason: "unescaped quote",
+		},
+		{
+			name: "ipv4 literal",
+			str:  "user@[192.0.2.1]",
+			rfc:  true, smtp8: true,
+			reason: "address literal domains are not allowed",
+		},
+		{
+			name: "ipv6 literal",
+			str:  "user@[IPv6:2001:db8::1]",
+			rfc:  true, smtp8: true,
+			reason: "address literal domains are not allowed",
+		},
+		{
+			name:   "invalid ipv4 literal",
+			str:    "user@[300.0.2.1]",
+			reason: "address literal",
+		},
+		{
+			name:   "ipv6 literal without tag",
+			str:    "user@[2001:db8::1]",
+			reason: "not a valid IPv4 address",
+		},
+		{
+			name:   "unterminated literal",
+			str:    "user@[192.0.2.1",
+			reason: "address literal",
+		},
+		{
+			name:   "internationalized address",
+			str:    "用户@例子.广告",
+			smtp8:  true,
+			reason: "requires RFC 6531 mode",
+		},
+		{
+			name:   "internationalized local part",
+			str:    "josé@example.com",
+			smtp8:  true,
+			reason: "requires RFC 6531 mode",
+		},
+		{
+			name:   "missing @",
+			str:    "abc",
+			reason: "missing @",
+		},
+		{
+			name:   "empty local part",
+			str:    "@example.com",
+			reason: "local part is empty",
+		},
+		{
+			name:   "empty domain",
+			str:    "abc@",
+			reason: "domain is empty",
+		},
+		{
+			name:   "two @ signs",
+			str:    "a@b@example.com",
+			reason: "invalid character '@'",
+		},
+		{
+			name:   "space in local part",
+			str:    "a b@example.com",
+			reason: "invalid character ' '",
+		},
+		{
+			name:  "local part at the length limit",
+			str:   long + "@example.com",
+			html5: true, rfc: true, smtp8: true,
+		},
+		{
+			name:   "local part too long",
+			str:    long + "a@example.com",
+			reason: "local part is 65 octets long",
+		},
+		{
+			name:   "domain label too long",
+			str:    "a@" + long + ".com",
+			reason: "the maximum is 63",
+		},
+		{
+			name:   "address too long",
+			str:    "a@" + strings.Repeat("abcdefghi.", 25) + "com",
+			reason: "the maximum is 254",
+		},
+		{
+			name:   "empty string",
+			str:    "",
+			reason: "address is empty",
+		},
+	}
+
+	modes := []EmailMode{EmailHTML5, EmailRFC5322, EmailRFC6531}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastRejecting := -1
+			for i, valid := range []bool{tt.html5, tt.rfc, tt.smtp8} {
+				if !valid {
+					lastRejecting = i
+				}
+			}
+
+			for i, valid := range []bool{tt.html5, tt.rfc, tt.smtp8} {
+				address, err := ParseEmail(tt.str, modes[i])
+
+				if valid {
+					if err != nil {
+						t.Errorf("ParseEmail(%q, %s) error = %v, want nil", tt.str, modes[i], err)
+					} else if address.String() != tt.str {
+						t.Errorf("ParseEmail(%q, %s) = %q, want the address unchanged", tt.str, modes[i], address)
+					}
+					continue
+				}
+
+				var emailErr *EmailError
+				if !errors.As(err, &emailErr) {
+					t.Errorf("ParseEmail(%q, %s) error = %v, want an *EmailError", tt.str, modes[i], err)
+					continue
+				}
+
+				// The expected reason is the one given by the most permissive mode that rejects the address
+				if i == lastRejecting && !strings.Contains(emailErr.Reason, tt.reason) {
+					t.Errorf("ParseEmail(%q, %s) reason = %q, want it to contain %q", tt.str, modes[i], emailErr.Reason, tt.reason)
+				}
+			}
+		})
+	}
+}
+
+// Tests the parsed parts of an email address
+func TestParseEmailParts(t *testing.T) {
+	address, err := ParseEmail(`"john doe"@[IPv6:2001:db8::1]`, EmailRFC5322)
+	if err != nil {
+		t.Fatalf("ParseEmail() error = %v", err)
+	}
+
+	if address.LocalPart != `"john doe"` || address.Domain != "[IPv6:2001:db8::1]" {
+		t.Errorf("ParseEmail() = %+v, want local part and domain as written", address)
+	}
+
+	if !address.Literal.Is6() || address.Literal.String() != "2001:db8::1" {
+		t.Errorf("ParseEmail() Literal = %v, want 2001:db8::1", address.Literal)
+	}
+}
+
+// Tests StringValidationOption MustBeValidEmail()
+func TestMustBeValidEmail(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		mode        EmailMode
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "valid html5 address",
+			mode:        EmailHTML5,
+			str:         "test@email.com",
+			errExpected: false,
+		},
+		{
+			name:        "quoted local part in html5 mode",
+			mode:        EmailHTML5,
+			str:         `"a b"@email.com`,
+			errExpected: true,
+		},
+		{
+			name:        "internationalized address in rfc 6531 mode",
+			mode:        EmailRFC6531,
+			str:         "用户@例子.广告",
+			errExpected: false,
+		},
+		{
+			name:        "consecutive dots in rfc 5322 mode",
+			mode:        EmailRFC5322,
+			str:         "a..b@x.com",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustBeValidEmail(tt.mode)(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Fatalf("MustBeValidEmail() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			if !errFound {
+				return
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Rule != RuleEmailAddress || fieldErr.Params["reason"] == "" {
+				t.Errorf("MustBeValidEmail() error = %+v, want email_address error with a reason", err)
+			}
+
+			// Make sure the strName and the reason are in the error message
+			if !strings.HasPrefix(err.Error(), "str must be a valid email address: ") {
+				t.Errorf("MustBeValidEmail() error = %v, expected to contain strName and reason", err)
+			}
+		})
+	}
+}
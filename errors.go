@@ -0,0 +1,157 @@
+package strval
+
+import "errors"
+
+// Rule codes identifying the rule that produced a FieldError
+const (
+	RuleMinLength          = "min_length"
+	RuleMaxLength          = "max_length"
+	RuleLengthBetween      = "length_between"
+	RuleNotEmpty           = "not_empty"
+	RuleAlphaNumeric       = "alphanumeric"
+	RuleContainsNumbers    = "contains_numbers"
+	RuleContainsAtLeastOne = "contains_at_least_one"
+	RuleNotContainAnyOf    = "not_contain_any_of"
+	RuleContainsUppercase  = "contains_uppercase"
+	RuleContainsLowercase  = "contains_lowercase"
+	RulePrintable          = "printable"
+	RuleASCII              = "ascii"
+	RuleEmail              = "email"
+	RuleOneOf              = "one_of"
+	RuleRegex              = "regex"
+	RuleEmailAddress       = "email_address"
+	RuleDisposableEmail    = "disposable_email"
+	RuleRoleAccount        = "role_account"
+	RuleEmailDomain        = "email_domain"
+	RuleAnyOf              = "any_of"
+	RuleAllOf              = "all_of"
+	RuleNot                = "not"
+	RulePasswordCommon     = "password_common"
+	RulePasswordStrength   = "password_strength"
+	RuleMixedScript        = "mixed_script"
+	RuleSingleScript       = "single_script"
+	RuleConfusable         = "confusable"
+	RulePRECIS             = "precis"
+	RuleMinValue           = "min_value"
+	RuleMaxValue           = "max_value"
+	RuleValueBetween       = "value_between"
+	RulePositive           = "positive"
+	RuleMultipleOf         = "multiple_of"
+	RuleBefore             = "before"
+	RuleAfter              = "after"
+	RuleTimeBetween        = "time_between"
+	RuleMinItems           = "min_items"
+	RuleMaxItems           = "max_items"
+	RuleUniqueItems        = "unique_items"
+	RuleEach               = "each"
+	RuleRequiredKeys       = "required_keys"
+	RuleURL                = "url"
+	RuleURLScheme          = "url_scheme"
+	RuleURLHost            = "url_host"
+	RuleURLIPHost          = "url_ip_host"
+	RuleURLUserInfo        = "url_user_info"
+	RuleURLFragment        = "url_fragment"
+	RuleRedirectURI        = "redirect_uri"
+
+	// RuleCustom is used for errors returned by options that do not produce a FieldError
+	RuleCustom = "custom"
+)
+
+// Message keys of the warnings and suggestions of a weak password, rendered from the catalogs like the rules.
+// The feedback of a RulePasswordStrength error lists them in its warning_key and suggestion_keys parameters.
+const (
+	PasswordWarningTop10                 = "password_warning_top10"
+	PasswordWarningTop100                = "password_warning_top100"
+	PasswordWarningVeryCommon            = "password_warning_very_common"
+	PasswordWarningSimilarToCommon       = "password_warning_similar_to_common"
+	PasswordWarningSingleWord            = "password_warning_single_word"
+	PasswordWarningUserInputs            = "password_warning_user_inputs"
+	PasswordWarningShortKeyboardPattern  = "password_warning_short_keyboard_pattern"
+	PasswordWarningStraightRow           = "password_warning_straight_row"
+	PasswordWarningRepeatedPattern       = "password_warning_repeated_pattern"
+	PasswordWarningRepeatedCharacter     = "password_warning_repeated_character"
+	PasswordWarningSequence              = "password_warning_sequence"
+	PasswordWarningDate                  = "password_warning_date"
+	PasswordWarningRecentYear            = "password_warning_recent_year"
+	PasswordSuggestionFewWords           = "password_suggestion_few_words"
+	PasswordSuggestionNoComposition      = "password_suggestion_no_composition"
+	PasswordSuggestionAddWords           = "password_suggestion_add_words"
+	PasswordSuggestionAllUppercase       = "password_suggestion_all_uppercase"
+	PasswordSuggestionCapitalization     = "password_suggestion_capitalization"
+	PasswordSuggestionReversed           = "password_suggestion_reversed"
+	PasswordSuggestionSubstitutions      = "password_suggestion_substitutions"
+	PasswordSuggestionLongerKeyboardWalk = "password_suggestion_longer_keyboard_walk"
+	PasswordSuggestionAvoidRepeats       = "password_suggestion_avoid_repeats"
+	PasswordSuggestionAvoidSequences     = "password_suggestion_avoid_sequences"
+	PasswordSuggestionAvoidDates         = "password_suggestion_avoid_dates"
+
+	// PasswordWarningSentence turns a warning into the first sentence of the feedback, e.g. {warning}.
+	PasswordWarningSentence = "password_warning_sentence"
+	// PasswordFeedbackSeparator separates the sentences of the feedback, a space in most languages
+	PasswordFeedbackSeparator = "password_feedback_separator"
+)
+
+// This represents a single failed rule for a validated string
+type FieldError struct {
+	// The name of the validated string
+	Field string `json:"field"`
+	// A stable code identifying the rule that failed, e.g. min_length
+	Rule string `json:"rule"`
+	// The parameters the rule was configured with, e.g. {"min": 8}
+	Params map[string]any `json:"params,omitempty"`
+	// The offending value
+	Value string `json:"value"`
+	// The rendered, human readable message
+	Message string `json:"message"`
+	// The failures of the nested options, for rules combining several options
+	Causes []*FieldError `json:"causes,omitempty"`
+	// The characters that made the rule fail, for rules on the characters of the string
+	Characters []OffendingCharacter `json:"characters,omitempty"`
+	// How serious the failure is, SeverityError unless set with WithSeverity
+	Severity Severity `json:"severity,omitempty"`
+
+	// Set when Message was supplied by the caller rather than rendered from a catalog
+	custom bool
+	// Set by Bail to stop the evaluation of the options that follow
+	bail bool
+}
+
+// Error returns the rendered message so a FieldError can be used as an error
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// newFieldError creates a FieldError with its message rendered from the English catalog
+func newFieldError(field, value, rule string, params map[string]any) *FieldError {
+	return &FieldError{
+		Field:   field,
+		Rule:    rule,
+		Params:  params,
+		Value:   value,
+		Message: renderMessage(englishCatalog[rule], field, params),
+	}
+}
+
+// withMessage replaces the message of a FieldError with a caller supplied template
+// Caller supplied messages are left untouched by translators
+func withMessage(fieldErr *FieldError, message string) *FieldError {
+	fieldErr.Message = renderMessage(message, fieldErr.Field, fieldErr.Params)
+	fieldErr.custom = true
+
+	return fieldErr
+}
+
+// asFieldError converts any error returned by an option into a FieldError
+func asFieldError(err error, field, value string) *FieldError {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr
+	}
+
+	return &FieldError{
+		Field:   field,
+		Rule:    RuleCustom,
+		Value:   value,
+		Message: err.Error(),
+	}
+}
@@ -0,0 +1,154 @@
+package strval
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Tests that every built-in StringValidationOption returns a FieldError
+func TestFieldError(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		option         StringValidationOption
+		str            string
+		expectedRule   string
+		expectedParams map[string]any
+	}{
+		{
+			name:           "min length",
+			option:         MustHaveMinLengthOf(8),
+			str:            "short",
+			expectedRule:   RuleMinLength,
+			expectedParams: map[string]any{"min": 8},
+		},
+		{
+			name:           "max length",
+			option:         MustHaveMaxLengthOf(2),
+			str:            "long",
+			expectedRule:   RuleMaxLength,
+			expectedParams: map[string]any{"max": 2},
+		},
+		{
+			name:         "not empty",
+			option:       MustNotBeEmpty(),
+			str:          " ",
+			expectedRule: RuleNotEmpty,
+		},
+		{
+			name:         "alphanumeric",
+			option:       MustBeAlphaNumeric(),
+			str:          "a-b",
+			expectedRule: RuleAlphaNumeric,
+		},
+		{
+			name:         "contains numbers",
+			option:       MustContainNumbers(),
+			str:          "abc",
+			expectedRule: RuleContainsNumbers,
+		},
+		{
+			name:           "contains at least one",
+			option:         MustContainAtLeastOne([]rune("!?")),
+			str:            "abc",
+			expectedRule:   RuleContainsAtLeastOne,
+			expectedParams: map[string]any{"characters": "!?"},
+		},
+		{
+			name:           "not contain any of",
+			option:         MustNotContainAnyOf([]rune("!?")),
+			str:            "abc!",
+			expectedRule:   RuleNotContainAnyOf,
+			expectedParams: map[string]any{"characters": "!?"},
+		},
+		{
+			name:         "contains uppercase",
+			option:       MustContainUppercaseLetter(),
+			str:          "abc",
+			expectedRule: RuleContainsUppercase,
+		},
+		{
+			name:         "contains lowercase",
+			option:       MustContainLowercaseLetter(),
+			str:          "ABC",
+			expectedRule: RuleContainsLowercase,
+		},
+		{
+			name:         "printable",
+			option:       MustOnlyContainPrintableCharacters(),
+			str:          "a\x00",
+			expectedRule: RulePrintable,
+		},
+		{
+			name:         "ascii",
+			option:       MustOnlyContainASCIICharacters(),
+			str:          "é",
+			expectedRule: RuleASCII,
+		},
+		{
+			name:         "email",
+			option:       MustBeValidEmailFormat(),
+			str:          "abc",
+			expectedRule: RuleEmail,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option(tt.str, "str")
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("option error = %#v, expected a *FieldError", err)
+			}
+
+			if fieldErr.Field != "str" || fieldErr.Value != tt.str || fieldErr.Rule != tt.expectedRule {
+				t.Errorf("FieldError = %+v, want field str, value %q and rule %s", fieldErr, tt.str, tt.expectedRule)
+			}
+
+			if len(fieldErr.Params) != 0 || len(tt.expectedParams) != 0 {
+				if !reflect.DeepEqual(fieldErr.Params, tt.expectedParams) {
+					t.Errorf("FieldError Params = %v, want %v", fieldErr.Params, tt.expectedParams)
+				}
+			}
+
+			if fieldErr.Message == "" || fieldErr.Error() != fieldErr.Message {
+				t.Errorf("FieldError Error() = %q, want the rendered message %q", fieldErr.Error(), fieldErr.Message)
+			}
+		})
+	}
+}
+
+// Test that ValidateStringWithName reports structured errors alongside messages
+func TestValidateStringWithNameErrors(t *testing.T) {
+	custom := func(str, strName string) error {
+		return errors.New(strName + " is not allowed")
+	}
+
+	result := ValidateStringWithName("", "username", MustNotBeEmpty(), MustHaveMinLengthOf(3), custom)
+
+	if result.Valid {
+		t.Fatalf("ValidateStringWithName() Valid = true, want false")
+	}
+
+	if len(result.Errors) != 3 || len(result.Messages) != 3 {
+		t.Fatalf("ValidateStringWithName() got %d errors and %d messages, want 3 of each", len(result.Errors), len(result.Messages))
+	}
+
+	expectedRules := []string{RuleNotEmpty, RuleMinLength, RuleCustom}
+	for i, fieldErr := range result.Errors {
+		if fieldErr.Rule != expectedRules[i] {
+			t.Errorf("Errors[%d].Rule = %s, want %s", i, fieldErr.Rule, expectedRules[i])
+		}
+
+		if fieldErr.Message != result.Messages[i] {
+			t.Errorf("Errors[%d].Message = %q, want %q", i, fieldErr.Message, result.Messages[i])
+		}
+
+		if fieldErr.Field != "username" {
+			t.Errorf("Errors[%d].Field = %q, want username", i, fieldErr.Field)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+//go:build ignore
+
+// This program generates charnametables.go from the Unicode Character Database.
+// Download UnicodeData.txt and NameAliases.txt for the Unicode version to support into a directory and run:
+//
+//	go run gen_charnames.go -ucd <directory> -version 15.0.0
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The general categories of the characters that are named: invisible characters and separators
+var namedCategories = map[string]bool{
+	"Cc": true,
+	"Cf": true,
+	"Zs": true,
+	"Zl": true,
+	"Zp": true,
+}
+
+// The ranges of the other characters that are named: Latin-1, General Punctuation, the variation selectors,
+// the specials and the default ignorable fillers
+var namedRanges = [][2]rune{
+	{0x0020, 0x007E},
+	{0x00A0, 0x00FF},
+	{0x034F, 0x034F},
+	{0x115F, 0x1160},
+	{0x17B4, 0x17B5},
+	{0x180B, 0x180F},
+	{0x2000, 0x206F},
+	{0x3164, 0x3164},
+	{0xFE00, 0xFE0F},
+	{0xFFA0, 0xFFA0},
+	{0xFFF0, 0xFFFF},
+	{0xE0100, 0xE01EF},
+}
+
+// A code point and its name
+type characterName struct {
+	r    rune
+	name string
+}
+
+func main() {
+	ucd := flag.String("ucd", ".", "directory holding UnicodeData.txt and NameAliases.txt")
+	version := flag.String("version", "15.0.0", "the Unicode version of the data files")
+	output := flag.String("o", "charnametables.go", "the file to write")
+	flag.Parse()
+
+	aliases, err := parseAliases(filepath.Join(*ucd, "NameAliases.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names, err := parseNames(filepath.Join(*ucd, "UnicodeData.txt"), aliases)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by gen_charnames.go. DO NOT EDIT.
+
+package strval
+
+// characterNames holds the names of the invisible characters, separators, Latin-1 and General Punctuation characters,
+// taken from UnicodeData.txt and NameAliases.txt of Unicode %s. Controls are named with their control alias.
+// See https://www.unicode.org/license.html for the Unicode license agreement.
+var characterNames = [...]characterName{
+`, *version)
+
+	for _, n := range names {
+		fmt.Fprintf(&buf, "\t{0x%04X, %q},\n", n.r, n.name)
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseNames reads the names of the selected code points of UnicodeData.txt, using the aliases for controls
+func parseNames(path string, aliases map[rune]string) ([]characterName, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []characterName
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), ";")
+		if len(fields) < 3 {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		r := rune(cp)
+
+		// Ranges such as CJK ideographs have no individual names
+		name := fields[1]
+		if strings.HasPrefix(name, "<") {
+			if name = aliases[r]; name == "" {
+				continue
+			}
+		}
+
+		if namedCategories[fields[2]] || inNamedRanges(r) {
+			names = append(names, characterName{r, name})
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].r < names[j].r
+	})
+
+	return names, scanner.Err()
+}
+
+// parseAliases reads the control aliases of NameAliases.txt, or the figment aliases of the controls without one
+func parseAliases(path string) (map[rune]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	aliases := map[rune]string{}
+	figments := map[rune]string{}
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Split(text, ";")
+		if len(fields) < 3 {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		r := rune(cp)
+
+		switch strings.TrimSpace(fields[2]) {
+		case "control":
+			if _, ok := aliases[r]; !ok {
+				aliases[r] = fields[1]
+			}
+		case "figment":
+			if _, ok := figments[r]; !ok {
+				figments[r] = fields[1]
+			}
+		}
+	}
+
+	for r, name := range figments {
+		if _, ok := aliases[r]; !ok {
+			aliases[r] = name
+		}
+	}
+
+	return aliases, scanner.Err()
+}
+
+// inNamedRanges reports whether a code point is in one of namedRanges
+func inNamedRanges(r rune) bool {
+	for _, rng := range namedRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+//go:build ignore
+
+// This program generates confusabletables.go from the data files of Unicode Security Mechanisms (UTS #39).
+// Download confusables.txt for the version to support into a directory and run:
+//
+//	go run gen_confusables.go -data <directory> -version 15.0.0
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A confusable code point and the prototype it is mapped to
+type prototype struct {
+	r         rune
+	prototype string
+}
+
+func main() {
+	data := flag.String("data", ".", "directory holding confusables.txt")
+	version := flag.String("version", "15.0.0", "the version of the data files")
+	output := flag.String("o", "confusabletables.go", "the file to write")
+	flag.Parse()
+
+	prototypes, err := parse(filepath.Join(*data, "confusables.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sort.Slice(prototypes, func(i, j int) bool {
+		return prototypes[i].r < prototypes[j].r
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by gen_confusables.go. DO NOT EDIT.
+
+package strval
+
+// confusablePrototypes maps every confusable code point to its prototype, the string it is visually confused with,
+// taken from confusables.txt of Unicode Security Mechanisms (UTS #39) version %s.
+// See https://www.unicode.org/license.html for the Unicode license agreement.
+var confusablePrototypes = [...]confusablePrototype{
+`, *version)
+
+	for _, p := range prototypes {
+		fmt.Fprintf(&buf, "\t{0x%04X, %+q},\n", p.r, p.prototype)
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse reads the mappings of confusables.txt
+func parse(path string) ([]prototype, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var prototypes []prototype
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		// The file starts with a byte order mark
+		text = strings.TrimPrefix(text, "\ufeff")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		fields := strings.Split(text, ";")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%s:%d: missing prototype", path, line)
+		}
+
+		source, err := parseCodePoints(fields[0])
+		if err != nil || len(source) != 1 {
+			return nil, fmt.Errorf("%s:%d: the source must be a single code point", path, line)
+		}
+
+		target, err := parseCodePoints(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		prototypes = append(prototypes, prototype{source[0], string(target)})
+	}
+
+	return prototypes, scanner.Err()
+}
+
+// parseCodePoints parses a sequence of hexadecimal code points separated by spaces
+func parseCodePoints(text string) ([]rune, error) {
+	var runes []rune
+
+	for _, hex := range strings.Fields(text) {
+		r, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		runes = append(runes, rune(r))
+	}
+
+	return runes, nil
+}
@@ -0,0 +1,156 @@
+//go:build ignore
+
+// This program generates graphemetables.go from the Unicode Character Database.
+// Download GraphemeBreakProperty.txt and emoji-data.txt for the Unicode version to
+// support into a directory and run:
+//
+//	go run gen_graphemes.go -ucd <directory> -version 15.0.0
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The Grapheme_Cluster_Break values and the constants they are written as
+// LV and LVT are left out since precomposed Hangul syllables are computed at runtime
+var properties = map[string]string{
+	"Prepend":               "gbPrepend",
+	"CR":                    "gbCR",
+	"LF":                    "gbLF",
+	"Control":               "gbControl",
+	"Extend":                "gbExtend",
+	"Regional_Indicator":    "gbRegionalIndicator",
+	"SpacingMark":           "gbSpacingMark",
+	"L":                     "gbL",
+	"V":                     "gbV",
+	"T":                     "gbT",
+	"ZWJ":                   "gbZWJ",
+	"Extended_Pictographic": "gbExtendedPictographic",
+}
+
+type codePointRange struct {
+	lo, hi   rune
+	property string
+}
+
+func main() {
+	ucd := flag.String("ucd", ".", "directory holding GraphemeBreakProperty.txt and emoji-data.txt")
+	version := flag.String("version", "15.0.0", "the Unicode version of the data files")
+	output := flag.String("o", "graphemetables.go", "the file to write")
+	flag.Parse()
+
+	ranges, err := parse(filepath.Join(*ucd, "GraphemeBreakProperty.txt"), nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	emoji, err := parse(filepath.Join(*ucd, "emoji-data.txt"), map[string]bool{"Extended_Pictographic": true})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ranges = merge(append(ranges, emoji...))
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by gen_graphemes.go. DO NOT EDIT.
+
+package strval
+
+// graphemeRanges holds the Grapheme_Cluster_Break property of every code point that is not Other,
+// taken from GraphemeBreakProperty.txt and emoji-data.txt (Extended_Pictographic only) of Unicode %s.
+// Precomposed Hangul syllables (LV and LVT) are computed by graphemeProperty instead of being listed.
+// See https://www.unicode.org/license.html for the Unicode license agreement.
+var graphemeRanges = [...]graphemeRange{
+`, *version)
+
+	for _, r := range ranges {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %s},\n", r.lo, r.hi, properties[r.property])
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse reads the code point ranges of a UCD property file, keeping only the properties in keep when it is not nil
+func parse(path string, keep map[string]bool) ([]codePointRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ranges []codePointRange
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		codePoints, property, ok := strings.Cut(text, ";")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: missing property", path, line)
+		}
+
+		property = strings.TrimSpace(property)
+		if _, known := properties[property]; !known || (keep != nil && !keep[property]) {
+			continue
+		}
+
+		loText, hiText, isRange := strings.Cut(strings.TrimSpace(codePoints), "..")
+		if !isRange {
+			hiText = loText
+		}
+
+		lo, err := strconv.ParseUint(loText, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		hi, err := strconv.ParseUint(hiText, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		ranges = append(ranges, codePointRange{rune(lo), rune(hi), property})
+	}
+
+	return ranges, scanner.Err()
+}
+
+// merge sorts the ranges and joins adjacent ranges with the same property
+func merge(ranges []codePointRange) []codePointRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].lo < ranges[j].lo
+	})
+
+	var merged []codePointRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && merged[n-1].property == r.property && merged[n-1].hi+1 == r.lo {
+			merged[n-1].hi = r.hi
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
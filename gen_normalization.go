@@ -0,0 +1,281 @@
+//go:build ignore
+
+// This program generates normalizationtables.go from the Unicode Character Database.
+// Download UnicodeData.txt and CompositionExclusions.txt for the Unicode version to
+// support into a directory and run:
+//
+//	go run gen_normalization.go -ucd <directory> -version 15.0.0
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The fields of a UnicodeData.txt line used by normalization
+type character struct {
+	combiningClass int
+	// The decomposition mapping, not applied recursively
+	mapping       []rune
+	compatibility bool
+}
+
+// A range of code points sharing a canonical combining class
+type combiningClassRange struct {
+	lo, hi rune
+	class  int
+}
+
+// A primary composite and the pair of code points it composes
+type composition struct {
+	first, second, composite rune
+}
+
+func main() {
+	ucd := flag.String("ucd", ".", "directory holding UnicodeData.txt and CompositionExclusions.txt")
+	version := flag.String("version", "15.0.0", "the Unicode version of the data files")
+	output := flag.String("o", "normalizationtables.go", "the file to write")
+	flag.Parse()
+
+	chars, err := parseUnicodeData(filepath.Join(*ucd, "UnicodeData.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exclusions, err := parseExclusions(filepath.Join(*ucd, "CompositionExclusions.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	codePoints := make([]rune, 0, len(chars))
+	for r := range chars {
+		codePoints = append(codePoints, r)
+	}
+	sort.Slice(codePoints, func(i, j int) bool {
+		return codePoints[i] < codePoints[j]
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by gen_normalization.go. DO NOT EDIT.
+
+package strval
+
+// The tables of Unicode normalization (UAX #15), taken from UnicodeData.txt and CompositionExclusions.txt of Unicode %s.
+// Hangul syllables are decomposed and composed by the algorithm of section 3.12 of the Unicode standard instead of being listed.
+// See https://www.unicode.org/license.html for the Unicode license agreement.
+
+// combiningClassRanges holds the canonical combining class of every code point whose class is not 0
+var combiningClassRanges = [...]combiningClassRange{
+`, *version)
+
+	for _, r := range combiningClasses(chars, codePoints) {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %d},\n", r.lo, r.hi, r.class)
+	}
+
+	buf.WriteString(`}
+
+// decompositions holds the full canonical and compatibility decompositions of every code point that has one.
+// The compatibility decomposition is empty when it is the canonical decomposition.
+var decompositions = [...]decomposition{
+`)
+
+	for _, r := range codePoints {
+		if len(chars[r].mapping) == 0 {
+			continue
+		}
+
+		canonical := string(decompose(chars, r, false))
+		compatibility := string(decompose(chars, r, true))
+		if canonical == string(r) {
+			canonical = ""
+		}
+		if compatibility == canonical || compatibility == string(r) {
+			compatibility = ""
+		}
+
+		fmt.Fprintf(&buf, "\t{0x%04X, %+q, %+q},\n", r, canonical, compatibility)
+	}
+
+	buf.WriteString(`}
+
+// compositions holds the primary composites, sorted by the pair of code points they compose
+var compositions = [...]composition{
+`)
+
+	for _, c := range primaryComposites(chars, codePoints, exclusions) {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, 0x%04X},\n", c.first, c.second, c.composite)
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseUnicodeData reads the code points of UnicodeData.txt that have a combining class or a decomposition
+func parseUnicodeData(path string) (map[rune]character, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	chars := map[rune]character{}
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), ";")
+		if len(fields) < 6 {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		class, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		var c character
+		c.combiningClass = class
+
+		mapping := fields[5]
+		if strings.HasPrefix(mapping, "<") {
+			c.compatibility = true
+			_, mapping, _ = strings.Cut(mapping, "> ")
+		}
+
+		for _, hex := range strings.Fields(mapping) {
+			r, err := strconv.ParseUint(hex, 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			c.mapping = append(c.mapping, rune(r))
+		}
+
+		if c.combiningClass != 0 || len(c.mapping) > 0 {
+			chars[rune(cp)] = c
+		}
+	}
+
+	return chars, scanner.Err()
+}
+
+// parseExclusions reads the code points listed in CompositionExclusions.txt
+func parseExclusions(path string) (map[rune]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	exclusions := map[rune]bool{}
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(text, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		exclusions[rune(cp)] = true
+	}
+
+	return exclusions, scanner.Err()
+}
+
+// combiningClasses returns the ranges of consecutive code points with the same non-zero combining class
+func combiningClasses(chars map[rune]character, codePoints []rune) []combiningClassRange {
+	var ranges []combiningClassRange
+
+	for _, r := range codePoints {
+		class := chars[r].combiningClass
+		if class == 0 {
+			continue
+		}
+
+		if n := len(ranges); n > 0 && ranges[n-1].class == class && ranges[n-1].hi+1 == r {
+			ranges[n-1].hi = r
+			continue
+		}
+		ranges = append(ranges, combiningClassRange{r, r, class})
+	}
+
+	return ranges
+}
+
+// decompose applies the decomposition mappings of a code point recursively, in canonical order
+func decompose(chars map[rune]character, r rune, compatibility bool) []rune {
+	c := chars[r]
+	if len(c.mapping) == 0 || (c.compatibility && !compatibility) {
+		return []rune{r}
+	}
+
+	var decomposed []rune
+	for _, m := range c.mapping {
+		decomposed = append(decomposed, decompose(chars, m, compatibility)...)
+	}
+
+	// Sort the runs of non-starters by combining class, keeping the order of equal classes
+	for i := range decomposed {
+		for j := i; j > 0; j-- {
+			prev, cur := chars[decomposed[j-1]].combiningClass, chars[decomposed[j]].combiningClass
+			if cur == 0 || prev <= cur {
+				break
+			}
+			decomposed[j-1], decomposed[j] = decomposed[j], decomposed[j-1]
+		}
+	}
+
+	return decomposed
+}
+
+// primaryComposites returns the canonical decompositions into a pair of code points that are composed again by NFC:
+// those that are not explicitly excluded, and that do not start with or decompose a non-starter
+func primaryComposites(chars map[rune]character, codePoints []rune, exclusions map[rune]bool) []composition {
+	var composites []composition
+
+	for _, r := range codePoints {
+		c := chars[r]
+		if c.compatibility || len(c.mapping) != 2 || exclusions[r] {
+			continue
+		}
+		if c.combiningClass != 0 || chars[c.mapping[0]].combiningClass != 0 {
+			continue
+		}
+
+		composites = append(composites, composition{c.mapping[0], c.mapping[1], r})
+	}
+
+	sort.Slice(composites, func(i, j int) bool {
+		if composites[i].first != composites[j].first {
+			return composites[i].first < composites[j].first
+		}
+		return composites[i].second < composites[j].second
+	})
+
+	return composites
+}
@@ -0,0 +1,168 @@
+//go:build ignore
+
+// This program generates precistables.go from the Unicode Character Database.
+// Download UnicodeData.txt for the Unicode version to support into a directory and run:
+//
+//	go run gen_precis.go -ucd <directory> -version 15.0.0
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The Bidi_Class values and the constants they are written as; L, the default, is left out
+var bidiClasses = map[string]string{
+	"R":   "bidiR",
+	"AL":  "bidiAL",
+	"EN":  "bidiEN",
+	"ES":  "bidiES",
+	"ET":  "bidiET",
+	"AN":  "bidiAN",
+	"CS":  "bidiCS",
+	"NSM": "bidiNSM",
+	"BN":  "bidiBN",
+	"B":   "bidiB",
+	"S":   "bidiS",
+	"WS":  "bidiWS",
+	"ON":  "bidiON",
+	"LRE": "bidiExplicit",
+	"LRO": "bidiExplicit",
+	"RLE": "bidiExplicit",
+	"RLO": "bidiExplicit",
+	"PDF": "bidiExplicit",
+	"LRI": "bidiExplicit",
+	"RLI": "bidiExplicit",
+	"FSI": "bidiExplicit",
+	"PDI": "bidiExplicit",
+}
+
+type codePointRange struct {
+	lo, hi rune
+	class  string
+}
+
+// A code point with a <wide> or <narrow> decomposition
+type widthMapping struct {
+	r, mapped rune
+}
+
+func main() {
+	ucd := flag.String("ucd", ".", "directory holding UnicodeData.txt")
+	version := flag.String("version", "15.0.0", "the Unicode version of the data files")
+	output := flag.String("o", "precistables.go", "the file to write")
+	flag.Parse()
+
+	ranges, widths, err := parse(filepath.Join(*ucd, "UnicodeData.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by gen_precis.go. DO NOT EDIT.
+
+package strval
+
+// The tables of the PRECIS profiles, taken from UnicodeData.txt of Unicode %s.
+// See https://www.unicode.org/license.html for the Unicode license agreement.
+
+// bidiClassRanges holds the Bidi_Class of every assigned code point that is not L, with the explicit formatting classes merged
+var bidiClassRanges = [...]bidiClassRange{
+`, *version)
+
+	for _, r := range ranges {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %s},\n", r.lo, r.hi, r.class)
+	}
+
+	buf.WriteString(`}
+
+// widthMappings maps the fullwidth and halfwidth code points to their decomposition
+var widthMappings = [...]widthMapping{
+`)
+
+	for _, w := range widths {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X},\n", w.r, w.mapped)
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse reads the Bidi_Class ranges and width mappings of UnicodeData.txt
+func parse(path string) ([]codePointRange, []widthMapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var ranges []codePointRange
+	var widths []widthMapping
+	var first rune = -1
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), ";")
+		if len(fields) < 6 {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		// Large blocks are given as a First and Last pair of lines
+		lo, hi := rune(cp), rune(cp)
+		switch {
+		case strings.HasSuffix(fields[1], ", First>"):
+			first = rune(cp)
+			continue
+		case strings.HasSuffix(fields[1], ", Last>"):
+			if first < 0 {
+				return nil, nil, fmt.Errorf("%s:%d: range without a first line", path, line)
+			}
+			lo, first = first, -1
+		}
+
+		if class, ok := bidiClasses[fields[4]]; ok {
+			if n := len(ranges); n > 0 && ranges[n-1].class == class && ranges[n-1].hi+1 == lo {
+				ranges[n-1].hi = hi
+			} else {
+				ranges = append(ranges, codePointRange{lo, hi, class})
+			}
+		}
+
+		tag, mapping, _ := strings.Cut(fields[5], " ")
+		if tag == "<wide>" || tag == "<narrow>" {
+			mapped, err := strconv.ParseUint(mapping, 16, 32)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			widths = append(widths, widthMapping{rune(cp), rune(mapped)})
+		}
+	}
+
+	sort.Slice(widths, func(i, j int) bool {
+		return widths[i].r < widths[j].r
+	})
+
+	return ranges, widths, scanner.Err()
+}
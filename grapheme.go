@@ -0,0 +1,179 @@
+package strval
+
+import "unicode/utf8"
+
+// The Grapheme_Cluster_Break property values of UAX #29
+type graphemeBreak uint8
+
+const (
+	gbOther graphemeBreak = iota
+	gbPrepend
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbRegionalIndicator
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbZWJ
+	gbExtendedPictographic
+)
+
+// A range of code points sharing a Grapheme_Cluster_Break property
+type graphemeRange struct {
+	lo, hi rune
+	prop   graphemeBreak
+}
+
+// The range of precomposed Hangul syllables, see section 3.12 of the Unicode standard
+const (
+	hangulSBase  = 0xAC00
+	hangulSCount = 11172
+	hangulTCount = 28
+)
+
+// GraphemeCount returns the number of user-perceived characters (extended grapheme clusters) in a string
+func GraphemeCount(str string) int {
+	count := 0
+
+	for len(str) > 0 {
+		str = str[firstGraphemeLength(str):]
+		count++
+	}
+
+	return count
+}
+
+// Graphemes splits a string into its user-perceived characters (extended grapheme clusters)
+func Graphemes(str string) []string {
+	var graphemes []string
+
+	for len(str) > 0 {
+		n := firstGraphemeLength(str)
+		graphemes = append(graphemes, str[:n])
+		str = str[n:]
+	}
+
+	return graphemes
+}
+
+// firstGraphemeLength returns the length in bytes of the first extended grapheme cluster in a non-empty string,
+// following the boundary rules of UAX #29
+func firstGraphemeLength(str string) int {
+	r, i := utf8.DecodeRuneInString(str)
+	prev := graphemeProperty(r)
+
+	// Track the state needed by GB11 (emoji ZWJ sequences) and GB12/GB13 (flag pairs)
+	inPictographic := prev == gbExtendedPictographic
+	zwjAfterPictographic := false
+	regionalIndicators := 0
+	if prev == gbRegionalIndicator {
+		regionalIndicators = 1
+	}
+
+	for i < len(str) {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		cur := graphemeProperty(r)
+
+		if isGraphemeBoundary(prev, cur, zwjAfterPictographic, regionalIndicators) {
+			break
+		}
+
+		switch cur {
+		case gbExtendedPictographic:
+			inPictographic = true
+		case gbExtend:
+			// Extend characters continue an ExtPict Extend* sequence
+		case gbZWJ:
+			zwjAfterPictographic = inPictographic
+			inPictographic = false
+		default:
+			inPictographic = false
+		}
+
+		if cur == gbRegionalIndicator {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+
+		prev = cur
+		i += size
+	}
+
+	return i
+}
+
+// isGraphemeBoundary reports whether there is a grapheme cluster boundary between two characters
+func isGraphemeBoundary(prev, cur graphemeBreak, zwjAfterPictographic bool, regionalIndicators int) bool {
+	switch {
+	// GB3: CR × LF
+	case prev == gbCR && cur == gbLF:
+		return false
+	// GB4 and GB5: break after and before controls
+	case prev == gbControl || prev == gbCR || prev == gbLF:
+		return true
+	case cur == gbControl || cur == gbCR || cur == gbLF:
+		return true
+	// GB6 to GB8: Hangul syllable sequences
+	case prev == gbL && (cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT):
+		return false
+	case (prev == gbLV || prev == gbV) && (cur == gbV || cur == gbT):
+		return false
+	case (prev == gbLVT || prev == gbT) && cur == gbT:
+		return false
+	// GB9, GB9a and GB9b: extending characters, spacing marks and prepended characters
+	case cur == gbExtend || cur == gbZWJ || cur == gbSpacingMark || prev == gbPrepend:
+		return false
+	// GB11: emoji ZWJ sequences
+	case prev == gbZWJ && cur == gbExtendedPictographic && zwjAfterPictographic:
+		return false
+	// GB12 and GB13: regional indicators pair up into flags
+	case prev == gbRegionalIndicator && cur == gbRegionalIndicator && regionalIndicators%2 == 1:
+		return false
+	}
+
+	// GB999: break everywhere else
+	return true
+}
+
+// graphemeProperty returns the Grapheme_Cluster_Break property of a code point
+func graphemeProperty(r rune) graphemeBreak {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		switch r {
+		case '\r':
+			return gbCR
+		case '\n':
+			return gbLF
+		}
+		return gbControl
+	case r < 0x7F:
+		return gbOther
+	case r >= hangulSBase && r < hangulSBase+hangulSCount:
+		if (r-hangulSBase)%hangulTCount == 0 {
+			return gbLV
+		}
+		return gbLVT
+	}
+
+	lo, hi := 0, len(graphemeRanges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < graphemeRanges[mid].lo:
+			hi = mid
+		case r > graphemeRanges[mid].hi:
+			lo = mid + 1
+		default:
+			return graphemeRanges[mid].prop
+		}
+	}
+
+	return gbOther
+}
@@ -0,0 +1,135 @@
+package strval
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Tests Graphemes() against the Unicode GraphemeBreakTest.txt conformance data
+func TestGraphemesConformance(t *testing.T) {
+	f, err := os.Open("testdata/GraphemeBreakTest.txt")
+	if err != nil {
+		t.Fatalf("failed to open test data: %v", err)
+	}
+	defer f.Close()
+
+	cases := 0
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		text, comment, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		// Each cluster is separated by ÷, code points within a cluster by ×
+		var input strings.Builder
+		var expected []string
+
+		for _, cluster := range strings.Split(strings.Trim(text, "÷ "), "÷") {
+			var sb strings.Builder
+			for _, codePoint := range strings.Split(cluster, "×") {
+				r, err := strconv.ParseUint(strings.TrimSpace(codePoint), 16, 32)
+				if err != nil {
+					t.Fatalf("line %d: invalid code point %q", line, codePoint)
+				}
+				sb.WriteRune(rune(r))
+			}
+			expected = append(expected, sb.String())
+			input.WriteString(sb.String())
+		}
+
+		got := Graphemes(input.String())
+		if strings.Join(got, "|") != strings.Join(expected, "|") || len(got) != len(expected) {
+			t.Errorf("line %d: Graphemes(%+q) = %+q, want %+q (%s)", line, input.String(), got, expected, strings.TrimSpace(comment))
+		}
+
+		if count := GraphemeCount(input.String()); count != len(expected) {
+			t.Errorf("line %d: GraphemeCount(%+q) = %d, want %d", line, input.String(), count, len(expected))
+		}
+
+		cases++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	if cases == 0 {
+		t.Fatalf("no test cases found")
+	}
+}
+
+// Tests GraphemeCount(str string) int
+func TestGraphemeCount(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		str      string
+		expected int
+	}{
+		{
+			name:     "empty string",
+			str:      "",
+			expected: 0,
+		},
+		{
+			name:     "ascii",
+			str:      "hello",
+			expected: 5,
+		},
+		{
+			name:     "crlf is a single character",
+			str:      "a\r\nb",
+			expected: 3,
+		},
+		{
+			name:     "combining accent",
+			str:      "é",
+			expected: 1,
+		},
+		{
+			name:     "emoji with skin tone modifier",
+			str:      "👍🏽",
+			expected: 1,
+		},
+		{
+			name:     "family zwj sequence",
+			str:      "👨‍👩‍👧‍👦",
+			expected: 1,
+		},
+		{
+			name:     "flags",
+			str:      "🇯🇵🇩🇪",
+			expected: 2,
+		},
+		{
+			name:     "hangul jamo",
+			str:      "각",
+			expected: 1,
+		},
+		{
+			name:     "japanese",
+			str:      "こんにちは世界",
+			expected: 7,
+		},
+		{
+			name:     "invalid utf-8",
+			str:      "\xff\xfe",
+			expected: 2,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GraphemeCount(tt.str); got != tt.expected {
+				t.Errorf("GraphemeCount(%q) = %d, want %d", tt.str, got, tt.expected)
+			}
+		})
+	}
+}
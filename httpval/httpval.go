@@ -0,0 +1,336 @@
+// Package httpval decodes JSON request bodies into structs and validates them with the strval struct tags.
+//
+// Handlers can decode and validate a body themselves:
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//		req, err := httpval.DecodeAndValidate[CreateUserRequest](r)
+//		if err != nil {
+//			httpval.WriteError(w, r, err)
+//			return
+//		}
+//		...
+//	}
+//
+// or leave it to the middleware, which answers invalid requests with an RFC 7807
+// application/problem+json response and passes the decoded value on in the request context:
+//
+//	mux.Handle("/users", httpval.Middleware[CreateUserRequest]()(http.HandlerFunc(createUser)))
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//		req, _ := httpval.FromContext[CreateUserRequest](r.Context())
+//		...
+//	}
+package httpval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/dmars8047/strval"
+)
+
+// DefaultMaxBodyBytes is the largest request body decoded unless changed with WithMaxBodyBytes
+const DefaultMaxBodyBytes = 1 << 20
+
+// A RequestError reports a request body that could not be decoded
+type RequestError struct {
+	// The HTTP status code describing the problem, e.g. 400 or 413
+	Status int
+	// A human readable explanation, safe to return to the client
+	Detail string
+	// The underlying error, if any
+	Err error
+}
+
+// Error returns the explanation of the problem
+func (e *RequestError) Error() string {
+	return e.Detail
+}
+
+// Unwrap returns the underlying error
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// A ValidationError reports a decoded request body that failed its strval rules
+type ValidationError struct {
+	Result strval.StructValidationResult
+}
+
+// Error lists the fields that failed validation
+func (e *ValidationError) Error() string {
+	var messages []string
+	for _, field := range sortedFields(e.Result) {
+		messages = append(messages, e.Result.Fields[field].Messages...)
+	}
+
+	return "httpval: invalid request: " + strings.Join(messages, "; ")
+}
+
+// This represents the configuration built from a list of Option values
+type config struct {
+	maxBodyBytes          int64
+	disallowUnknownFields bool
+	status                func(*http.Request, error) int
+	problem               func(*http.Request, int, error) any
+	translator            strval.Translator
+}
+
+// An Option configures DecodeAndValidate, Middleware and WriteError
+type Option func(*config)
+
+// WithMaxBodyBytes sets the largest request body that is decoded, larger bodies are rejected with 413
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *config) {
+		c.maxBodyBytes = n
+	}
+}
+
+// WithDisallowUnknownFields rejects request bodies with fields that the target struct does not have
+func WithDisallowUnknownFields() Option {
+	return func(c *config) {
+		c.disallowUnknownFields = true
+	}
+}
+
+// WithStatus replaces the function choosing the status code of an error response.
+// By default validation errors are answered with 422, RequestErrors with their own status and other errors with 500.
+func WithStatus(status func(r *http.Request, err error) int) Option {
+	return func(c *config) {
+		c.status = status
+	}
+}
+
+// WithProblem replaces the function building the body of an error response.
+// The returned value is encoded as JSON and sent with the application/problem+json content type.
+func WithProblem(problem func(r *http.Request, status int, err error) any) Option {
+	return func(c *config) {
+		c.problem = problem
+	}
+}
+
+// WithTranslator localizes the validation messages into the language preferred by the Accept-Language header
+func WithTranslator(translator strval.Translator) Option {
+	return func(c *config) {
+		c.translator = translator
+	}
+}
+
+// newConfig applies a list of options over the defaults
+func newConfig(options []Option) *config {
+	c := &config{
+		maxBodyBytes: DefaultMaxBodyBytes,
+		status:       DefaultStatus,
+		problem:      DefaultProblem,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// DecodeAndValidate decodes the JSON body of a request into a T and validates it with ValidateStruct
+// r: The request whose body is decoded
+// Returns the decoded value, and a *RequestError if the body could not be decoded,
+// a *ValidationError if it failed validation or any other error if the strval tags of T are malformed
+func DecodeAndValidate[T any](r *http.Request, options ...Option) (T, error) {
+	return decodeAndValidate[T](r, newConfig(options))
+}
+
+// decodeAndValidate decodes and validates a request body with a built configuration
+func decodeAndValidate[T any](r *http.Request, c *config) (T, error) {
+	var v T
+
+	if err := checkContentType(r); err != nil {
+		return v, err
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return v, &RequestError{Status: http.StatusBadRequest, Detail: "The request body is empty"}
+	}
+
+	// Read one byte past the limit to tell a body of exactly the limit from a larger one
+	body, err := io.ReadAll(io.LimitReader(r.Body, c.maxBodyBytes+1))
+	if err != nil {
+		return v, &RequestError{Status: http.StatusBadRequest, Detail: "The request body could not be read", Err: err}
+	}
+
+	if int64(len(body)) > c.maxBodyBytes {
+		return v, &RequestError{
+			Status: http.StatusRequestEntityTooLarge,
+			Detail: fmt.Sprintf("The request body must not be larger than %d bytes", c.maxBodyBytes),
+		}
+	}
+
+	if err := decodeJSON(body, &v, c.disallowUnknownFields); err != nil {
+		return v, err
+	}
+
+	result, err := strval.ValidateStruct(&v)
+	if err != nil {
+		return v, err
+	}
+
+	if !result.Valid {
+		if c.translator != nil {
+			result = localizeResult(result, preferredLocale(r), c.translator)
+		}
+		return v, &ValidationError{Result: result}
+	}
+
+	return v, nil
+}
+
+// checkContentType rejects request bodies that are declared as something other than JSON
+func checkContentType(r *http.Request) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return nil
+	}
+
+	return &RequestError{
+		Status: http.StatusUnsupportedMediaType,
+		Detail: fmt.Sprintf("The request body must be JSON, got %s", contentType),
+		Err:    err,
+	}
+}
+
+// errTrailingData reports a request body holding more than one JSON value
+var errTrailingData = errors.New("httpval: trailing data after the JSON value")
+
+// decodeJSON decodes a single JSON value, describing syntax and type errors in terms the client can act on
+func decodeJSON(body []byte, v any, disallowUnknownFields bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(v)
+	if err == nil && decoder.More() {
+		err = errTrailingData
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	detail := "The request body is not valid JSON"
+	switch {
+	case errors.As(err, &syntaxErr):
+		detail = fmt.Sprintf("The request body is not valid JSON at offset %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		detail = fmt.Sprintf("The field %s must be a JSON %s", typeErr.Field, jsonKind(typeErr.Type))
+	case errors.Is(err, io.EOF):
+		detail = "The request body is empty"
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		detail = "The request body has an unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")
+	case errors.Is(err, errTrailingData):
+		detail = "The request body must hold a single JSON value"
+	}
+
+	return &RequestError{Status: http.StatusBadRequest, Detail: detail, Err: err}
+}
+
+// jsonKind names the JSON type expected for a Go type
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// The key under which Middleware stores the decoded value of type T
+type contextKey[T any] struct{}
+
+// Middleware decodes and validates the JSON body of every request into a T.
+// Invalid requests are answered with an application/problem+json response and do not reach next;
+// valid requests are passed on with the decoded value in their context, see FromContext.
+func Middleware[T any](options ...Option) func(http.Handler) http.Handler {
+	c := newConfig(options)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v, err := decodeAndValidate[T](r, c)
+			if err != nil {
+				writeError(w, r, err, c)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, v)))
+		})
+	}
+}
+
+// FromContext returns the value decoded by Middleware[T]
+// Returns false if the context does not hold a T
+func FromContext[T any](ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(contextKey[T]{}).(T)
+	return v, ok
+}
+
+// localizeResult localizes the messages of every field of a struct validation result
+func localizeResult(result strval.StructValidationResult, locale string, translator strval.Translator) strval.StructValidationResult {
+	localized := strval.StructValidationResult{
+		Valid:  result.Valid,
+		Fields: make(map[string]strval.StringValidationResult, len(result.Fields)),
+	}
+
+	for field, fieldResult := range result.Fields {
+		localized.Fields[field] = strval.LocalizeResult(fieldResult, locale, translator)
+	}
+
+	return localized
+}
+
+// preferredLocale returns the language with the highest quality in the Accept-Language header of a request
+func preferredLocale(r *http.Request) string {
+	locale, best := strval.DefaultLocale, 0.0
+
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if _, err := fmt.Sscanf(q, "%g", &quality); err != nil {
+				continue
+			}
+		}
+
+		if quality > best {
+			locale, best = tag, quality
+		}
+	}
+
+	return locale
+}
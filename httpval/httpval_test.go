@@ -0,0 +1,237 @@
+package httpval
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dmars8047/strval"
+)
+
+type signupRequest struct {
+	Username string   `json:"username" strval:"notempty,alphanum,max=16"`
+	Email    string   `json:"email" strval:"email"`
+	Tags     []string `json:"tags" strval:"lower"`
+}
+
+// Tests DecodeAndValidate[T any](r *http.Request, options ...Option) (T, error)
+func TestDecodeAndValidate(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		options        []Option
+		expectedStatus int
+		expectedFields []string
+	}{
+		{
+			name:        "valid body",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"username":"jane","email":"jane@example.com","tags":["a"]}`,
+		},
+		{
+			name:           "invalid fields",
+			contentType:    "application/json",
+			body:           `{"username":"jane doe","email":"jane","tags":["ok","NOPE"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"email", "tags[1]", "username"},
+		},
+		{
+			name:           "malformed JSON",
+			body:           `{"username":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong JSON type",
+			body:           `{"username":42}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty body",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "several JSON values",
+			body:           `{"username":"jane"} {"username":"joe"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not JSON",
+			contentType:    "application/x-www-form-urlencoded",
+			body:           "username=jane",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "body too large",
+			body:           `{"username":"jane","email":"jane@example.com"}`,
+			options:        []Option{WithMaxBodyBytes(16)},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "unknown field",
+			body:           `{"username":"jane","email":"jane@example.com","admin":true}`,
+			options:        []Option{WithDisallowUnknownFields()},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			v, err := DecodeAndValidate[signupRequest](r, tt.options...)
+
+			if tt.expectedStatus == 0 {
+				if err != nil {
+					t.Fatalf("DecodeAndValidate() error = %v", err)
+				}
+				if v.Username != "jane" {
+					t.Errorf("DecodeAndValidate() = %+v, expected the decoded body", v)
+				}
+				return
+			}
+
+			if status := DefaultStatus(r, err); status != tt.expectedStatus {
+				t.Fatalf("DecodeAndValidate() error = %v with status %d, want %d", err, status, tt.expectedStatus)
+			}
+
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				for _, field := range tt.expectedFields {
+					if validationErr.Result.Fields[field].Valid {
+						t.Errorf("DecodeAndValidate() field %s is valid, expected it to fail", field)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Tests Middleware[T any](options ...Option) func(http.Handler) http.Handler
+func TestMiddleware(t *testing.T) {
+	var received signupRequest
+	handler := Middleware[signupRequest]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		received, ok = FromContext[signupRequest](r.Context())
+		if !ok {
+			t.Errorf("FromContext() found no value")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	// A valid request reaches the handler with the decoded value
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"username":"jane","email":"jane@example.com"}`)))
+
+	if w.Code != http.StatusCreated || received.Email != "jane@example.com" {
+		t.Errorf("Middleware() status = %d, value = %+v, expected the handler to run", w.Code, received)
+	}
+
+	// An invalid request is answered with a problem
+	received = signupRequest{}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"username":"","email":"jane@example.com"}`)))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Middleware() status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+
+	if received.Email != "" {
+		t.Errorf("Middleware() called the handler for an invalid request")
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != ContentTypeProblemJSON {
+		t.Errorf("Middleware() content type = %s, want %s", contentType, ContentTypeProblemJSON)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Middleware() body is not a problem: %v", err)
+	}
+
+	expected := Problem{
+		Type:     "about:blank",
+		Title:    "Unprocessable Entity",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   "The request body failed validation",
+		Instance: "/signup",
+		Errors:   map[string][]string{"username": {"username must not be empty", "username must be alphanumeric"}},
+	}
+
+	got, _ := json.Marshal(problem)
+	want, _ := json.Marshal(expected)
+	if string(got) != string(want) {
+		t.Errorf("Middleware() problem = %s, want %s", got, want)
+	}
+}
+
+// Tests the WithStatus, WithProblem and WithTranslator hooks
+func TestMiddlewareHooks(t *testing.T) {
+	handler := Middleware[signupRequest](
+		WithStatus(func(r *http.Request, err error) int {
+			return http.StatusBadRequest
+		}),
+		WithProblem(func(r *http.Request, status int, err error) any {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				return map[string]any{"code": status}
+			}
+
+			var messages []string
+			for _, field := range validationErr.Result.Fields {
+				messages = append(messages, field.Messages...)
+			}
+			return map[string]any{"code": status, "messages": messages}
+		}),
+		WithTranslator(strval.DefaultTranslator),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Middleware() called the handler for an invalid request")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"username":"jane","email":"nope"}`))
+	r.Header.Set("Accept-Language", "fr;q=0.5, de-CH, en;q=0.8")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("WithStatus() status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	expected := `{"code":400,"messages":["email muss eine gültige E-Mail-Adresse sein"]}`
+	if body := strings.TrimSpace(w.Body.String()); body != expected {
+		t.Errorf("WithProblem() body = %s, want %s", body, expected)
+	}
+}
+
+// Tests that malformed strval tags are reported as internal errors without disclosing details
+func TestWriteErrorInternal(t *testing.T) {
+	type badRequest struct {
+		Name string `strval:"nonsense"`
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/bad", strings.NewReader(`{"Name":"x"}`))
+	_, err := DecodeAndValidate[badRequest](r)
+	if err == nil {
+		t.Fatalf("DecodeAndValidate() expected an error for a malformed tag")
+	}
+
+	w := httptest.NewRecorder()
+	WriteError(w, r, err)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("WriteError() status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+
+	if strings.Contains(w.Body.String(), "nonsense") {
+		t.Errorf("WriteError() body = %s, expected internal details to be hidden", w.Body.String())
+	}
+}
@@ -0,0 +1,107 @@
+package httpval
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/dmars8047/strval"
+)
+
+// ContentTypeProblemJSON is the media type of RFC 7807 problem details
+const ContentTypeProblemJSON = "application/problem+json"
+
+// This represents an RFC 7807 problem details object
+type Problem struct {
+	// A URI identifying the problem type, about:blank when the status code says it all
+	Type string `json:"type"`
+	// A short summary of the problem type
+	Title string `json:"title"`
+	// The HTTP status code
+	Status int `json:"status"`
+	// An explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// A URI identifying this occurrence of the problem
+	Instance string `json:"instance,omitempty"`
+	// The validation messages of every invalid field, keyed by the field path
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// DefaultStatus chooses the status code of an error response:
+// 422 for a *ValidationError, the status of a *RequestError and 500 otherwise
+func DefaultStatus(r *http.Request, err error) int {
+	var validationErr *ValidationError
+	var requestErr *RequestError
+
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &requestErr):
+		return requestErr.Status
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// DefaultProblem builds a *Problem describing an error.
+// Validation errors list the messages of each invalid field; the details of internal errors are not disclosed.
+func DefaultProblem(r *http.Request, status int, err error) any {
+	problem := &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: r.URL.Path,
+	}
+
+	var validationErr *ValidationError
+	var requestErr *RequestError
+
+	switch {
+	case errors.As(err, &validationErr):
+		problem.Detail = "The request body failed validation"
+		problem.Errors = make(map[string][]string)
+		for _, field := range sortedFields(validationErr.Result) {
+			if fieldResult := validationErr.Result.Fields[field]; !fieldResult.Valid {
+				problem.Errors[field] = fieldResult.Messages
+			}
+		}
+	case errors.As(err, &requestErr):
+		problem.Detail = requestErr.Detail
+	}
+
+	return problem
+}
+
+// WriteError writes the application/problem+json response for an error returned by DecodeAndValidate.
+// The status code and body can be customized with WithStatus and WithProblem.
+func WriteError(w http.ResponseWriter, r *http.Request, err error, options ...Option) {
+	writeError(w, r, err, newConfig(options))
+}
+
+// writeError writes an error response with a built configuration
+func writeError(w http.ResponseWriter, r *http.Request, err error, c *config) {
+	status := c.status(r, err)
+
+	body, marshalErr := json.Marshal(c.problem(r, status, err))
+	if marshalErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblemJSON)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// sortedFields returns the field paths of a struct validation result in a stable order
+func sortedFields(result strval.StructValidationResult) []string {
+	fields := make([]string, 0, len(result.Fields))
+	for field := range result.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
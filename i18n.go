@@ -0,0 +1,241 @@
+package strval
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The locale used when no catalog matches the requested locale
+const DefaultLocale = "en"
+
+// A Catalog maps rule codes to message templates.
+// Templates reference the field name as {field} and rule parameters by name, e.g. {min}.
+type Catalog map[string]string
+
+// A Translator renders the message of a FieldError in the requested locale
+type Translator interface {
+	Translate(locale string, fieldErr *FieldError) string
+}
+
+// CatalogTranslator is a Translator backed by per-locale message catalogs.
+// Lookups fall back from a regional locale (de-AT) to its language (de) and then to English.
+type CatalogTranslator struct {
+	mu       sync.RWMutex
+	catalogs map[string]Catalog
+}
+
+// DefaultTranslator is the Translator used by ValidateStringWithLocale.
+// It is preloaded with the bundled catalogs.
+var DefaultTranslator = NewCatalogTranslator()
+
+// NewCatalogTranslator creates a CatalogTranslator preloaded with the bundled catalogs
+func NewCatalogTranslator() *CatalogTranslator {
+	t := &CatalogTranslator{catalogs: make(map[string]Catalog)}
+
+	for locale, catalog := range bundledCatalogs {
+		t.AddCatalog(locale, catalog)
+	}
+
+	return t
+}
+
+// AddCatalog merges the templates in catalog into the catalog for locale, replacing existing templates with the same rule code
+func (t *CatalogTranslator) AddCatalog(locale string, catalog Catalog) {
+	locale = normalizeLocale(locale)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	existing, ok := t.catalogs[locale]
+	if !ok {
+		existing = make(Catalog, len(catalog))
+		t.catalogs[locale] = existing
+	}
+
+	for rule, template := range catalog {
+		existing[rule] = template
+	}
+}
+
+// Translate renders the message of fieldErr in locale.
+// If no template exists for the rule in any fallback locale, or the message was supplied by the caller, the original message is returned.
+func (t *CatalogTranslator) Translate(locale string, fieldErr *FieldError) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.translate(localeFallbacks(locale), fieldErr)
+}
+
+// translate renders the message of fieldErr using the first matching locale, t.mu must be held
+func (t *CatalogTranslator) translate(locales []string, fieldErr *FieldError) string {
+	if fieldErr.custom {
+		return fieldErr.Message
+	}
+
+	params := fieldErr.Params
+	if len(fieldErr.Causes) > 0 {
+		// Combined rules list the messages of their causes, which need translating too
+		params = copyParams(fieldErr.Params)
+		params["errors"] = joinCauses(fieldErr.Causes, func(cause *FieldError) string {
+			return t.translate(locales, cause)
+		})
+	}
+
+	if suggestions, ok := fieldErr.Params["suggestion_keys"].([]string); ok && fieldErr.Rule == RulePasswordStrength {
+		// The feedback on a weak password is rendered from its message keys
+		warning, _ := fieldErr.Params["warning_key"].(string)
+		params = copyParams(params)
+		params["feedback"] = renderPasswordFeedback(warning, suggestions, func(key string) string {
+			return t.template(locales, key)
+		})
+	}
+
+	for _, candidate := range locales {
+		if template, ok := t.catalogs[candidate][fieldErr.Rule]; ok {
+			return renderMessage(template, fieldErr.Field, params)
+		}
+	}
+
+	return fieldErr.Message
+}
+
+// template returns the template of a message key in the first matching locale, t.mu must be held
+func (t *CatalogTranslator) template(locales []string, key string) string {
+	for _, candidate := range locales {
+		if template, ok := t.catalogs[candidate][key]; ok {
+			return template
+		}
+	}
+
+	return englishCatalog[key]
+}
+
+// copyParams copies the parameters of a FieldError so that translating it does not modify them
+func copyParams(params map[string]any) map[string]any {
+	copied := make(map[string]any, len(params)+1)
+	for name, value := range params {
+		copied[name] = value
+	}
+
+	return copied
+}
+
+// ValidateStringWithLocale validates a string against the provided options, rendering messages in the given locale
+// str: The string to validate
+// strName: The name of the string to validate (used in error messages)
+// locale: A BCP 47 language tag such as "de" or "es-MX"
+// options: The options to validate the string against
+// Returns a StringValidationResult
+func ValidateStringWithLocale(str, strName, locale string, options ...StringValidationOption) StringValidationResult {
+	return ValidateStringWithTranslator(str, strName, locale, DefaultTranslator, options...)
+}
+
+// ValidateStringWithTranslator validates a string against the provided options, rendering messages with translator
+// str: The string to validate
+// strName: The name of the string to validate (used in error messages)
+// locale: The locale passed to the translator
+// translator: The Translator used to render messages
+// options: The options to validate the string against
+// Returns a StringValidationResult
+func ValidateStringWithTranslator(str, strName, locale string, translator Translator, options ...StringValidationOption) StringValidationResult {
+	return LocalizeResult(ValidateStringWithName(str, strName, options...), locale, translator)
+}
+
+// LocalizeResult re-renders every message of a StringValidationResult in the given locale
+// The errors in the result are copied, not modified in place
+func LocalizeResult(result StringValidationResult, locale string, translator Translator) StringValidationResult {
+	if result.Valid && len(result.Warnings) == 0 && len(result.Infos) == 0 {
+		return result
+	}
+
+	localized := StringValidationResult{Valid: result.Valid}
+
+	for _, list := range [][]*FieldError{result.Errors, result.Warnings, result.Infos} {
+		for _, fieldErr := range list {
+			localized.add(localizeError(fieldErr, locale, translator))
+		}
+	}
+
+	return localized
+}
+
+// localizeError copies a FieldError and its causes with their messages rendered in the given locale
+func localizeError(fieldErr *FieldError, locale string, translator Translator) *FieldError {
+	translated := *fieldErr
+	translated.Message = translator.Translate(locale, fieldErr)
+
+	if len(fieldErr.Causes) > 0 {
+		translated.Causes = make([]*FieldError, len(fieldErr.Causes))
+		for i, cause := range fieldErr.Causes {
+			translated.Causes[i] = localizeError(cause, locale, translator)
+		}
+	}
+
+	return &translated
+}
+
+// renderMessage substitutes {field} and {param} placeholders in a message template
+func renderMessage(template, field string, params map[string]any) string {
+	var sb strings.Builder
+	sb.Grow(len(template) + len(field))
+
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(template[:start])
+
+		name := template[start+1 : end]
+		if name == "field" {
+			sb.WriteString(field)
+		} else if value, ok := params[name]; ok {
+			fmt.Fprint(&sb, value)
+		} else {
+			// Unknown placeholders are left untouched
+			sb.WriteString(template[start : end+1])
+		}
+
+		template = template[end+1:]
+	}
+
+	sb.WriteString(template)
+
+	return sb.String()
+}
+
+// normalizeLocale lowercases a locale and uses hyphens as the subtag separator
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// localeFallbacks lists the locales to try for a requested locale, most specific first
+// e.g. zh-Hant-TW yields zh-hant-tw, zh-hant, zh, en
+func localeFallbacks(locale string) []string {
+	locale = normalizeLocale(locale)
+
+	var fallbacks []string
+	for locale != "" {
+		fallbacks = append(fallbacks, locale)
+
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+
+	if len(fallbacks) == 0 || fallbacks[len(fallbacks)-1] != DefaultLocale {
+		fallbacks = append(fallbacks, DefaultLocale)
+	}
+
+	return fallbacks
+}
@@ -0,0 +1,191 @@
+package strval
+
+import "testing"
+
+// Tests that every built-in rule has a template in every bundled catalog
+func TestBundledCatalogs(t *testing.T) {
+	for _, rule := range []string{
+		RuleMinLength, RuleMaxLength, RuleNotEmpty, RuleAlphaNumeric, RuleContainsNumbers, RuleContainsAtLeastOne,
+		RuleNotContainAnyOf, RuleContainsUppercase, RuleContainsLowercase, RulePrintable, RuleASCII, RuleEmail,
+		RuleMixedScript, RuleSingleScript, RuleConfusable, RulePRECIS, RuleMinValue, RuleMaxValue, RuleValueBetween,
+		RulePositive, RuleMultipleOf, RuleBefore, RuleAfter, RuleTimeBetween, RuleMinItems, RuleMaxItems, RuleUniqueItems,
+		RuleEach, RuleRequiredKeys, RuleURL, RuleURLScheme, RuleURLHost, RuleURLIPHost, RuleURLUserInfo, RuleURLFragment,
+		RuleRedirectURI, PasswordWarningTop10, PasswordWarningTop100, PasswordWarningVeryCommon, PasswordWarningSimilarToCommon,
+		PasswordWarningSingleWord, PasswordWarningUserInputs, PasswordWarningShortKeyboardPattern, PasswordWarningStraightRow,
+		PasswordWarningRepeatedPattern, PasswordWarningRepeatedCharacter, PasswordWarningSequence, PasswordWarningDate,
+		PasswordWarningRecentYear, PasswordWarningSentence, PasswordFeedbackSeparator, PasswordSuggestionFewWords,
+		PasswordSuggestionNoComposition, PasswordSuggestionAddWords, PasswordSuggestionAllUppercase,
+		PasswordSuggestionCapitalization, PasswordSuggestionReversed, PasswordSuggestionSubstitutions,
+		PasswordSuggestionLongerKeyboardWalk, PasswordSuggestionAvoidRepeats, PasswordSuggestionAvoidSequences,
+		PasswordSuggestionAvoidDates,
+	} {
+		for _, locale := range []string{"en", "de", "es", "ja"} {
+			if _, ok := bundledCatalogs[locale][rule]; !ok {
+				t.Errorf("catalog %s is missing a template for %s", locale, rule)
+			}
+		}
+	}
+}
+
+// Test ValidateStringWithLocale(str, strName, locale string, options ...StringValidationOption) StringValidationResult
+func TestValidateStringWithLocale(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		locale          string
+		expectedMessage string
+	}{
+		{
+			name:            "english",
+			locale:          "en",
+			expectedMessage: "password must have a minimum length of 8",
+		},
+		{
+			name:            "german",
+			locale:          "de",
+			expectedMessage: "password muss mindestens 8 Zeichen lang sein",
+		},
+		{
+			name:            "spanish with region",
+			locale:          "es-MX",
+			expectedMessage: "password debe tener una longitud mínima de 8",
+		},
+		{
+			name:            "japanese with underscore separator",
+			locale:          "ja_JP",
+			expectedMessage: "passwordは8文字以上で入力してください",
+		},
+		{
+			name:            "unknown locale falls back to english",
+			locale:          "xx",
+			expectedMessage: "password must have a minimum length of 8",
+		},
+		{
+			name:            "empty locale falls back to english",
+			locale:          "",
+			expectedMessage: "password must have a minimum length of 8",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateStringWithLocale("short", "password", tt.locale, MustHaveMinLengthOf(8))
+
+			if result.Valid {
+				t.Fatalf("ValidateStringWithLocale() Valid = true, want false")
+			}
+
+			if result.Messages[0] != tt.expectedMessage {
+				t.Errorf("ValidateStringWithLocale() message = %q, want %q", result.Messages[0], tt.expectedMessage)
+			}
+
+			if result.Errors[0].Message != tt.expectedMessage || result.Errors[0].Rule != RuleMinLength {
+				t.Errorf("ValidateStringWithLocale() error = %+v, want localized min_length error", result.Errors[0])
+			}
+		})
+	}
+}
+
+// Tests CatalogTranslator fallback and custom catalogs
+func TestCatalogTranslator(t *testing.T) {
+	translator := NewCatalogTranslator()
+	translator.AddCatalog("fr", Catalog{
+		RuleNotEmpty: "{field} ne doit pas être vide",
+	})
+	translator.AddCatalog("de-CH", Catalog{
+		RuleNotEmpty: "{field} darf nicht leer sein (CH)",
+	})
+
+	custom := func(str, strName string) error {
+		return &FieldError{Field: strName, Rule: "unknown_rule", Value: str, Message: strName + " is invalid"}
+	}
+
+	// Test cases
+	tests := []struct {
+		name            string
+		locale          string
+		option          StringValidationOption
+		expectedMessage string
+	}{
+		{
+			name:            "custom locale",
+			locale:          "fr",
+			option:          MustNotBeEmpty(),
+			expectedMessage: "name ne doit pas être vide",
+		},
+		{
+			name:            "missing key in custom locale falls back to english",
+			locale:          "fr",
+			option:          MustBeAlphaNumeric(),
+			expectedMessage: "name must be alphanumeric",
+		},
+		{
+			name:            "regional catalog",
+			locale:          "de-CH",
+			option:          MustNotBeEmpty(),
+			expectedMessage: "name darf nicht leer sein (CH)",
+		},
+		{
+			name:            "missing key in regional catalog falls back to language",
+			locale:          "de-CH",
+			option:          MustBeAlphaNumeric(),
+			expectedMessage: "name darf nur Buchstaben und Ziffern enthalten",
+		},
+		{
+			name:            "unknown rule keeps the original message",
+			locale:          "de",
+			option:          StringValidationOption(custom),
+			expectedMessage: "name is invalid",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateStringWithTranslator("", "name", tt.locale, translator, tt.option)
+
+			if result.Messages[0] != tt.expectedMessage {
+				t.Errorf("ValidateStringWithTranslator() message = %q, want %q", result.Messages[0], tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// Tests renderMessage(template, field string, params map[string]any) string
+func TestRenderMessage(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		template string
+		params   map[string]any
+		expected string
+	}{
+		{
+			name:     "field and parameter",
+			template: "{field} must be at least {min}",
+			params:   map[string]any{"min": 3},
+			expected: "name must be at least 3",
+		},
+		{
+			name:     "unknown placeholder",
+			template: "{field} {unknown}",
+			expected: "name {unknown}",
+		},
+		{
+			name:     "unterminated placeholder",
+			template: "{field} {min",
+			params:   map[string]any{"min": 3},
+			expected: "name {min",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderMessage(tt.template, "name", tt.params); got != tt.expected {
+				t.Errorf("renderMessage() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package yamlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A value of the JSON document being converted, keeping the order of object keys
+type node struct {
+	// '{' for an object, '[' for an array, 0 for a scalar
+	kind   byte
+	keys   []string
+	values []*node
+	// The YAML form of a scalar
+	scalar string
+}
+
+// FromJSON converts a JSON document to a YAML document that Unmarshal decodes to the same value.
+// Objects become block mappings with their keys in the original order, arrays become block sequences,
+// and strings are written plain when that cannot change their meaning, double quoted otherwise.
+func FromJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	root, err := readNode(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("yaml: %w", err)
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("yaml: unexpected data after the JSON value")
+	}
+
+	var b strings.Builder
+	if root.kind == 0 || len(root.values) == 0 {
+		b.WriteString(root.inline())
+		b.WriteByte('\n')
+	} else {
+		writeBlock(&b, root, 0, false)
+	}
+
+	return []byte(b.String()), nil
+}
+
+// readNode reads the next JSON value
+func readNode(decoder *json.Decoder) (*node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := token.(type) {
+	case json.Delim:
+		n := &node{kind: byte(v)}
+		for decoder.More() {
+			if n.kind == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+
+			value, err := readNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, value)
+		}
+
+		// The closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &node{scalar: formatString(v)}, nil
+	case json.Number:
+		return &node{scalar: v.String()}, nil
+	case bool:
+		return &node{scalar: fmt.Sprint(v)}, nil
+	default:
+		return &node{scalar: "null"}, nil
+	}
+}
+
+// inline returns the YAML form of a scalar or an empty collection
+func (n *node) inline() string {
+	switch n.kind {
+	case '{':
+		return "{}"
+	case '[':
+		return "[]"
+	default:
+		return n.scalar
+	}
+}
+
+// writeBlock writes a non-empty collection as a block at an indentation.
+// continued is true when the first line follows a sequence indicator already written.
+func writeBlock(b *strings.Builder, n *node, indent int, continued bool) {
+	for i, value := range n.values {
+		if i > 0 || !continued {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+
+		if n.kind == '{' {
+			b.WriteString(formatString(n.keys[i]))
+			b.WriteByte(':')
+		} else {
+			b.WriteByte('-')
+		}
+
+		switch {
+		case value.kind == 0 || len(value.values) == 0:
+			b.WriteByte(' ')
+			b.WriteString(value.inline())
+			b.WriteByte('\n')
+		case n.kind == '[':
+			// Nested collections start on the line of the sequence indicator
+			b.WriteByte(' ')
+			writeBlock(b, value, indent+2, true)
+		default:
+			b.WriteByte('\n')
+			writeBlock(b, value, indent+2, false)
+		}
+	}
+}
+
+// formatString writes a string as a plain scalar if Unmarshal reads it back unchanged, double quoted otherwise
+func formatString(s string) string {
+	if isPlainSafe(s) {
+		return s
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	// A JSON string is a valid YAML double quoted scalar; encoding a string cannot fail
+	_ = encoder.Encode(s)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// isPlainSafe checks if a string can be written as a plain scalar:
+// it is made of letters, digits and a few punctuation characters, and does not resolve to another type
+func isPlainSafe(s string) bool {
+	if s == "" || s[0] == '-' || s[0] == '.' || s[0] == ' ' || s[len(s)-1] == ' ' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("_./$+- ", c) >= 0) {
+			return false
+		}
+	}
+
+	v, ok := resolvePlain(s).(string)
+	return ok && v == s
+}
@@ -0,0 +1,83 @@
+package yamlite
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests FromJSON(data []byte) ([]byte, error)
+func TestFromJSON(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "scalar",
+			input:    `"text"`,
+			expected: "text\n",
+		},
+		{
+			name:     "empty object",
+			input:    `{}`,
+			expected: "{}\n",
+		},
+		{
+			name:     "keys keep their order",
+			input:    `{"openapi": "3.1.0", "b": 1, "a": [true, null, 2.5], "empty": {}, "none": []}`,
+			expected: "openapi: 3.1.0\nb: 1\na:\n  - true\n  - null\n  - 2.5\nempty: {}\nnone: []\n",
+		},
+		{
+			name:     "nested collections in sequences",
+			input:    `[{"rule": "min", "args": [3]}, [1, [2]], "x"]`,
+			expected: "- rule: min\n  args:\n    - 3\n- - 1\n  - - 2\n- x\n",
+		},
+		{
+			name:  "strings that need quotes",
+			input: `{"$ref": "#/components/schemas/User", "pattern": "^[a-z]+$", "true": "true", "n": "10", "s": "a: b", "e": "", "u": "é\t<"}`,
+			expected: "$ref: \"#/components/schemas/User\"\npattern: \"^[a-z]+$\"\n\"true\": \"true\"\nn: \"10\"\n" +
+				"s: \"a: b\"\ne: \"\"\nu: \"é\\t<\"\n",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromJSON([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("FromJSON() error = %v", err)
+			}
+
+			if string(got) != tt.expected {
+				t.Errorf("FromJSON() = %q, want %q", got, tt.expected)
+			}
+
+			// The YAML document decodes to the value of the JSON document
+			var expected any
+			if err := json.Unmarshal([]byte(tt.input), &expected); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			decoded, err := Unmarshal(got)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			// Compare through JSON, which does not distinguish int64 and float64
+			a, _ := json.Marshal(expected)
+			b, _ := json.Marshal(decoded)
+			if !reflect.DeepEqual(a, b) {
+				t.Errorf("Unmarshal(FromJSON()) = %s, want %s", b, a)
+			}
+		})
+	}
+
+	for _, input := range []string{`{"a": `, `{} {}`, `[1,]`} {
+		if _, err := FromJSON([]byte(input)); err == nil || !strings.HasPrefix(err.Error(), "yaml: ") {
+			t.Errorf("FromJSON(%q) error = %v, want a yaml error", input, err)
+		}
+	}
+}
@@ -0,0 +1,577 @@
+// Package yamlite parses the subset of YAML used by configuration files:
+// block mappings and sequences, single line flow sequences and mappings, plain and quoted scalars and comments.
+//
+// Documents are decoded into the values encoding/json produces for an any:
+// map[string]any, []any, string, bool, nil, and int64 or float64 for numbers.
+// Anchors, aliases, tags, block scalars (| and >) and multiple documents are rejected.
+// FromJSON writes JSON documents as YAML in the same subset.
+package yamlite
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// An Error reports a document that could not be parsed
+type Error struct {
+	// The 1-based line of the problem
+	Line int
+	// A description of the problem
+	Msg string
+}
+
+// Error describes the problem and where it is
+func (e *Error) Error() string {
+	return fmt.Sprintf("yaml: line %d: %s", e.Line, e.Msg)
+}
+
+// A line of the document without its indentation and comment
+type line struct {
+	number int
+	indent int
+	text   string
+}
+
+// This represents the state of Unmarshal
+type parser struct {
+	lines []line
+	pos   int
+}
+
+// Unmarshal parses a YAML document
+// Returns the decoded value, or an *Error describing the first problem
+func Unmarshal(data []byte) (any, error) {
+	if !utf8.Valid(data) {
+		return nil, &Error{Line: 1, Msg: "document is not valid UTF-8"}
+	}
+
+	lines, err := splitLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{lines: lines}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+
+	v, err := p.parseNode(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, p.errorf(p.lines[p.pos], "unexpected indentation")
+	}
+
+	return v, nil
+}
+
+// splitLines removes comments and blank lines and measures the indentation of the rest
+func splitLines(data string) ([]line, error) {
+	var lines []line
+
+	for i, text := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		number := i + 1
+		text = strings.TrimRight(stripComment(text), " \t")
+
+		indent := 0
+		for indent < len(text) && text[indent] == ' ' {
+			indent++
+		}
+
+		if indent < len(text) && text[indent] == '\t' {
+			return nil, &Error{Line: number, Msg: "tabs are not allowed in indentation"}
+		}
+
+		text = text[indent:]
+		switch {
+		case text == "":
+			continue
+		case indent == 0 && text == "---" && len(lines) == 0:
+			// Start of the document
+			continue
+		case indent == 0 && (text == "---" || text == "..."):
+			return nil, &Error{Line: number, Msg: "multiple documents are not supported"}
+		case strings.HasPrefix(text, "%"):
+			return nil, &Error{Line: number, Msg: "directives are not supported"}
+		}
+
+		lines = append(lines, line{number: number, indent: indent, text: text})
+	}
+
+	return lines, nil
+}
+
+// stripComment removes a comment from a line, a # at the start of the line or after whitespace outside quotes
+func stripComment(text string) string {
+	quote := byte(0)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" \t[{,:-", text[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+
+	return text
+}
+
+// parseNode parses the block node starting at the current line, which is indented by indent
+func (p *parser) parseNode(indent int) (any, error) {
+	l := p.lines[p.pos]
+
+	if isSequenceItem(l.text) {
+		return p.parseSequence(indent)
+	}
+
+	if _, _, ok, err := splitMappingEntry(l.text); err != nil {
+		return nil, p.errorf(l, err.Error())
+	} else if ok {
+		return p.parseMapping(indent)
+	}
+
+	p.pos++
+	v, err := parseValue(l.text)
+	if err != nil {
+		return nil, p.errorf(l, err.Error())
+	}
+
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.errorf(p.lines[p.pos], "multi-line scalars are not supported")
+	}
+
+	return v, nil
+}
+
+// parseSequence parses the items of a block sequence indented by indent
+func (p *parser) parseSequence(indent int) (any, error) {
+	items := []any{}
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent || (l.indent == indent && !isSequenceItem(l.text)) {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+
+		rest := strings.TrimLeft(l.text[1:], " ")
+		if rest == "" {
+			// The item is the block node on the following lines
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				item, err := p.parseNode(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			} else {
+				items = append(items, nil)
+			}
+			continue
+		}
+
+		// The item starts on this line: continue parsing as if it was written on its own line
+		p.lines[p.pos] = line{number: l.number, indent: l.indent + len(l.text) - len(rest), text: rest}
+		item, err := p.parseNode(p.lines[p.pos].indent)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// parseMapping parses the entries of a block mapping indented by indent
+func (p *parser) parseMapping(indent int) (any, error) {
+	entries := map[string]any{}
+
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+
+		key, value, ok, err := splitMappingEntry(l.text)
+		if err != nil {
+			return nil, p.errorf(l, err.Error())
+		}
+		if !ok {
+			if isSequenceItem(l.text) {
+				return nil, p.errorf(l, "unexpected sequence item in a mapping")
+			}
+			return nil, p.errorf(l, "expected a mapping entry")
+		}
+
+		if _, duplicate := entries[key]; duplicate {
+			return nil, p.errorf(l, fmt.Sprintf("duplicate key %q", key))
+		}
+
+		p.pos++
+
+		if value != "" {
+			v, err := parseValue(value)
+			if err != nil {
+				return nil, p.errorf(l, err.Error())
+			}
+			entries[key] = v
+			continue
+		}
+
+		// The value is the block node on the following lines; a sequence may be indented like its key
+		switch {
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			v, err := p.parseNode(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = v
+		case p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text):
+			v, err := p.parseSequence(indent)
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = v
+		default:
+			entries[key] = nil
+		}
+	}
+
+	return entries, nil
+}
+
+// errorf creates an Error for a line
+func (p *parser) errorf(l line, msg string) *Error {
+	return &Error{Line: l.number, Msg: msg}
+}
+
+// isSequenceItem checks if a line starts a block sequence item
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitMappingEntry splits a "key: value" line, reporting false if the line is not a mapping entry
+func splitMappingEntry(text string) (string, string, bool, error) {
+	if text == "" || text[0] == '[' || text[0] == '{' || isSequenceItem(text) {
+		return "", "", false, nil
+	}
+
+	if text[0] == '"' || text[0] == '\'' {
+		key, n, err := parseQuoted(text)
+		if err != nil {
+			return "", "", false, err
+		}
+
+		rest := strings.TrimLeft(text[n:], " ")
+		if rest == ":" || strings.HasPrefix(rest, ": ") {
+			return key, strings.TrimSpace(rest[1:]), true, nil
+		}
+		return "", "", false, nil
+	}
+
+	// A plain key ends at the first colon followed by a space or the end of the line
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			key := strings.TrimRight(text[:i], " ")
+			if err := checkPlain(key); err != nil {
+				return "", "", false, err
+			}
+			return key, strings.TrimSpace(text[i+1:]), true, nil
+		}
+	}
+
+	return "", "", false, nil
+}
+
+// parseValue parses a value written on a single line: a flow collection or a scalar
+func parseValue(text string) (any, error) {
+	f := &flowParser{text: text}
+
+	v, err := f.parse(true)
+	if err != nil {
+		return nil, err
+	}
+
+	f.skipSpaces()
+	if f.pos < len(text) {
+		return nil, fmt.Errorf("unexpected %q after value", text[f.pos:])
+	}
+
+	return v, nil
+}
+
+// This represents the state of a flow collection parse
+type flowParser struct {
+	text string
+	pos  int
+}
+
+// parse parses a flow node; top is set for a node that is not inside a flow collection
+func (f *flowParser) parse(top bool) (any, error) {
+	f.skipSpaces()
+	if f.pos == len(f.text) {
+		return nil, nil
+	}
+
+	switch f.text[f.pos] {
+	case '[':
+		return f.parseSequence()
+	case '{':
+		return f.parseMapping()
+	case '"', '\'':
+		s, n, err := parseQuoted(f.text[f.pos:])
+		if err != nil {
+			return nil, err
+		}
+		f.pos += n
+		return s, nil
+	}
+
+	// A plain scalar runs to the end of the line, or inside a flow collection to the next , ] or }
+	start := f.pos
+	for f.pos < len(f.text) && (top || strings.IndexByte(",]}", f.text[f.pos]) < 0) {
+		if !top && f.text[f.pos] == ':' && (f.pos+1 == len(f.text) || f.text[f.pos+1] == ' ') {
+			break
+		}
+		f.pos++
+	}
+
+	plain := strings.TrimSpace(f.text[start:f.pos])
+	if err := checkPlain(plain); err != nil {
+		return nil, err
+	}
+
+	return resolvePlain(plain), nil
+}
+
+// parseSequence parses a flow sequence such as [a, b]
+func (f *flowParser) parseSequence() (any, error) {
+	items := []any{}
+	f.pos++
+
+	for {
+		f.skipSpaces()
+		if f.pos == len(f.text) {
+			return nil, fmt.Errorf("unterminated flow sequence")
+		}
+		if f.text[f.pos] == ']' {
+			f.pos++
+			return items, nil
+		}
+
+		item, err := f.parse(false)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		if err := f.separator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseMapping parses a flow mapping such as {a: 1, b: 2}
+func (f *flowParser) parseMapping() (any, error) {
+	entries := map[string]any{}
+	f.pos++
+
+	for {
+		f.skipSpaces()
+		if f.pos == len(f.text) {
+			return nil, fmt.Errorf("unterminated flow mapping")
+		}
+		if f.text[f.pos] == '}' {
+			f.pos++
+			return entries, nil
+		}
+
+		key, err := f.parse(false)
+		if err != nil {
+			return nil, err
+		}
+
+		f.skipSpaces()
+		if f.pos == len(f.text) || f.text[f.pos] != ':' {
+			return nil, fmt.Errorf("expected ':' in flow mapping")
+		}
+		f.pos++
+
+		value, err := f.parse(false)
+		if err != nil {
+			return nil, err
+		}
+
+		name := fmt.Sprint(key)
+		if _, duplicate := entries[name]; duplicate {
+			return nil, fmt.Errorf("duplicate key %q", name)
+		}
+		entries[name] = value
+
+		if err := f.separator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// separator consumes the comma between flow collection entries, leaving the closing bracket
+func (f *flowParser) separator(closing byte) error {
+	f.skipSpaces()
+	switch {
+	case f.pos < len(f.text) && f.text[f.pos] == ',':
+		f.pos++
+		return nil
+	case f.pos < len(f.text) && f.text[f.pos] == closing:
+		return nil
+	default:
+		return fmt.Errorf("expected ',' or '%c' in flow collection", closing)
+	}
+}
+
+// skipSpaces skips the spaces at the current position
+func (f *flowParser) skipSpaces() {
+	for f.pos < len(f.text) && f.text[f.pos] == ' ' {
+		f.pos++
+	}
+}
+
+// parseQuoted parses a single or double quoted scalar at the start of text, returning it and its length in text
+func parseQuoted(text string) (string, int, error) {
+	quote := text[0]
+	var b strings.Builder
+
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case c == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			// '' is an escaped single quote
+			b.WriteByte('\'')
+			i++
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\' && quote == '"':
+			r, n, err := parseEscape(text[i+1:])
+			if err != nil {
+				return "", 0, err
+			}
+			b.WriteRune(r)
+			i += n
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted scalar")
+}
+
+// parseEscape parses the escape sequence following a backslash in a double quoted scalar
+func parseEscape(text string) (rune, int, error) {
+	if text == "" {
+		return 0, 0, fmt.Errorf("unterminated escape sequence")
+	}
+
+	simple := map[byte]rune{
+		'0': 0, 'a': '\a', 'b': '\b', 't': '\t', 'n': '\n', 'v': '\v', 'f': '\f', 'r': '\r',
+		'e': 0x1b, ' ': ' ', '"': '"', '/': '/', '\\': '\\', 'N': 0x85, '_': 0xa0,
+	}
+	if r, ok := simple[text[0]]; ok {
+		return r, 1, nil
+	}
+
+	digits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[text[0]]
+	if digits == 0 || len(text) < 1+digits {
+		return 0, 0, fmt.Errorf("invalid escape sequence \\%c", text[0])
+	}
+
+	n, err := strconv.ParseUint(text[1:1+digits], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(n)) {
+		return 0, 0, fmt.Errorf("invalid escape sequence \\%s", text[:1+digits])
+	}
+
+	return rune(n), 1 + digits, nil
+}
+
+// checkPlain rejects plain scalars using YAML features this package does not support
+func checkPlain(plain string) error {
+	if plain == "" {
+		return nil
+	}
+
+	switch plain[0] {
+	case '&', '*':
+		return fmt.Errorf("anchors and aliases are not supported")
+	case '!':
+		return fmt.Errorf("tags are not supported")
+	case '|', '>':
+		return fmt.Errorf("block scalars are not supported")
+	case '@', '`':
+		return fmt.Errorf("plain scalars cannot start with %q", plain[0])
+	}
+
+	return nil
+}
+
+// resolvePlain converts a plain scalar to null, a boolean, a number or a string, following the YAML 1.2 core schema
+func resolvePlain(plain string) any {
+	switch plain {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+
+	if n, err := strconv.ParseInt(plain, 10, 64); err == nil && isNumeric(plain) {
+		return n
+	}
+
+	if strings.HasPrefix(plain, "0x") || strings.HasPrefix(plain, "0o") {
+		if n, err := strconv.ParseInt(plain[2:], map[byte]int{'x': 16, 'o': 8}[plain[1]], 64); err == nil {
+			return n
+		}
+	}
+
+	if f, err := strconv.ParseFloat(plain, 64); err == nil && isNumeric(plain) {
+		return f
+	}
+
+	return plain
+}
+
+// isNumeric checks if a plain scalar is written like a decimal number, so that strconv extensions such as 1_000 are strings
+func isNumeric(plain string) bool {
+	for i := 0; i < len(plain); i++ {
+		if strings.IndexByte("0123456789+-.eE", plain[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
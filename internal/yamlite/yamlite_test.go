@@ -0,0 +1,165 @@
+package yamlite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Tests Unmarshal(data []byte) (any, error)
+func TestUnmarshal(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{
+			name:     "empty document",
+			input:    "# only a comment\n\n",
+			expected: nil,
+		},
+		{
+			name:  "block mapping with scalars",
+			input: "---\nversion: 1\nname: signup form # trailing comment\nratio: 0.5\nenabled: true\nmissing: ~\n",
+			expected: map[string]any{
+				"version": int64(1),
+				"name":    "signup form",
+				"ratio":   0.5,
+				"enabled": true,
+				"missing": nil,
+			},
+		},
+		{
+			name:  "nested mappings and sequences",
+			input: "fields:\n  username:\n    - required\n    - rule: min\n      args: [3, runes]\n      message: 'Too short, it''s {min}'\n  tags:\n  - lower\n",
+			expected: map[string]any{
+				"fields": map[string]any{
+					"username": []any{
+						"required",
+						map[string]any{
+							"rule":    "min",
+							"args":    []any{int64(3), "runes"},
+							"message": "Too short, it's {min}",
+						},
+					},
+					"tags": []any{"lower"},
+				},
+			},
+		},
+		{
+			name:  "quoted scalars",
+			input: "a: \"tab\\there \\u00e9 # not a comment\"\n\"quoted key\": 'x'\nregex: ^[a-z]+:\\d$\n",
+			expected: map[string]any{
+				"a":          "tab\there é # not a comment",
+				"quoted key": "x",
+				"regex":      `^[a-z]+:\d$`,
+			},
+		},
+		{
+			name:  "flow collections",
+			input: "list: [a, 'b, c', [1, 2], {k: v}]\nmap: {x: 1, y: [true]}\nempty: []\n",
+			expected: map[string]any{
+				"list":  []any{"a", "b, c", []any{int64(1), int64(2)}, map[string]any{"k": "v"}},
+				"map":   map[string]any{"x": int64(1), "y": []any{true}},
+				"empty": []any{},
+			},
+		},
+		{
+			name:     "nested sequences",
+			input:    "- - a\n  - b\n-\n  - c\n- \n",
+			expected: []any{[]any{"a", "b"}, []any{"c"}, nil},
+		},
+		{
+			name:     "numbers that stay strings",
+			input:    "- 1_000\n- 0x1F\n- 1.2.3\n- +7\n",
+			expected: []any{"1_000", int64(31), "1.2.3", int64(7)},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Unmarshal([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(v, tt.expected) {
+				t.Errorf("Unmarshal() = %#v, want %#v", v, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests the *Error returned by Unmarshal(data []byte) (any, error)
+func TestUnmarshalErrors(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		input        string
+		expectedLine int
+	}{
+		{
+			name:         "tab indentation",
+			input:        "a:\n\t- b\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "duplicate key",
+			input:        "a: 1\nb: 2\na: 3\n",
+			expectedLine: 3,
+		},
+		{
+			name:         "bad indentation",
+			input:        "a: 1\n   b: 2\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "alias",
+			input:        "a: &anchor 1\n",
+			expectedLine: 1,
+		},
+		{
+			name:         "block scalar",
+			input:        "a: |\n  text\n",
+			expectedLine: 1,
+		},
+		{
+			name:         "unterminated quote",
+			input:        "a: ok\nb: \"open\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "unterminated flow sequence",
+			input:        "a: [1, 2\n",
+			expectedLine: 1,
+		},
+		{
+			name:         "multiple documents",
+			input:        "a: 1\n---\nb: 2\n",
+			expectedLine: 2,
+		},
+		{
+			name:         "sequence item in a mapping",
+			input:        "a: 1\n- b\n",
+			expectedLine: 2,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Unmarshal([]byte(tt.input))
+
+			var yamlErr *Error
+			if !errors.As(err, &yamlErr) {
+				t.Fatalf("Unmarshal() error = %v, want an *Error", err)
+			}
+
+			if yamlErr.Line != tt.expectedLine {
+				t.Errorf("Unmarshal() error = %v, want line %d", err, tt.expectedLine)
+			}
+		})
+	}
+}
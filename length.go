@@ -0,0 +1,88 @@
+package strval
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// A LengthUnit selects how the length of a string is measured
+type LengthUnit int
+
+const (
+	// UnitBytes measures length in bytes of UTF-8, the same as len(str)
+	UnitBytes LengthUnit = iota
+	// UnitRunes measures length in Unicode code points
+	UnitRunes
+	// UnitGraphemes measures length in user-perceived characters (extended grapheme clusters, UAX #29),
+	// so an emoji with a skin tone modifier or a ZWJ sequence counts as one character
+	UnitGraphemes
+)
+
+// String returns the name of the unit
+func (u LengthUnit) String() string {
+	switch u {
+	case UnitBytes:
+		return "bytes"
+	case UnitRunes:
+		return "runes"
+	case UnitGraphemes:
+		return "graphemes"
+	default:
+		return fmt.Sprintf("LengthUnit(%d)", int(u))
+	}
+}
+
+// StringLength returns the length of a string measured in the given unit
+func StringLength(str string, unit LengthUnit) int {
+	switch unit {
+	case UnitRunes:
+		return utf8.RuneCountInString(str)
+	case UnitGraphemes:
+		return GraphemeCount(str)
+	default:
+		return len(str)
+	}
+}
+
+// This option will validate that the string is at least minLength long, measured in the given unit
+func MustHaveMinLengthOfIn(minLength int, unit LengthUnit) StringValidationOption {
+	return func(str, strName string) error {
+		if StringLength(str, unit) < minLength {
+			return newFieldError(strName, str, RuleMinLength, map[string]any{"min": minLength, "unit": unit.String()})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the string is at most maxLength long, measured in the given unit
+func MustHaveMaxLengthOfIn(maxLength int, unit LengthUnit) StringValidationOption {
+	return func(str, strName string) error {
+		if StringLength(str, unit) > maxLength {
+			return newFieldError(strName, str, RuleMaxLength, map[string]any{"max": maxLength, "unit": unit.String()})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the string has between minLength and maxLength code points (inclusive)
+func MustHaveRuneLengthBetween(minLength, maxLength int) StringValidationOption {
+	return MustHaveLengthBetweenIn(minLength, maxLength, UnitRunes)
+}
+
+// This option will validate that the string has between minLength and maxLength user-perceived characters (inclusive)
+func MustHaveGraphemeLengthBetween(minLength, maxLength int) StringValidationOption {
+	return MustHaveLengthBetweenIn(minLength, maxLength, UnitGraphemes)
+}
+
+// This option will validate that the string is between minLength and maxLength long (inclusive), measured in the given unit
+func MustHaveLengthBetweenIn(minLength, maxLength int, unit LengthUnit) StringValidationOption {
+	return func(str, strName string) error {
+		if length := StringLength(str, unit); length < minLength || length > maxLength {
+			return newFieldError(strName, str, RuleLengthBetween, map[string]any{"min": minLength, "max": maxLength, "unit": unit.String()})
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+package strval
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests StringLength(str string, unit LengthUnit) int
+func TestStringLength(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		str       string
+		bytes     int
+		runes     int
+		graphemes int
+	}{
+		{
+			name:      "ascii",
+			str:       "gopher",
+			bytes:     6,
+			runes:     6,
+			graphemes: 6,
+		},
+		{
+			name:      "japanese display name",
+			str:       "山田太郎",
+			bytes:     12,
+			runes:     4,
+			graphemes: 4,
+		},
+		{
+			name:      "emoji zwj sequence",
+			str:       "👩🏽‍💻",
+			bytes:     15,
+			runes:     4,
+			graphemes: 1,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringLength(tt.str, UnitBytes); got != tt.bytes {
+				t.Errorf("StringLength(%q, UnitBytes) = %d, want %d", tt.str, got, tt.bytes)
+			}
+			if got := StringLength(tt.str, UnitRunes); got != tt.runes {
+				t.Errorf("StringLength(%q, UnitRunes) = %d, want %d", tt.str, got, tt.runes)
+			}
+			if got := StringLength(tt.str, UnitGraphemes); got != tt.graphemes {
+				t.Errorf("StringLength(%q, UnitGraphemes) = %d, want %d", tt.str, got, tt.graphemes)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustHaveMinLengthOfIn() and MustHaveMaxLengthOfIn()
+func TestMustHaveLengthOfIn(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		option      StringValidationOption
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "japanese name within rune max",
+			option:      MustHaveMaxLengthOfIn(10, UnitRunes),
+			str:         "山田太郎山田太郎山田",
+			errExpected: false,
+		},
+		{
+			name:        "japanese name exceeds byte max",
+			option:      MustHaveMaxLengthOfIn(20, UnitBytes),
+			str:         "山田太郎山田太郎山田",
+			errExpected: true,
+		},
+		{
+			name:        "emoji meets grapheme min",
+			option:      MustHaveMinLengthOfIn(2, UnitGraphemes),
+			str:         "👍🏽👍🏽",
+			errExpected: false,
+		},
+		{
+			name:        "emoji below grapheme min",
+			option:      MustHaveMinLengthOfIn(3, UnitGraphemes),
+			str:         "👍🏽👍🏽",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Errorf("option error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			// Make sure the strName is in the error message
+			if errFound && !strings.Contains(err.Error(), "str") {
+				t.Errorf("option strName error = %v, expected to contain strName str", err)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustHaveRuneLengthBetween(), MustHaveGraphemeLengthBetween() and MustHaveLengthBetweenIn()
+func TestMustHaveLengthBetween(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		option      StringValidationOption
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "runes within range",
+			option:      MustHaveRuneLengthBetween(2, 4),
+			str:         "山田太郎",
+			errExpected: false,
+		},
+		{
+			name:        "runes above range",
+			option:      MustHaveRuneLengthBetween(2, 3),
+			str:         "山田太郎",
+			errExpected: true,
+		},
+		{
+			name:        "runes below range",
+			option:      MustHaveRuneLengthBetween(2, 3),
+			str:         "山",
+			errExpected: true,
+		},
+		{
+			name:        "family emoji counts as one grapheme",
+			option:      MustHaveGraphemeLengthBetween(1, 1),
+			str:         "👨‍👩‍👧‍👦",
+			errExpected: false,
+		},
+		{
+			name:        "graphemes above range",
+			option:      MustHaveGraphemeLengthBetween(1, 2),
+			str:         "🇯🇵🇩🇪🇪🇸",
+			errExpected: true,
+		},
+		{
+			name:        "bytes above range",
+			option:      MustHaveLengthBetweenIn(2, 4, UnitBytes),
+			str:         "山田",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option(tt.str, "str")
+
+			if (err != nil) != tt.errExpected {
+				t.Errorf("option error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "str must have a length between") {
+				t.Errorf("option error = %v, expected length_between message", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package strval
+
+// englishCatalog holds the default message for every built-in rule
+var englishCatalog = Catalog{
+	RuleMinLength:          "{field} must have a minimum length of {min}",
+	RuleMaxLength:          "{field} must have a maximum length of {max}",
+	RuleLengthBetween:      "{field} must have a length between {min} and {max}",
+	RuleNotEmpty:           "{field} must not be empty",
+	RuleAlphaNumeric:       "{field} must be alphanumeric",
+	RuleContainsNumbers:    "{field} must contain numbers",
+	RuleContainsAtLeastOne: "{field} must contain at least one of the following characters: {characters}",
+	RuleNotContainAnyOf:    "{field} must not contain any of the following characters: {characters}",
+	RuleContainsUppercase:  "{field} must contain at least one uppercase letter",
+	RuleContainsLowercase:  "{field} must contain at least one lowercase letter",
+	RulePrintable:          "{field} must only contain printable characters",
+	RuleASCII:              "{field} must only contain ASCII characters",
+	RuleEmail:              "{field} must be a valid email format",
+	RuleOneOf:              "{field} must be one of the following values: {values}",
+	RuleRegex:              "{field} must match the pattern {pattern}",
+	RuleEmailAddress:       "{field} must be a valid email address: {reason}",
+	RuleDisposableEmail:    "{field} must not use a disposable email domain",
+	RuleRoleAccount:        "{field} must be a personal address, not a role account",
+	RuleEmailDomain:        "{field} must be an address at one of the following domains: {domains}",
+	RuleAnyOf:              "{field} must satisfy at least one of the following: {errors}",
+	RuleAllOf:              "{field} must satisfy all of the following: {errors}",
+	RuleNot:                "{field} is not allowed",
+	RulePasswordCommon:     "{field} is a commonly used password",
+	RulePasswordStrength:   "{field} is too easy to guess (strength {score} of 4, at least {min_score} required). {feedback}",
+	RuleMixedScript:        "{field} must not mix characters of different scripts: {scripts}",
+	RuleSingleScript:       "{field} must be written in one of the following scripts: {scripts}",
+	RuleConfusable:         "{field} can be confused with {confusable}",
+	RulePRECIS:             "{field} is not a valid {profile}: {reason}",
+	RuleMinValue:           "{field} must be at least {min}",
+	RuleMaxValue:           "{field} must be at most {max}",
+	RuleValueBetween:       "{field} must be between {min} and {max}",
+	RulePositive:           "{field} must be positive",
+	RuleMultipleOf:         "{field} must be a multiple of {multiple}",
+	RuleBefore:             "{field} must be before {time}",
+	RuleAfter:              "{field} must be after {time}",
+	RuleTimeBetween:        "{field} must be between {start} and {end}",
+	RuleMinItems:           "{field} must have at least {min} items",
+	RuleMaxItems:           "{field} must have at most {max} items",
+	RuleUniqueItems:        "{field} must not contain duplicates: {duplicate}",
+	RuleEach:               "{field} has invalid items: {errors}",
+	RuleRequiredKeys:       "{field} is missing the following keys: {keys}",
+	RuleURL:                "{field} must be an absolute URL: {reason}",
+	RuleURLScheme:          "{field} must use one of the following schemes: {schemes}",
+	RuleURLHost:            "{field} must have one of the following hosts: {hosts}",
+	RuleURLIPHost:          "{field} must use a host name, not an IP address",
+	RuleURLUserInfo:        "{field} must not contain a user name or password",
+	RuleURLFragment:        "{field} must not contain a fragment",
+	RuleRedirectURI:        "{field} is not an allowed redirect URI: {reason}",
+
+	// The feedback of RulePasswordStrength
+	PasswordWarningTop10:                 "This is a top-10 common password",
+	PasswordWarningTop100:                "This is a top-100 common password",
+	PasswordWarningVeryCommon:            "This is a very common password",
+	PasswordWarningSimilarToCommon:       "This is similar to a commonly used password",
+	PasswordWarningSingleWord:            "A word by itself is easy to guess",
+	PasswordWarningUserInputs:            "Passwords based on your personal information are easy to guess",
+	PasswordWarningShortKeyboardPattern:  "Short keyboard patterns are easy to guess",
+	PasswordWarningStraightRow:           "Straight rows of keys are easy to guess",
+	PasswordWarningRepeatedPattern:       `Repeats like "abcabcabc" are only slightly harder to guess than "abc"`,
+	PasswordWarningRepeatedCharacter:     `Repeats like "aaa" are easy to guess`,
+	PasswordWarningSequence:              "Sequences like abc or 6543 are easy to guess",
+	PasswordWarningDate:                  "Dates are often easy to guess",
+	PasswordWarningRecentYear:            "Recent years are easy to guess",
+	PasswordWarningSentence:              "{warning}.",
+	PasswordFeedbackSeparator:            " ",
+	PasswordSuggestionFewWords:           "Use a few words, avoid common phrases.",
+	PasswordSuggestionNoComposition:      "No need for symbols, digits, or uppercase letters.",
+	PasswordSuggestionAddWords:           "Add another word or two. Uncommon words are better.",
+	PasswordSuggestionAllUppercase:       "All-uppercase is almost as easy to guess as all-lowercase.",
+	PasswordSuggestionCapitalization:     "Capitalization doesn't help very much.",
+	PasswordSuggestionReversed:           "Reversed words aren't much harder to guess.",
+	PasswordSuggestionSubstitutions:      "Predictable substitutions like '@' instead of 'a' don't help very much.",
+	PasswordSuggestionLongerKeyboardWalk: "Use a longer keyboard pattern with more turns.",
+	PasswordSuggestionAvoidRepeats:       "Avoid repeated words and characters.",
+	PasswordSuggestionAvoidSequences:     "Avoid sequences.",
+	PasswordSuggestionAvoidDates:         "Avoid dates and years that are associated with you.",
+}
+
+// germanCatalog holds the German messages for the built-in rules
+var germanCatalog = Catalog{
+	RuleMinLength:          "{field} muss mindestens {min} Zeichen lang sein",
+	RuleMaxLength:          "{field} darf höchstens {max} Zeichen lang sein",
+	RuleLengthBetween:      "{field} muss zwischen {min} und {max} Zeichen lang sein",
+	RuleNotEmpty:           "{field} darf nicht leer sein",
+	RuleAlphaNumeric:       "{field} darf nur Buchstaben und Ziffern enthalten",
+	RuleContainsNumbers:    "{field} muss Ziffern enthalten",
+	RuleContainsAtLeastOne: "{field} muss mindestens eines der folgenden Zeichen enthalten: {characters}",
+	RuleNotContainAnyOf:    "{field} darf keines der folgenden Zeichen enthalten: {characters}",
+	RuleContainsUppercase:  "{field} muss mindestens einen Großbuchstaben enthalten",
+	RuleContainsLowercase:  "{field} muss mindestens einen Kleinbuchstaben enthalten",
+	RulePrintable:          "{field} darf nur druckbare Zeichen enthalten",
+	RuleASCII:              "{field} darf nur ASCII-Zeichen enthalten",
+	RuleEmail:              "{field} muss eine gültige E-Mail-Adresse sein",
+	RuleOneOf:              "{field} muss einer der folgenden Werte sein: {values}",
+	RuleRegex:              "{field} muss dem Muster {pattern} entsprechen",
+	RuleEmailAddress:       "{field} muss eine gültige E-Mail-Adresse sein: {reason}",
+	RuleDisposableEmail:    "{field} darf keine Wegwerf-E-Mail-Adresse sein",
+	RuleRoleAccount:        "{field} muss eine persönliche Adresse sein, kein Funktionspostfach",
+	RuleEmailDomain:        "{field} muss eine Adresse bei einer der folgenden Domains sein: {domains}",
+	RuleAnyOf:              "{field} muss mindestens eine der folgenden Bedingungen erfüllen: {errors}",
+	RuleAllOf:              "{field} muss alle folgenden Bedingungen erfüllen: {errors}",
+	RuleNot:                "{field} ist nicht zulässig",
+	RulePasswordCommon:     "{field} ist ein häufig verwendetes Passwort",
+	RulePasswordStrength:   "{field} ist zu leicht zu erraten (Stärke {score} von 4, mindestens {min_score} erforderlich). {feedback}",
+	RuleMixedScript:        "{field} darf keine Zeichen verschiedener Schriften mischen: {scripts}",
+	RuleSingleScript:       "{field} muss in einer der folgenden Schriften geschrieben sein: {scripts}",
+	RuleConfusable:         "{field} ist leicht mit {confusable} zu verwechseln",
+	RulePRECIS:             "{field} ist kein gültiger Wert für {profile}: {reason}",
+	RuleMinValue:           "{field} muss mindestens {min} sein",
+	RuleMaxValue:           "{field} darf höchstens {max} sein",
+	RuleValueBetween:       "{field} muss zwischen {min} und {max} liegen",
+	RulePositive:           "{field} muss positiv sein",
+	RuleMultipleOf:         "{field} muss ein Vielfaches von {multiple} sein",
+	RuleBefore:             "{field} muss vor {time} liegen",
+	RuleAfter:              "{field} muss nach {time} liegen",
+	RuleTimeBetween:        "{field} muss zwischen {start} und {end} liegen",
+	RuleMinItems:           "{field} muss mindestens {min} Einträge haben",
+	RuleMaxItems:           "{field} darf höchstens {max} Einträge haben",
+	RuleUniqueItems:        "{field} darf keine doppelten Einträge enthalten: {duplicate}",
+	RuleEach:               "{field} enthält ungültige Einträge: {errors}",
+	RuleRequiredKeys:       "{field} fehlen die folgenden Schlüssel: {keys}",
+	RuleURL:                "{field} muss eine absolute URL sein: {reason}",
+	RuleURLScheme:          "{field} muss eines der folgenden Schemata verwenden: {schemes}",
+	RuleURLHost:            "{field} muss einen der folgenden Hosts haben: {hosts}",
+	RuleURLIPHost:          "{field} muss einen Hostnamen statt einer IP-Adresse verwenden",
+	RuleURLUserInfo:        "{field} darf keinen Benutzernamen und kein Passwort enthalten",
+	RuleURLFragment:        "{field} darf kein Fragment enthalten",
+	RuleRedirectURI:        "{field} ist keine zulässige Weiterleitungs-URI: {reason}",
+
+	// The feedback of RulePasswordStrength
+	PasswordWarningTop10:                 "Dies ist eines der 10 häufigsten Passwörter",
+	PasswordWarningTop100:                "Dies ist eines der 100 häufigsten Passwörter",
+	PasswordWarningVeryCommon:            "Dies ist ein sehr häufiges Passwort",
+	PasswordWarningSimilarToCommon:       "Dies ähnelt einem häufig verwendeten Passwort",
+	PasswordWarningSingleWord:            "Ein einzelnes Wort ist leicht zu erraten",
+	PasswordWarningUserInputs:            "Passwörter, die auf persönlichen Informationen beruhen, sind leicht zu erraten",
+	PasswordWarningShortKeyboardPattern:  "Kurze Tastaturmuster sind leicht zu erraten",
+	PasswordWarningStraightRow:           "Gerade Tastenreihen sind leicht zu erraten",
+	PasswordWarningRepeatedPattern:       "Wiederholungen wie „abcabcabc“ sind kaum schwerer zu erraten als „abc“",
+	PasswordWarningRepeatedCharacter:     "Wiederholungen wie „aaa“ sind leicht zu erraten",
+	PasswordWarningSequence:              "Folgen wie abc oder 6543 sind leicht zu erraten",
+	PasswordWarningDate:                  "Datumsangaben sind oft leicht zu erraten",
+	PasswordWarningRecentYear:            "Jahreszahlen der letzten Jahre sind leicht zu erraten",
+	PasswordWarningSentence:              "{warning}.",
+	PasswordFeedbackSeparator:            " ",
+	PasswordSuggestionFewWords:           "Verwenden Sie mehrere Wörter und vermeiden Sie gängige Redewendungen.",
+	PasswordSuggestionNoComposition:      "Sonderzeichen, Ziffern oder Großbuchstaben sind nicht nötig.",
+	PasswordSuggestionAddWords:           "Fügen Sie ein oder zwei weitere Wörter hinzu. Ungewöhnliche Wörter sind besser.",
+	PasswordSuggestionAllUppercase:       "Nur Großbuchstaben sind kaum schwerer zu erraten als nur Kleinbuchstaben.",
+	PasswordSuggestionCapitalization:     "Großschreibung hilft nicht viel.",
+	PasswordSuggestionReversed:           "Rückwärts geschriebene Wörter sind kaum schwerer zu erraten.",
+	PasswordSuggestionSubstitutions:      "Vorhersehbare Ersetzungen wie „@“ statt „a“ helfen nicht viel.",
+	PasswordSuggestionLongerKeyboardWalk: "Verwenden Sie ein längeres Tastaturmuster mit mehr Richtungswechseln.",
+	PasswordSuggestionAvoidRepeats:       "Vermeiden Sie wiederholte Wörter und Zeichen.",
+	PasswordSuggestionAvoidSequences:     "Vermeiden Sie Zeichenfolgen.",
+	PasswordSuggestionAvoidDates:         "Vermeiden Sie Daten und Jahreszahlen, die mit Ihnen in Verbindung stehen.",
+}
+
+// spanishCatalog holds the Spanish messages for the built-in rules
+var spanishCatalog = Catalog{
+	RuleMinLength:          "{field} debe tener una longitud mínima de {min}",
+	RuleMaxLength:          "{field} debe tener una longitud máxima de {max}",
+	RuleLengthBetween:      "{field} debe tener una longitud entre {min} y {max}",
+	RuleNotEmpty:           "{field} no debe estar vacío",
+	RuleAlphaNumeric:       "{field} debe ser alfanumérico",
+	RuleContainsNumbers:    "{field} debe contener números",
+	RuleContainsAtLeastOne: "{field} debe contener al menos uno de los siguientes caracteres: {characters}",
+	RuleNotContainAnyOf:    "{field} no debe contener ninguno de los siguientes caracteres: {characters}",
+	RuleContainsUppercase:  "{field} debe contener al menos una letra mayúscula",
+	RuleContainsLowercase:  "{field} debe contener al menos una letra minúscula",
+	RulePrintable:          "{field} solo debe contener caracteres imprimibles",
+	RuleASCII:              "{field} solo debe contener caracteres ASCII",
+	RuleEmail:              "{field} debe tener un formato de correo electrónico válido",
+	RuleOneOf:              "{field} debe ser uno de los siguientes valores: {values}",
+	RuleRegex:              "{field} debe coincidir con el patrón {pattern}",
+	RuleEmailAddress:       "{field} debe ser una dirección de correo electrónico válida: {reason}",
+	RuleDisposableEmail:    "{field} no debe usar un dominio de correo desechable",
+	RuleRoleAccount:        "{field} debe ser una dirección personal, no una cuenta genérica",
+	RuleEmailDomain:        "{field} debe ser una dirección de uno de los siguientes dominios: {domains}",
+	RuleAnyOf:              "{field} debe cumplir al menos una de las siguientes condiciones: {errors}",
+	RuleAllOf:              "{field} debe cumplir todas las siguientes condiciones: {errors}",
+	RuleNot:                "{field} no está permitido",
+	RulePasswordCommon:     "{field} es una contraseña de uso común",
+	RulePasswordStrength:   "{field} es demasiado fácil de adivinar (seguridad {score} de 4, se requiere al menos {min_score}). {feedback}",
+	RuleMixedScript:        "{field} no debe mezclar caracteres de distintas escrituras: {scripts}",
+	RuleSingleScript:       "{field} debe estar escrito en una de las siguientes escrituras: {scripts}",
+	RuleConfusable:         "{field} se puede confundir con {confusable}",
+	RulePRECIS:             "{field} no es un valor válido de {profile}: {reason}",
+	RuleMinValue:           "{field} debe ser como mínimo {min}",
+	RuleMaxValue:           "{field} debe ser como máximo {max}",
+	RuleValueBetween:       "{field} debe estar entre {min} y {max}",
+	RulePositive:           "{field} debe ser positivo",
+	RuleMultipleOf:         "{field} debe ser un múltiplo de {multiple}",
+	RuleBefore:             "{field} debe ser anterior a {time}",
+	RuleAfter:              "{field} debe ser posterior a {time}",
+	RuleTimeBetween:        "{field} debe estar entre {start} y {end}",
+	RuleMinItems:           "{field} debe tener al menos {min} elementos",
+	RuleMaxItems:           "{field} debe tener como máximo {max} elementos",
+	RuleUniqueItems:        "{field} no debe contener duplicados: {duplicate}",
+	RuleEach:               "{field} tiene elementos no válidos: {errors}",
+	RuleRequiredKeys:       "a {field} le faltan las siguientes claves: {keys}",
+	RuleURL:                "{field} debe ser una URL absoluta: {reason}",
+	RuleURLScheme:          "{field} debe usar uno de los siguientes esquemas: {schemes}",
+	RuleURLHost:            "{field} debe tener uno de los siguientes hosts: {hosts}",
+	RuleURLIPHost:          "{field} debe usar un nombre de host, no una dirección IP",
+	RuleURLUserInfo:        "{field} no debe contener un nombre de usuario ni una contraseña",
+	RuleURLFragment:        "{field} no debe contener un fragmento",
+	RuleRedirectURI:        "{field} no es una URI de redirección permitida: {reason}",
+
+	// The feedback of RulePasswordStrength
+	PasswordWarningTop10:                 "Esta es una de las 10 contraseñas más comunes",
+	PasswordWarningTop100:                "Esta es una de las 100 contraseñas más comunes",
+	PasswordWarningVeryCommon:            "Esta es una contraseña muy común",
+	PasswordWarningSimilarToCommon:       "Se parece a una contraseña de uso común",
+	PasswordWarningSingleWord:            "Una palabra sola es fácil de adivinar",
+	PasswordWarningUserInputs:            "Las contraseñas basadas en información personal son fáciles de adivinar",
+	PasswordWarningShortKeyboardPattern:  "Los patrones de teclado cortos son fáciles de adivinar",
+	PasswordWarningStraightRow:           "Las filas de teclas seguidas son fáciles de adivinar",
+	PasswordWarningRepeatedPattern:       `Las repeticiones como "abcabcabc" son apenas más difíciles de adivinar que "abc"`,
+	PasswordWarningRepeatedCharacter:     `Las repeticiones como "aaa" son fáciles de adivinar`,
+	PasswordWarningSequence:              "Las secuencias como abc o 6543 son fáciles de adivinar",
+	PasswordWarningDate:                  "Las fechas suelen ser fáciles de adivinar",
+	PasswordWarningRecentYear:            "Los años recientes son fáciles de adivinar",
+	PasswordWarningSentence:              "{warning}.",
+	PasswordFeedbackSeparator:            " ",
+	PasswordSuggestionFewWords:           "Use varias palabras y evite frases comunes.",
+	PasswordSuggestionNoComposition:      "No hacen falta símbolos, dígitos ni mayúsculas.",
+	PasswordSuggestionAddWords:           "Añada una o dos palabras más. Las palabras poco comunes son mejores.",
+	PasswordSuggestionAllUppercase:       "Todo en mayúsculas es casi tan fácil de adivinar como todo en minúsculas.",
+	PasswordSuggestionCapitalization:     "Las mayúsculas iniciales no ayudan mucho.",
+	PasswordSuggestionReversed:           "Las palabras escritas al revés no son mucho más difíciles de adivinar.",
+	PasswordSuggestionSubstitutions:      "Las sustituciones previsibles como '@' en lugar de 'a' no ayudan mucho.",
+	PasswordSuggestionLongerKeyboardWalk: "Use un patrón de teclado más largo y con más giros.",
+	PasswordSuggestionAvoidRepeats:       "Evite repetir palabras y caracteres.",
+	PasswordSuggestionAvoidSequences:     "Evite las secuencias.",
+	PasswordSuggestionAvoidDates:         "Evite fechas y años relacionados con usted.",
+}
+
+// japaneseCatalog holds the Japanese messages for the built-in rules
+var japaneseCatalog = Catalog{
+	RuleMinLength:          "{field}は{min}文字以上で入力してください",
+	RuleMaxLength:          "{field}は{max}文字以内で入力してください",
+	RuleLengthBetween:      "{field}は{min}文字以上{max}文字以内で入力してください",
+	RuleNotEmpty:           "{field}を入力してください",
+	RuleAlphaNumeric:       "{field}は英数字のみで入力してください",
+	RuleContainsNumbers:    "{field}には数字を含めてください",
+	RuleContainsAtLeastOne: "{field}には次のいずれかの文字を含めてください: {characters}",
+	RuleNotContainAnyOf:    "{field}には次の文字を含めないでください: {characters}",
+	RuleContainsUppercase:  "{field}には大文字を1文字以上含めてください",
+	RuleContainsLowercase:  "{field}には小文字を1文字以上含めてください",
+	RulePrintable:          "{field}には印刷可能な文字のみ使用してください",
+	RuleASCII:              "{field}にはASCII文字のみ使用してください",
+	RuleEmail:              "{field}は有効なメールアドレスの形式で入力してください",
+	RuleOneOf:              "{field}は次のいずれかの値である必要があります: {values}",
+	RuleRegex:              "{field}はパターン{pattern}に一致する必要があります",
+	RuleEmailAddress:       "{field}は有効なメールアドレスではありません: {reason}",
+	RuleDisposableEmail:    "{field}に使い捨てメールアドレスは使用できません",
+	RuleRoleAccount:        "{field}には個人のメールアドレスを入力してください",
+	RuleEmailDomain:        "{field}は次のいずれかのドメインのアドレスである必要があります: {domains}",
+	RuleAnyOf:              "{field}は次のいずれかの条件を満たす必要があります: {errors}",
+	RuleAllOf:              "{field}は次のすべての条件を満たす必要があります: {errors}",
+	RuleNot:                "{field}は使用できません",
+	RulePasswordCommon:     "{field}はよく使われるパスワードです",
+	RulePasswordStrength:   "{field}は推測されやすすぎます（強度{score}/4、{min_score}以上が必要です）{feedback}",
+	RuleMixedScript:        "{field}に異なる文字体系の文字を混在させないでください: {scripts}",
+	RuleSingleScript:       "{field}は次のいずれかの文字体系で入力してください: {scripts}",
+	RuleConfusable:         "{field}は{confusable}と紛らわしいため使用できません",
+	RulePRECIS:             "{field}は{profile}として使用できません: {reason}",
+	RuleMinValue:           "{field}は{min}以上で入力してください",
+	RuleMaxValue:           "{field}は{max}以下で入力してください",
+	RuleValueBetween:       "{field}は{min}以上{max}以下で入力してください",
+	RulePositive:           "{field}は正の数で入力してください",
+	RuleMultipleOf:         "{field}は{multiple}の倍数で入力してください",
+	RuleBefore:             "{field}は{time}より前の日時を入力してください",
+	RuleAfter:              "{field}は{time}より後の日時を入力してください",
+	RuleTimeBetween:        "{field}は{start}から{end}までの日時を入力してください",
+	RuleMinItems:           "{field}には{min}個以上の項目が必要です",
+	RuleMaxItems:           "{field}の項目は{max}個以内にしてください",
+	RuleUniqueItems:        "{field}に重複した項目があります: {duplicate}",
+	RuleEach:               "{field}に無効な項目があります: {errors}",
+	RuleRequiredKeys:       "{field}に次のキーがありません: {keys}",
+	RuleURL:                "{field}は絶対URLで入力してください: {reason}",
+	RuleURLScheme:          "{field}は次のいずれかのスキームを使用する必要があります: {schemes}",
+	RuleURLHost:            "{field}のホストは次のいずれかである必要があります: {hosts}",
+	RuleURLIPHost:          "{field}にはIPアドレスではなくホスト名を使用してください",
+	RuleURLUserInfo:        "{field}にユーザー名やパスワードを含めないでください",
+	RuleURLFragment:        "{field}にフラグメントを含めないでください",
+	RuleRedirectURI:        "{field}は許可されたリダイレクトURIではありません: {reason}",
+
+	// The feedback of RulePasswordStrength
+	PasswordWarningTop10:                 "よく使われるパスワードの上位10位に入っています",
+	PasswordWarningTop100:                "よく使われるパスワードの上位100位に入っています",
+	PasswordWarningVeryCommon:            "非常によく使われるパスワードです",
+	PasswordWarningSimilarToCommon:       "よく使われるパスワードに似ています",
+	PasswordWarningSingleWord:            "単語1つだけでは簡単に推測されます",
+	PasswordWarningUserInputs:            "個人情報に基づくパスワードは簡単に推測されます",
+	PasswordWarningShortKeyboardPattern:  "短いキーボードのパターンは簡単に推測されます",
+	PasswordWarningStraightRow:           "キーボードの一列に並んだキーは簡単に推測されます",
+	PasswordWarningRepeatedPattern:       "「abcabcabc」のような繰り返しは「abc」よりわずかに推測しにくいだけです",
+	PasswordWarningRepeatedCharacter:     "「aaa」のような繰り返しは簡単に推測されます",
+	PasswordWarningSequence:              "abcや6543のような連続した文字は簡単に推測されます",
+	PasswordWarningDate:                  "日付は推測されやすいことがよくあります",
+	PasswordWarningRecentYear:            "最近の年は簡単に推測されます",
+	PasswordWarningSentence:              "{warning}。",
+	PasswordFeedbackSeparator:            "",
+	PasswordSuggestionFewWords:           "ありふれたフレーズを避けて、いくつかの単語を使ってください。",
+	PasswordSuggestionNoComposition:      "記号、数字、大文字は必要ありません。",
+	PasswordSuggestionAddWords:           "単語を1つか2つ追加してください。珍しい単語ほど効果的です。",
+	PasswordSuggestionAllUppercase:       "すべて大文字にしても、すべて小文字とほとんど変わらず推測されやすいです。",
+	PasswordSuggestionCapitalization:     "大文字を使ってもあまり効果はありません。",
+	PasswordSuggestionReversed:           "単語を逆さにしても推測の難しさはあまり変わりません。",
+	PasswordSuggestionSubstitutions:      "「a」の代わりに「@」を使うような予測しやすい置き換えはあまり効果がありません。",
+	PasswordSuggestionLongerKeyboardWalk: "曲がる箇所の多い、より長いキーボードのパターンを使ってください。",
+	PasswordSuggestionAvoidRepeats:       "単語や文字の繰り返しは避けてください。",
+	PasswordSuggestionAvoidSequences:     "連続した文字は避けてください。",
+	PasswordSuggestionAvoidDates:         "自分に関係のある日付や年は避けてください。",
+}
+
+// bundledCatalogs holds the catalogs shipped with the package, keyed by locale
+var bundledCatalogs = map[string]Catalog{
+	"en": englishCatalog,
+	"de": germanCatalog,
+	"es": spanishCatalog,
+	"ja": japaneseCatalog,
+}
@@ -0,0 +1,208 @@
+package strval
+
+import (
+	"sort"
+	"strings"
+)
+
+// This represents the results of the values of a nested payload, each one at its Path
+// Results are added with Add or Validate, and the results of sub-validators combined with Merge.
+// The zero value is empty and becomes valid when the first result is added or merged, like the result of NewNestedResult.
+type NestedResult struct {
+	Valid bool
+	// The entries are keyed by the dotted path, as a JSON pointer does not tell the key "3" from the index 3
+	entries map[string]*nestedEntry
+}
+
+// The result of the value at a path
+type nestedEntry struct {
+	path   Path
+	result ValidationResult
+}
+
+// NewNestedResult returns an empty, valid NestedResult
+func NewNestedResult() *NestedResult {
+	return &NestedResult{
+		Valid:   true,
+		entries: make(map[string]*nestedEntry),
+	}
+}
+
+// Add records the result of the value at a path, marking the payload invalid if the value is invalid
+// The failures are appended to those already recorded at the same path.
+func (r *NestedResult) Add(path Path, result ValidationResult) {
+	r.init()
+	key := path.String()
+
+	entry, ok := r.entries[key]
+	if !ok {
+		// The failures are copied so that appending to them never changes the result of the caller
+		entry = &nestedEntry{path: path.Join(nil), result: ValidationResult{Valid: true}}
+		r.entries[key] = entry
+	}
+
+	entry.result.Valid = entry.result.Valid && result.Valid
+	entry.result.Messages = append(entry.result.Messages, result.Messages...)
+	entry.result.Errors = append(entry.result.Errors, result.Errors...)
+	entry.result.Warnings = append(entry.result.Warnings, result.Warnings...)
+	entry.result.Infos = append(entry.result.Infos, result.Infos...)
+
+	if !result.Valid {
+		r.Valid = false
+	}
+}
+
+// Validate validates the string at a path against the provided options and records its result
+// The string is named after its dotted path in the messages, e.g. orders[3].shipping.address.line1 must not be empty,
+// or String like with ValidateString at the root.
+func (r *NestedResult) Validate(path Path, str string, options ...StringValidationOption) {
+	if len(path) == 0 {
+		r.Add(path, ValidateString(str, options...))
+		return
+	}
+
+	r.Add(path, ValidateStringWithName(str, path.String(), options...))
+}
+
+// Merge records the results of a sub-validator under a prefix, e.g. the result of an address under orders[3].shipping
+// Messages are not renamed: name the values after their full path when they are validated.
+func (r *NestedResult) Merge(prefix Path, other *NestedResult) {
+	r.init()
+	for _, entry := range other.sortedEntries() {
+		r.Add(prefix.Join(entry.path), entry.result)
+	}
+
+	// A zero value that recorded nothing is not invalid
+	if !other.Valid && other.entries != nil {
+		r.Valid = false
+	}
+}
+
+// Get returns the result recorded at a path
+func (r *NestedResult) Get(path Path) (ValidationResult, bool) {
+	entry, ok := r.entries[path.String()]
+	if !ok {
+		return ValidationResult{}, false
+	}
+
+	return entry.result, true
+}
+
+// Paths returns the paths of the recorded results, sorted
+func (r *NestedResult) Paths() []Path {
+	entries := r.sortedEntries()
+
+	paths := make([]Path, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.path
+	}
+
+	return paths
+}
+
+// Flatten returns the error messages of every invalid value, keyed by its dotted path, e.g. for the errors of an API response
+func (r *NestedResult) Flatten() map[string][]string {
+	return r.flatten(Path.String)
+}
+
+// FlattenJSONPointer returns the error messages of every invalid value, keyed by its JSON pointer (RFC 6901)
+func (r *NestedResult) FlattenJSONPointer() map[string][]string {
+	return r.flatten(Path.JSONPointer)
+}
+
+// Tree renders the failures as a tree of paths for logs, one indented line per path segment and failure
+//
+//	orders
+//	  [3]
+//	    shipping
+//	      address
+//	        line1
+//	          error: orders[3].shipping.address.line1 must not be empty
+func (r *NestedResult) Tree() string {
+	var sb strings.Builder
+	var previous Path
+
+	for _, entry := range r.sortedEntries() {
+		result := entry.result
+		if len(result.Errors) == 0 && len(result.Warnings) == 0 && len(result.Infos) == 0 {
+			continue
+		}
+
+		// The segments shared with the previous path are already written
+		common := 0
+		for common < len(previous) && common < len(entry.path) && previous[common] == entry.path[common] {
+			common++
+		}
+		for i := common; i < len(entry.path); i++ {
+			sb.WriteString(strings.Repeat("  ", i))
+			sb.WriteString(entry.path[i : i+1].String())
+			sb.WriteByte('\n')
+		}
+		previous = entry.path
+
+		indent := strings.Repeat("  ", len(entry.path))
+		for _, failures := range [][]*FieldError{result.Errors, result.Warnings, result.Infos} {
+			for _, fieldErr := range failures {
+				sb.WriteString(indent)
+				sb.WriteString(fieldErr.Severity.String())
+				sb.WriteString(": ")
+				sb.WriteString(fieldErr.Message)
+				sb.WriteByte('\n')
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// Nested returns the results of the fields of a struct as a NestedResult, parsing their dotted paths
+func (r StructValidationResult) Nested() *NestedResult {
+	nested := NewNestedResult()
+
+	for name, fieldResult := range r.Fields {
+		path, err := ParsePath(name)
+		if err != nil {
+			// Field names come from ValidateStruct, a name that is not a path is kept as a single key
+			path = Path{{Key: name}}
+		}
+		nested.Add(path, fieldResult)
+	}
+
+	return nested
+}
+
+// init prepares a zero value NestedResult for its first result
+func (r *NestedResult) init() {
+	if r.entries == nil {
+		r.entries = make(map[string]*nestedEntry)
+		r.Valid = true
+	}
+}
+
+// flatten returns the error messages of every invalid value, keyed by format
+func (r *NestedResult) flatten(format func(Path) string) map[string][]string {
+	flat := make(map[string][]string)
+
+	for _, entry := range r.entries {
+		if len(entry.result.Messages) > 0 {
+			// Copied so that callers cannot change the recorded results
+			flat[format(entry.path)] = append([]string(nil), entry.result.Messages...)
+		}
+	}
+
+	return flat
+}
+
+// sortedEntries returns the recorded results sorted by path
+func (r *NestedResult) sortedEntries() []*nestedEntry {
+	entries := make([]*nestedEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].path.compare(entries[j].path) < 0
+	})
+
+	return entries
+}
@@ -0,0 +1,208 @@
+package strval
+
+import (
+	"reflect"
+	"testing"
+)
+
+// validateAddress validates an address as a sub-validator, with paths relative to the address
+func validateAddress(prefix Path, line1, zip string) *NestedResult {
+	result := NewNestedResult()
+	result.Validate(prefix.Key("line1"), line1, MustNotBeEmpty())
+	result.Validate(prefix.Key("zip"), zip, MustContainNumbers(), WithSeverity(SeverityWarning, MustHaveMinLengthOf(5)))
+
+	return result
+}
+
+// Tests NestedResult.Add(), Validate() and Merge()
+func TestNestedResult(t *testing.T) {
+	orders := Path{}.Key("orders")
+
+	result := NewNestedResult()
+	result.Validate(orders.Index(0).Key("id"), "A-1", MustNotBeEmpty())
+	result.Merge(nil, validateAddress(orders.Index(0).Key("shipping").Key("address"), "1 Main St", "12345"))
+	result.Merge(nil, validateAddress(orders.Index(3).Key("shipping").Key("address"), "", "ab"))
+
+	if result.Valid {
+		t.Fatal("Valid = true, want false")
+	}
+
+	expectedFlat := map[string][]string{
+		"orders[3].shipping.address.line1": {"orders[3].shipping.address.line1 must not be empty"},
+		"orders[3].shipping.address.zip":   {"orders[3].shipping.address.zip must contain numbers"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, expectedFlat) {
+		t.Errorf("Flatten() = %v, want %v", got, expectedFlat)
+	}
+
+	expectedPointers := map[string][]string{
+		"/orders/3/shipping/address/line1": {"orders[3].shipping.address.line1 must not be empty"},
+		"/orders/3/shipping/address/zip":   {"orders[3].shipping.address.zip must contain numbers"},
+	}
+	if got := result.FlattenJSONPointer(); !reflect.DeepEqual(got, expectedPointers) {
+		t.Errorf("FlattenJSONPointer() = %v, want %v", got, expectedPointers)
+	}
+
+	expectedPaths := []string{
+		"orders[0].id",
+		"orders[0].shipping.address.line1",
+		"orders[0].shipping.address.zip",
+		"orders[3].shipping.address.line1",
+		"orders[3].shipping.address.zip",
+	}
+	paths := result.Paths()
+	if len(paths) != len(expectedPaths) {
+		t.Fatalf("Paths() = %v, want %v", paths, expectedPaths)
+	}
+	for i, path := range expectedPaths {
+		if paths[i].String() != path {
+			t.Errorf("Paths()[%d] = %s, want %s", i, paths[i], path)
+		}
+	}
+
+	zip, ok := result.Get(orders.Index(3).Key("shipping").Key("address").Key("zip"))
+	if !ok || zip.Valid || len(zip.Warnings) != 1 {
+		t.Errorf("Get() = %+v, %v, want the invalid zip with a warning", zip, ok)
+	}
+}
+
+// Tests NestedResult.Merge() under a prefix and at the same path
+func TestNestedResultMerge(t *testing.T) {
+	address := NewNestedResult()
+	address.Add(Path{}.Key("line1"), ValidateStringWithName("", "line1", MustNotBeEmpty()))
+
+	result := NewNestedResult()
+	result.Add(Path{}.Key("shipping").Key("line1"), ValidateStringWithName("", "line1", MustHaveMinLengthOf(3)))
+	result.Merge(Path{}.Key("shipping"), address)
+
+	line1, ok := result.Get(Path{}.Key("shipping").Key("line1"))
+	if !ok || len(line1.Errors) != 2 {
+		t.Fatalf("Get() = %+v, %v, want the failures of both results", line1, ok)
+	}
+	if line1.Errors[0].Rule != RuleMinLength || line1.Errors[1].Rule != RuleNotEmpty {
+		t.Errorf("rules = %s, %s, want %s, %s", line1.Errors[0].Rule, line1.Errors[1].Rule, RuleMinLength, RuleNotEmpty)
+	}
+
+	valid := NewNestedResult()
+	valid.Validate(Path{}.Key("name"), "ok", MustNotBeEmpty())
+	valid.Merge(Path{}.Key("items").Index(0), NewNestedResult())
+	if !valid.Valid || len(valid.Flatten()) != 0 {
+		t.Errorf("merging valid results = %+v, want a valid result", valid)
+	}
+}
+
+// Tests that a key made of digits and an index are recorded apart
+func TestNestedResultKeyAndIndex(t *testing.T) {
+	result := NewNestedResult()
+	result.Validate(Path{}.Key("items").Key("3"), "", MustNotBeEmpty())
+	result.Validate(Path{}.Key("items").Index(3), "ok", MustNotBeEmpty())
+
+	if paths := result.Paths(); len(paths) != 2 {
+		t.Fatalf("Paths() = %v, want items[3] and items.3", paths)
+	}
+
+	index, ok := result.Get(Path{}.Key("items").Index(3))
+	if !ok || !index.Valid {
+		t.Errorf("Get(items[3]) = %+v, %v, want a valid result", index, ok)
+	}
+
+	expected := map[string][]string{"items.3": {"items.3 must not be empty"}}
+	if got := result.Flatten(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Flatten() = %v, want %v", got, expected)
+	}
+}
+
+// Tests that the zero value NestedResult is usable
+func TestNestedResultZeroValue(t *testing.T) {
+	var valid NestedResult
+	valid.Validate(Path{}.Key("name"), "ok", MustNotBeEmpty())
+	if !valid.Valid {
+		t.Errorf("Valid = false, want true")
+	}
+
+	var merged NestedResult
+	merged.Merge(nil, NewNestedResult())
+	if !merged.Valid {
+		t.Errorf("Valid after Merge() = false, want true")
+	}
+
+	var invalid NestedResult
+	invalid.Validate(Path{}.Key("name"), "", MustNotBeEmpty())
+	if invalid.Valid || len(invalid.Flatten()) != 1 {
+		t.Errorf("zero value after an invalid result = %+v, want an invalid result", invalid)
+	}
+
+	parent := NewNestedResult()
+	parent.Merge(Path{}.Key("address"), &NestedResult{})
+	if !parent.Valid || len(parent.Paths()) != 0 {
+		t.Errorf("merging an empty zero value = %+v, want a valid result", parent)
+	}
+}
+
+// Tests that the results returned by NestedResult.Flatten() are copies
+func TestNestedResultFlattenCopies(t *testing.T) {
+	result := NewNestedResult()
+	result.Validate(Path{}.Key("name"), "", MustNotBeEmpty())
+
+	result.Flatten()["name"][0] = "changed"
+	result.FlattenJSONPointer()["/name"][0] = "changed"
+
+	if got, _ := result.Get(Path{}.Key("name")); got.Messages[0] != "name must not be empty" {
+		t.Errorf("Get() message = %q after changing the flattened results, want the recorded message", got.Messages[0])
+	}
+}
+
+// Tests NestedResult.Tree()
+func TestNestedResultTree(t *testing.T) {
+	result := NewNestedResult()
+	result.Validate(nil, "x", MustHaveMinLengthOf(3))
+	result.Merge(nil, validateAddress(Path{}.Key("orders").Index(3).Key("shipping").Key("address"), "", "ab"))
+	result.Validate(Path{}.Key("labels").Key("app.kubernetes.io/name"), "", MustNotBeEmpty())
+	result.Validate(Path{}.Key("orders").Index(0).Key("id"), "A-1", MustNotBeEmpty())
+
+	expected := `error: String must have a minimum length of 3
+labels
+  ["app.kubernetes.io/name"]
+    error: labels["app.kubernetes.io/name"] must not be empty
+orders
+  [3]
+    shipping
+      address
+        line1
+          error: orders[3].shipping.address.line1 must not be empty
+        zip
+          error: orders[3].shipping.address.zip must contain numbers
+          warning: orders[3].shipping.address.zip must have a minimum length of 5
+`
+	if got := result.Tree(); got != expected {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+// Tests StructValidationResult.Nested()
+func TestStructValidationResultNested(t *testing.T) {
+	type address struct {
+		Line1 string `json:"line1" strval:"notempty"`
+	}
+	type order struct {
+		Shipping address `json:"shipping"`
+	}
+	type payload struct {
+		Orders []order `json:"orders"`
+	}
+
+	structResult, err := ValidateStruct(payload{Orders: []order{{Shipping: address{Line1: "1 Main St"}}, {}}})
+	if err != nil {
+		t.Fatalf("ValidateStruct() returned error: %v", err)
+	}
+
+	nested := structResult.Nested()
+	if nested.Valid {
+		t.Fatal("Valid = true, want false")
+	}
+
+	expected := map[string][]string{"/orders/1/shipping/line1": {"orders[1].shipping.line1 must not be empty"}}
+	if got := nested.FlattenJSONPointer(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FlattenJSONPointer() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,223 @@
+package strval
+
+// A range of code points sharing a canonical combining class
+type combiningClassRange struct {
+	lo, hi rune
+	class  uint8
+}
+
+// The full decompositions of a code point, empty when the code point does not decompose
+type decomposition struct {
+	r             rune
+	canonical     string
+	compatibility string
+}
+
+// A primary composite and the pair of code points it composes
+type composition struct {
+	first, second, composite rune
+}
+
+// The Hangul jamo and syllables, see section 3.12 of the Unicode standard
+const (
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulNCount = 588
+)
+
+// NFC returns the canonical composition of a string (Unicode Normalization Form C)
+// Invalid UTF-8 bytes are replaced with U+FFFD.
+func NFC(str string) string {
+	return normalize(str, false)
+}
+
+// NFKC returns the compatibility composition of a string (Unicode Normalization Form KC),
+// which also folds compatibility variants such as full-width letters, ligatures and superscripts
+// Invalid UTF-8 bytes are replaced with U+FFFD.
+func NFKC(str string) string {
+	return normalize(str, true)
+}
+
+// normalize decomposes a string, puts its combining marks in canonical order and composes it again
+func normalize(str string, compatibility bool) string {
+	if isQuickNormalized(str, compatibility) {
+		return str
+	}
+
+	return string(compose(decompose(str, compatibility)))
+}
+
+// decompose returns the full canonical or compatibility decomposition of a string, in canonical order (NFD or NFKD)
+func decompose(str string, compatibility bool) []rune {
+	runes := make([]rune, 0, len(str))
+	for _, r := range str {
+		runes = appendDecomposition(runes, r, compatibility)
+	}
+
+	reorder(runes)
+
+	return runes
+}
+
+// isQuickNormalized reports whether a string only holds code points that normalization leaves unchanged
+// whatever surrounds them, so that it can be returned as is
+func isQuickNormalized(str string, compatibility bool) bool {
+	// Below U+0300 nothing composes with a preceding character, and only Latin-1 has compatibility decompositions
+	limit := rune(0x300)
+	if compatibility {
+		limit = 0xA0
+	}
+
+	for _, r := range str {
+		// Invalid UTF-8 decodes as U+FFFD, above the limit
+		if r >= limit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appendDecomposition appends the full decomposition of a code point, itself if it does not decompose
+func appendDecomposition(runes []rune, r rune, compatibility bool) []rune {
+	if r >= hangulSBase && r < hangulSBase+hangulSCount {
+		s := r - hangulSBase
+		runes = append(runes, hangulLBase+s/hangulNCount, hangulVBase+(s%hangulNCount)/hangulTCount)
+		if t := s % hangulTCount; t != 0 {
+			runes = append(runes, hangulTBase+t)
+		}
+		return runes
+	}
+
+	d, ok := lookupDecomposition(r)
+	switch {
+	case !ok:
+		return append(runes, r)
+	case compatibility && d.compatibility != "":
+		return append(runes, []rune(d.compatibility)...)
+	case d.canonical != "":
+		return append(runes, []rune(d.canonical)...)
+	default:
+		return append(runes, r)
+	}
+}
+
+// reorder sorts every run of combining marks by combining class, keeping the order of marks of the same class
+func reorder(runes []rune) {
+	for i := 1; i < len(runes); i++ {
+		for j := i; j > 0; j-- {
+			prev, cur := combiningClass(runes[j-1]), combiningClass(runes[j])
+			if cur == 0 || prev <= cur {
+				break
+			}
+			runes[j-1], runes[j] = runes[j], runes[j-1]
+		}
+	}
+}
+
+// compose replaces every starter and the following characters it is not blocked from with their primary composite
+func compose(runes []rune) []rune {
+	if len(runes) == 0 {
+		return runes
+	}
+
+	starter := 0
+	lastClass := uint16(combiningClass(runes[0]))
+	if lastClass != 0 {
+		// A leading combining mark has no starter to compose with
+		lastClass = 256
+	}
+
+	n := 1
+	for _, r := range runes[1:] {
+		class := combiningClass(r)
+
+		if composite, ok := primaryComposite(runes[starter], r); ok && (lastClass < uint16(class) || lastClass == 0) {
+			runes[starter] = composite
+			continue
+		}
+
+		if class == 0 {
+			starter = n
+		}
+		lastClass = uint16(class)
+		runes[n] = r
+		n++
+	}
+
+	return runes[:n]
+}
+
+// combiningClass returns the canonical combining class of a code point
+func combiningClass(r rune) uint8 {
+	if r < 0x300 {
+		return 0
+	}
+
+	lo, hi := 0, len(combiningClassRanges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < combiningClassRanges[mid].lo:
+			hi = mid
+		case r > combiningClassRanges[mid].hi:
+			lo = mid + 1
+		default:
+			return combiningClassRanges[mid].class
+		}
+	}
+
+	return 0
+}
+
+// lookupDecomposition returns the decompositions of a code point
+func lookupDecomposition(r rune) (decomposition, bool) {
+	lo, hi := 0, len(decompositions)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < decompositions[mid].r:
+			hi = mid
+		case r > decompositions[mid].r:
+			lo = mid + 1
+		default:
+			return decompositions[mid], true
+		}
+	}
+
+	return decomposition{}, false
+}
+
+// primaryComposite returns the code point composing a pair of code points, if there is one
+func primaryComposite(first, second rune) (rune, bool) {
+	// Hangul LV and LVT syllables
+	if first >= hangulLBase && first < hangulLBase+hangulLCount && second >= hangulVBase && second < hangulVBase+hangulVCount {
+		return hangulSBase + ((first-hangulLBase)*hangulVCount+second-hangulVBase)*hangulTCount, true
+	}
+	if first >= hangulSBase && first < hangulSBase+hangulSCount && (first-hangulSBase)%hangulTCount == 0 &&
+		second > hangulTBase && second < hangulTBase+hangulTCount {
+		return first + second - hangulTBase, true
+	}
+
+	lo, hi := 0, len(compositions)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		c := compositions[mid]
+
+		switch {
+		case first < c.first || (first == c.first && second < c.second):
+			hi = mid
+		case first > c.first || second > c.second:
+			lo = mid + 1
+		default:
+			return c.composite, true
+		}
+	}
+
+	return 0, false
+}
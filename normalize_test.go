@@ -0,0 +1,212 @@
+package strval
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tests NFC, NFKC and the NFD and NFKD decompositions against the Unicode NormalizationTest.txt conformance data
+func TestNormalizationConformance(t *testing.T) {
+	f, err := os.Open("testdata/NormalizationTest.txt")
+	if err != nil {
+		t.Fatalf("failed to open test data: %v", err)
+	}
+	defer f.Close()
+
+	nfd := func(str string) string { return string(decompose(str, false)) }
+	nfkd := func(str string) string { return string(decompose(str, true)) }
+
+	cases := 0
+	listed := make(map[rune]bool)
+	part := ""
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "@") {
+			part = text
+			continue
+		}
+
+		// The columns are the source and its NFC, NFD, NFKC and NFKD forms
+		var c [5]string
+		columns := strings.Split(text, ";")
+		if len(columns) < 5 {
+			t.Fatalf("line %d: expected 5 columns, got %d", line, len(columns))
+		}
+		for i := range c {
+			var sb strings.Builder
+			for _, codePoint := range strings.Fields(columns[i]) {
+				r, err := strconv.ParseUint(codePoint, 16, 32)
+				if err != nil {
+					t.Fatalf("line %d: invalid code point %q", line, codePoint)
+				}
+				sb.WriteRune(rune(r))
+			}
+			c[i] = sb.String()
+		}
+
+		if part == "@Part1" {
+			r, _ := utf8.DecodeRuneInString(c[0])
+			listed[r] = true
+		}
+
+		for i, str := range c {
+			// NFC and NFD leave the compatibility characters of the source columns alone
+			if i < 3 {
+				if got := NFC(str); got != c[1] {
+					t.Errorf("line %d: NFC(c%d) = %+q, want %+q", line, i+1, got, c[1])
+				}
+				if got := nfd(str); got != c[2] {
+					t.Errorf("line %d: NFD(c%d) = %+q, want %+q", line, i+1, got, c[2])
+				}
+			} else {
+				if got := NFC(str); got != c[3] {
+					t.Errorf("line %d: NFC(c%d) = %+q, want %+q", line, i+1, got, c[3])
+				}
+				if got := nfd(str); got != c[4] {
+					t.Errorf("line %d: NFD(c%d) = %+q, want %+q", line, i+1, got, c[4])
+				}
+			}
+			if got := NFKC(str); got != c[3] {
+				t.Errorf("line %d: NFKC(c%d) = %+q, want %+q", line, i+1, got, c[3])
+			}
+			if got := nfkd(str); got != c[4] {
+				t.Errorf("line %d: NFKD(c%d) = %+q, want %+q", line, i+1, got, c[4])
+			}
+		}
+
+		cases++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	if cases == 0 {
+		t.Fatalf("no test cases found")
+	}
+
+	// Every code point that is not listed in Part1 is left unchanged by every form
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if listed[r] || (r >= 0xD800 && r <= 0xDFFF) {
+			continue
+		}
+
+		str := string(r)
+		if NFC(str) != str || NFKC(str) != str || nfd(str) != str || nfkd(str) != str {
+			t.Errorf("%U is not listed in Part1 but changes under normalization", r)
+		}
+	}
+}
+
+// Tests NFC(str string) string and NFKC(str string) string
+func TestNormalize(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		str          string
+		expectedNFC  string
+		expectedNFKC string
+	}{
+		{
+			name:         "ascii",
+			str:          "hello",
+			expectedNFC:  "hello",
+			expectedNFKC: "hello",
+		},
+		{
+			name:         "combining acute accent",
+			str:          "Jose\u0301",
+			expectedNFC:  "Jos\u00E9",
+			expectedNFKC: "Jos\u00E9",
+		},
+		{
+			name:         "already composed",
+			str:          "Jos\u00E9",
+			expectedNFC:  "Jos\u00E9",
+			expectedNFKC: "Jos\u00E9",
+		},
+		{
+			name:         "marks in canonical order",
+			str:          "a\u0301\u0328",
+			expectedNFC:  "\u0105\u0301",
+			expectedNFKC: "\u0105\u0301",
+		},
+		{
+			name:         "blocked mark",
+			str:          "a\u0301\u0301",
+			expectedNFC:  "\u00E1\u0301",
+			expectedNFKC: "\u00E1\u0301",
+		},
+		{
+			name:         "singleton decomposition",
+			str:          "\u212B",
+			expectedNFC:  "\u00C5",
+			expectedNFKC: "\u00C5",
+		},
+		{
+			name:         "composition exclusion",
+			str:          "\u0958",
+			expectedNFC:  "\u0915\u093C",
+			expectedNFKC: "\u0915\u093C",
+		},
+		{
+			name:         "leading combining mark",
+			str:          "\u0301a",
+			expectedNFC:  "\u0301a",
+			expectedNFKC: "\u0301a",
+		},
+		{
+			name:         "hangul jamo",
+			str:          "\u1112\u1161\u11AB",
+			expectedNFC:  "\uD55C",
+			expectedNFKC: "\uD55C",
+		},
+		{
+			name:         "compatibility characters",
+			str:          "\uFF46\uFF55\uFF4C\uFF4C \uFB01 x\u00B2",
+			expectedNFC:  "\uFF46\uFF55\uFF4C\uFF4C \uFB01 x\u00B2",
+			expectedNFKC: "full fi x2",
+		},
+		{
+			name:         "compatibility decomposition composed again",
+			str:          "\u1E9B\u0323",
+			expectedNFC:  "\u1E9B\u0323",
+			expectedNFKC: "\u1E69",
+		},
+		{
+			name:         "no-break space",
+			str:          "a\u00A0b",
+			expectedNFC:  "a\u00A0b",
+			expectedNFKC: "a b",
+		},
+		{
+			name:         "invalid utf-8",
+			str:          "a\xffb",
+			expectedNFC:  "a\uFFFDb",
+			expectedNFKC: "a\uFFFDb",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NFC(tt.str); got != tt.expectedNFC {
+				t.Errorf("NFC(%+q) = %+q, want %+q", tt.str, got, tt.expectedNFC)
+			}
+			if got := NFKC(tt.str); got != tt.expectedNFKC {
+				t.Errorf("NFKC(%+q) = %+q, want %+q", tt.str, got, tt.expectedNFKC)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package strval
+
+import (
+	"fmt"
+	"math"
+)
+
+// Number is the constraint of the integer and floating point types accepted by the number options
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// The relative tolerance of MustBeMultipleOf for floating point numbers
+const multipleTolerance = 1e-9
+
+// This option will validate that the number is at least min
+func MustBeAtLeast[T Number](min T) Option[T] {
+	return func(value T, name string) error {
+		if value < min || isNaN(value) {
+			return newFieldError(name, fmt.Sprint(value), RuleMinValue, map[string]any{"min": min})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the number is at most max
+func MustBeAtMost[T Number](max T) Option[T] {
+	return func(value T, name string) error {
+		if value > max || isNaN(value) {
+			return newFieldError(name, fmt.Sprint(value), RuleMaxValue, map[string]any{"max": max})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the number is between min and max, both included
+func MustBeBetween[T Number](min, max T) Option[T] {
+	return func(value T, name string) error {
+		if value < min || value > max || isNaN(value) {
+			return newFieldError(name, fmt.Sprint(value), RuleValueBetween, map[string]any{"min": min, "max": max})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the number is greater than zero
+func MustBePositive[T Number]() Option[T] {
+	return func(value T, name string) error {
+		if !(value > 0) {
+			return newFieldError(name, fmt.Sprint(value), RulePositive, nil)
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the number is a multiple of n
+// Floating point numbers are compared with a relative tolerance of 1e-9, so that 0.3 is a multiple of 0.1.
+// MustBeMultipleOf panics if n is zero.
+func MustBeMultipleOf[T Number](n T) Option[T] {
+	if n == 0 {
+		panic("strval: MustBeMultipleOf: n must not be zero")
+	}
+
+	return func(value T, name string) error {
+		if !isMultipleOf(value, n) {
+			return newFieldError(name, fmt.Sprint(value), RuleMultipleOf, map[string]any{"multiple": n})
+		}
+
+		return nil
+	}
+}
+
+// isMultipleOf reports whether value is a multiple of n
+func isMultipleOf[T Number](value, n T) bool {
+	// Integer division truncates, floating point division does not
+	if T(1)/2 == 0 {
+		return value-value/n*n == 0
+	}
+
+	quotient := float64(value) / float64(n)
+	return math.Abs(quotient-math.Round(quotient)) <= multipleTolerance*math.Max(1, math.Abs(quotient))
+}
+
+// isNaN reports whether a number is a floating point NaN, which no range contains
+func isNaN[T Number](value T) bool {
+	return value != value
+}
@@ -0,0 +1,129 @@
+package strval
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests the number options with Validate()
+func TestNumberOptions(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		validate        func() ValidationResult
+		expectedValid   bool
+		expectedMessage string
+	}{
+		{
+			name:          "at least",
+			validate:      func() ValidationResult { return Validate(18, "age", MustBeAtLeast(18)) },
+			expectedValid: true,
+		},
+		{
+			name:            "below the minimum",
+			validate:        func() ValidationResult { return Validate(17, "age", MustBeAtLeast(18)) },
+			expectedValid:   false,
+			expectedMessage: "age must be at least 18",
+		},
+		{
+			name:            "above the maximum",
+			validate:        func() ValidationResult { return Validate(uint8(200), "percent", MustBeAtMost(uint8(100))) },
+			expectedValid:   false,
+			expectedMessage: "percent must be at most 100",
+		},
+		{
+			name:          "between",
+			validate:      func() ValidationResult { return Validate(0.5, "ratio", MustBeBetween(0.0, 1.0)) },
+			expectedValid: true,
+		},
+		{
+			name:            "not between",
+			validate:        func() ValidationResult { return Validate(1.5, "ratio", MustBeBetween(0.0, 1.0)) },
+			expectedValid:   false,
+			expectedMessage: "ratio must be between 0 and 1",
+		},
+		{
+			name:            "nan is not between",
+			validate:        func() ValidationResult { return Validate(math.NaN(), "ratio", MustBeBetween(0.0, 1.0)) },
+			expectedValid:   false,
+			expectedMessage: "ratio must be between 0 and 1",
+		},
+		{
+			name:          "positive",
+			validate:      func() ValidationResult { return Validate(int64(1), "quantity", MustBePositive[int64]()) },
+			expectedValid: true,
+		},
+		{
+			name:            "zero is not positive",
+			validate:        func() ValidationResult { return Validate(0, "quantity", MustBePositive[int]()) },
+			expectedValid:   false,
+			expectedMessage: "quantity must be positive",
+		},
+		{
+			name:          "integer multiple",
+			validate:      func() ValidationResult { return Validate(-15, "minutes", MustBeMultipleOf(5)) },
+			expectedValid: true,
+		},
+		{
+			name:            "integer not a multiple",
+			validate:        func() ValidationResult { return Validate(7, "minutes", MustBeMultipleOf(5)) },
+			expectedValid:   false,
+			expectedMessage: "minutes must be a multiple of 5",
+		},
+		{
+			name:          "float multiple",
+			validate:      func() ValidationResult { return Validate(0.3, "price", MustBeMultipleOf(0.1)) },
+			expectedValid: true,
+		},
+		{
+			name:            "float not a multiple",
+			validate:        func() ValidationResult { return Validate(0.25, "price", MustBeMultipleOf(0.1)) },
+			expectedValid:   false,
+			expectedMessage: "price must be a multiple of 0.1",
+		},
+		{
+			name: "several options",
+			validate: func() ValidationResult {
+				return Validate(12, "minutes", MustBeBetween(0, 10), MustBeMultipleOf(5))
+			},
+			expectedValid:   false,
+			expectedMessage: "minutes must be between 0 and 10",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.validate()
+
+			if result.Valid != tt.expectedValid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.expectedValid, result.Messages)
+			}
+			if !tt.expectedValid && result.Messages[0] != tt.expectedMessage {
+				t.Errorf("Messages[0] = %q, want %q", result.Messages[0], tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// Tests Validate() with string options and the string specialization
+func TestValidateStrings(t *testing.T) {
+	result := Validate("", "username", StringOptions(MustNotBeEmpty(), MustHaveMinLengthOf(3))...)
+	expected := ValidateStringWithName("", "username", MustNotBeEmpty(), MustHaveMinLengthOf(3))
+
+	if result.Valid || len(result.Messages) != len(expected.Messages) {
+		t.Fatalf("Validate() = %+v, want %+v", result, expected)
+	}
+	for i := range expected.Messages {
+		if result.Messages[i] != expected.Messages[i] {
+			t.Errorf("Messages[%d] = %q, want %q", i, result.Messages[i], expected.Messages[i])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustBeMultipleOf(0) did not panic")
+		}
+	}()
+	MustBeMultipleOf(0)
+}
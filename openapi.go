@@ -0,0 +1,170 @@
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/dmars8047/strval/internal/yamlite"
+)
+
+// OpenAPIComponents collects the schemas of structs for the components.schemas section of an OpenAPI 3.1 document.
+// Each struct is described like StructSchema describes it, with these differences:
+//   - Named struct types used by fields become components of their own, referenced with $ref
+//   - String schemas list every rule of the field, with its description, in the x-strval-rules extension,
+//     including the rules that JSON Schema keywords cannot express
+//
+// The zero value is not usable, create one with NewOpenAPIComponents.
+type OpenAPIComponents struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// The names OpenAPI allows for components
+var openAPIComponentName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// NewOpenAPIComponents creates an empty set of components
+func NewOpenAPIComponents() *OpenAPIComponents {
+	return &OpenAPIComponents{
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// Add adds the schemas of structs, named after their types, and of the named struct types their fields use
+// values: The structs, or pointers to structs, to describe; only their types are used
+// Returns an error if a value is not a named struct, a tag is malformed, or two types have the same name.
+// The components are left unchanged when an error is returned.
+func (c *OpenAPIComponents) Add(values ...any) error {
+	work := c.clone()
+	for _, v := range values {
+		if err := work.add("", v); err != nil {
+			return err
+		}
+	}
+
+	*c = *work
+	return nil
+}
+
+// AddNamed adds the schema of a struct under a name, and of the named struct types its fields use
+// Use it for types whose names are not valid component names, such as generic types, or that clash
+// with a type of another package. The type keeps that name when other structs reference it.
+// Returns an error like Add
+func (c *OpenAPIComponents) AddNamed(name string, v any) error {
+	work := c.clone()
+	if err := work.add(name, v); err != nil {
+		return err
+	}
+
+	*c = *work
+	return nil
+}
+
+// clone copies the components, so that a failed Add can leave them unchanged
+func (c *OpenAPIComponents) clone() *OpenAPIComponents {
+	clone := NewOpenAPIComponents()
+	for name, schema := range c.schemas {
+		clone.schemas[name] = schema
+	}
+	for rt, name := range c.names {
+		clone.names[rt] = name
+	}
+
+	return clone
+}
+
+// add adds the schema of a struct under a name, the name of its type if empty
+func (c *OpenAPIComponents) add(name string, v any) error {
+	rt := reflect.TypeOf(v)
+	if rt == nil || indirectType(rt).Kind() != reflect.Struct {
+		return fmt.Errorf("strval: OpenAPIComponents expects a struct, got %T", v)
+	}
+
+	b := &schemaBuilder{visiting: map[reflect.Type]bool{}, components: c}
+	_, err := c.ref(b, indirectType(rt), name)
+
+	return err
+}
+
+// ref describes a named struct type as a component, once, and returns a schema referencing it
+func (c *OpenAPIComponents) ref(b *schemaBuilder, rt reflect.Type, name string) (*Schema, error) {
+	if existing, ok := c.names[rt]; ok {
+		if name != "" && name != existing {
+			return nil, fmt.Errorf("strval: OpenAPI component %s was already added as %q", rt, existing)
+		}
+		return &Schema{Ref: "#/components/schemas/" + existing}, nil
+	}
+
+	if name == "" {
+		name = rt.Name()
+	}
+
+	if !openAPIComponentName.MatchString(name) {
+		return nil, fmt.Errorf("strval: %q is not a valid OpenAPI component name for %s, use AddNamed to name it", name, rt)
+	}
+
+	for other, otherName := range c.names {
+		if otherName == name {
+			return nil, fmt.Errorf("strval: OpenAPI component %q is used by both %s and %s, use AddNamed to rename one", name, other, rt)
+		}
+	}
+
+	// Name the type before describing it, so that recursive types reference themselves
+	c.names[rt] = name
+
+	schema, err := b.structSchema(rt)
+	if err != nil {
+		return nil, err
+	}
+	c.schemas[name] = schema
+
+	return &Schema{Ref: "#/components/schemas/" + name}, nil
+}
+
+// Schemas returns the schemas added so far, keyed by component name
+func (c *OpenAPIComponents) Schemas() map[string]*Schema {
+	schemas := make(map[string]*Schema, len(c.schemas))
+	for name, schema := range c.schemas {
+		schemas[name] = schema
+	}
+
+	return schemas
+}
+
+// The part of an OpenAPI document written by OpenAPIComponents
+type openAPIDocument struct {
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// JSON returns an OpenAPI document fragment holding the components, to be merged into a full document:
+//
+//	{"components": {"schemas": {...}}}
+func (c *OpenAPIComponents) JSON() ([]byte, error) {
+	var doc openAPIDocument
+	doc.Components.Schemas = c.schemas
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// YAML returns the document fragment of JSON in YAML
+func (c *OpenAPIComponents) YAML() ([]byte, error) {
+	data, err := c.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return yamlite.FromJSON(data)
+}
@@ -0,0 +1,174 @@
+package strval
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type openAPIAddress struct {
+	Line1 string `json:"line1" strval:"required,max=100"`
+}
+
+type openAPIUser struct {
+	Email    string           `json:"email" strval:"required,email,not_disposable"`
+	Code     string           `json:"code" strval:"test_sku"`
+	Address  openAPIAddress   `json:"address"`
+	Previous []openAPIAddress `json:"previous"`
+	Manager  *openAPIUser     `json:"manager"`
+	Meta     struct {
+		Source string `json:"source" strval:"oneof=web"`
+	} `json:"meta"`
+}
+
+type openAPIPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+// Tests OpenAPIComponents.Add(values ...any) error and OpenAPIComponents.YAML() ([]byte, error)
+func TestOpenAPIComponents(t *testing.T) {
+	RegisterRule("test_sku", noArgRule(MustBeAlphaNumeric))
+	DescribeRule("test_sku", "Must be a stock keeping unit")
+	defer func() {
+		ruleRegistry.Lock()
+		delete(ruleRegistry.factories, "test_sku")
+		delete(ruleRegistry.descriptions, "test_sku")
+		ruleRegistry.Unlock()
+	}()
+
+	components := NewOpenAPIComponents()
+	if err := components.Add(openAPIUser{}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	got, err := components.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+
+	expected := `components:
+  schemas:
+    openAPIAddress:
+      type: object
+      properties:
+        line1:
+          type: string
+          maxLength: 100
+          pattern: "[^\\t\\n\\f\\r ]"
+          x-strval-rules:
+            - rule: required
+              description: Must contain a character that is not whitespace
+            - rule: "max:100"
+              description: Must have a maximum length
+      required:
+        - line1
+    openAPIUser:
+      type: object
+      properties:
+        address:
+          $ref: "#/components/schemas/openAPIAddress"
+        code:
+          type: string
+          x-strval-rules:
+            - rule: test_sku
+              description: Must be a stock keeping unit
+        email:
+          type: string
+          pattern: "[^\\t\\n\\f\\r ]"
+          allOf:
+            - format: email
+              pattern: "^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$"
+          x-strval-rules:
+            - rule: required
+              description: Must contain a character that is not whitespace
+            - rule: email
+              description: Must be an email address
+            - rule: not_disposable
+              description: Must not be an email address of a disposable email provider
+        manager:
+          $ref: "#/components/schemas/openAPIUser"
+        meta:
+          type: object
+          properties:
+            source:
+              type: string
+              enum:
+                - web
+              x-strval-rules:
+                - rule: "oneof:web"
+                  description: Must be one of the listed values
+        previous:
+          type: array
+          items:
+            $ref: "#/components/schemas/openAPIAddress"
+      required:
+        - email
+`
+
+	if string(got) != expected {
+		t.Errorf("YAML() = %s\nwant %s", got, expected)
+	}
+
+	data, err := components.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]*Schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("JSON() is not valid JSON: %v", err)
+	}
+
+	if len(doc.Components.Schemas) != 2 || doc.Components.Schemas["openAPIUser"].Properties["code"].Rules[0].Rule != "test_sku" {
+		t.Errorf("JSON() = %s", data)
+	}
+}
+
+// Tests OpenAPIComponents.AddNamed(name string, v any) error and the errors of Add
+func TestOpenAPIComponentsNames(t *testing.T) {
+	components := NewOpenAPIComponents()
+
+	// Generic type names are not valid component names
+	if err := components.Add(openAPIPage[openAPIAddress]{}); err == nil || !strings.Contains(err.Error(), "AddNamed") {
+		t.Errorf("Add() error = %v, want an error suggesting AddNamed", err)
+	}
+
+	if err := components.AddNamed("AddressPage", &openAPIPage[openAPIAddress]{}); err != nil {
+		t.Fatalf("AddNamed() error = %v", err)
+	}
+
+	if items := components.Schemas()["AddressPage"].Properties["items"].Items; items.Ref != "#/components/schemas/openAPIAddress" {
+		t.Errorf("AddNamed() items = %+v, want a reference to openAPIAddress", items)
+	}
+
+	// Another type with the same name, and a failed Add leaving the components unchanged
+	type openAPIAddress struct {
+		Zip string `json:"zip"`
+	}
+	type other struct {
+		Name string
+	}
+	if err := components.Add(other{}, openAPIAddress{}); err == nil || !strings.Contains(err.Error(), "used by both") {
+		t.Errorf("Add() error = %v, want a name clash", err)
+	}
+
+	if _, ok := components.Schemas()["other"]; ok || len(components.Schemas()) != 2 {
+		t.Errorf("Add() changed the components after an error: %v", components.Schemas())
+	}
+
+	if err := components.AddNamed("LocalAddress", openAPIAddress{}); err != nil {
+		t.Errorf("AddNamed() error = %v", err)
+	}
+
+	if err := components.AddNamed("Renamed", openAPIAddress{}); err == nil {
+		t.Errorf("AddNamed() expected an error renaming a type")
+	}
+
+	if err := components.Add("not a struct"); err == nil {
+		t.Errorf("Add() expected an error for a string")
+	}
+}
@@ -0,0 +1,43 @@
+package strval
+
+import "fmt"
+
+// An Option validates a value of type T, given the value and its name.
+// StringValidationOption is the option for strings: convert it with Option[string](option) or StringOptions.
+type Option[T any] func(T, string) error
+
+// This represents the result of a validation operation, for strings and other values
+type ValidationResult = StringValidationResult
+
+// Validate validates a value against the provided options
+// value: The value to validate
+// name: The name of the value (used in error messages)
+// options: The options to validate the value against
+// Returns a ValidationResult. The Value of its errors is the value formatted with fmt.Sprint.
+// The evaluation stops after a failed string option marked with Bail.
+func Validate[T any](value T, name string, options ...Option[T]) ValidationResult {
+	result := ValidationResult{Valid: true}
+
+	for _, option := range options {
+		if err := option(value, name); err != nil {
+			fieldErr := asFieldError(err, name, fmt.Sprint(value))
+			result.add(fieldErr)
+
+			if fieldErr.stopsEvaluation() {
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// StringOptions converts string validation options to Option[string], to use them with Validate, EachItem or EachValue
+func StringOptions(options ...StringValidationOption) []Option[string] {
+	converted := make([]Option[string], len(options))
+	for i, option := range options {
+		converted[i] = Option[string](option)
+	}
+
+	return converted
+}
@@ -0,0 +1,1118 @@
+package strval
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed data/common_passwords.txt
+var commonPasswordsData string
+
+//go:embed data/english_words.txt
+var englishWordsData string
+
+// The ranked dictionaries used to detect dictionary words in passwords
+var (
+	commonPasswords = parseRankedList(commonPasswordsData)
+	englishWords    = parseRankedList(englishWordsData)
+)
+
+// The password strength scores, from PasswordTooGuessable (0) to PasswordVeryUnguessable (4)
+const (
+	PasswordTooGuessable = iota
+	PasswordVeryGuessable
+	PasswordSomewhatGuessable
+	PasswordSafelyUnguessable
+	PasswordVeryUnguessable
+)
+
+// Only the first maxPasswordEvaluationLength characters of a password are analysed, to bound the cost of evaluation
+const maxPasswordEvaluationLength = 100
+
+// The ASCII symbols accepted by PasswordPolicy.RequireSymbol
+const passwordSymbols = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// This represents a password policy
+// The zero value accepts every password; start from NISTPolicy or NISTPrivilegedPolicy for sensible defaults
+type PasswordPolicy struct {
+	// The minimum length in characters (code points)
+	MinLength int
+	// The maximum length in characters (code points), 0 for no maximum
+	MaxLength int
+	// The minimum strength score, from PasswordTooGuessable (0) to PasswordVeryUnguessable (4)
+	MinScore int
+	// Reject passwords found in the embedded list of common passwords
+	RejectCommon bool
+	// Composition rules, not recommended by NIST SP 800-63B but available for custom policies
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	// Words specific to the user or the site, such as the username, that make a password easy to guess
+	UserInputs []string
+}
+
+// NISTPolicy returns a policy following the memorized secret guidance of NIST SP 800-63B:
+// at least 8 characters, at most 64, no common passwords, no composition rules and a minimum score of 2
+func NISTPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    8,
+		MaxLength:    64,
+		MinScore:     PasswordSomewhatGuessable,
+		RejectCommon: true,
+	}
+}
+
+// NISTPrivilegedPolicy returns a stricter NIST SP 800-63B style policy for administrator accounts:
+// at least 15 characters and a minimum score of 3
+func NISTPrivilegedPolicy() PasswordPolicy {
+	policy := NISTPolicy()
+	policy.MinLength = 15
+	policy.MinScore = PasswordSafelyUnguessable
+	return policy
+}
+
+// WithUserInputs returns a copy of the policy that also treats the given words (username, email, site name) as guessable
+func (p PasswordPolicy) WithUserInputs(inputs ...string) PasswordPolicy {
+	p.UserInputs = append(append([]string(nil), p.UserInputs...), inputs...)
+	return p
+}
+
+// This option will validate that the string satisfies a password policy
+// Every violated rule of the policy is reported; the strength rule includes feedback on how to improve the password
+func MustSatisfyPasswordPolicy(policy PasswordPolicy) StringValidationOption {
+	var options []StringValidationOption
+
+	if policy.MinLength > 0 {
+		options = append(options, MustHaveMinLengthOfIn(policy.MinLength, UnitRunes))
+	}
+	if policy.MaxLength > 0 {
+		options = append(options, MustHaveMaxLengthOfIn(policy.MaxLength, UnitRunes))
+	}
+	if policy.RequireUppercase {
+		options = append(options, MustContainUppercaseLetter())
+	}
+	if policy.RequireLowercase {
+		options = append(options, MustContainLowercaseLetter())
+	}
+	if policy.RequireDigit {
+		options = append(options, MustContainNumbers())
+	}
+	if policy.RequireSymbol {
+		options = append(options, MustContainAtLeastOne([]rune(passwordSymbols)))
+	}
+	if policy.RejectCommon {
+		options = append(options, mustNotBeCommonPassword())
+	}
+	if policy.MinScore > 0 {
+		options = append(options, mustHavePasswordScore(policy.MinScore, policy.UserInputs))
+	}
+
+	return AllOf(options...)
+}
+
+// mustNotBeCommonPassword validates that a password is not in the embedded list of common passwords
+func mustNotBeCommonPassword() StringValidationOption {
+	return func(str, strName string) error {
+		if _, ok := commonPasswords[strings.ToLower(str)]; ok {
+			return newFieldError(strName, str, RulePasswordCommon, nil)
+		}
+
+		return nil
+	}
+}
+
+// mustHavePasswordScore validates that the strength score of a password is at least minScore
+func mustHavePasswordScore(minScore int, userInputs []string) StringValidationOption {
+	return func(str, strName string) error {
+		strength := EvaluatePassword(str, userInputs...)
+		if strength.Score >= minScore {
+			return nil
+		}
+
+		// The keys let translators render the feedback in the language of the message
+		warning, suggestions := passwordFeedback(strength.Score, strength.Matches)
+
+		return newFieldError(strName, str, RulePasswordStrength, map[string]any{
+			"score":           strength.Score,
+			"min_score":       minScore,
+			"feedback":        renderPasswordFeedback(warning, suggestions, englishTemplate),
+			"warning_key":     warning,
+			"suggestion_keys": suggestions,
+		})
+	}
+}
+
+// renderPasswordFeedback joins the warning and suggestions of a weak password, rendering the template of each key with template
+func renderPasswordFeedback(warning string, suggestions []string, template func(key string) string) string {
+	feedback := make([]string, 0, len(suggestions)+1)
+	if warning != "" {
+		feedback = append(feedback, renderMessage(template(PasswordWarningSentence), "", map[string]any{"warning": template(warning)}))
+	}
+	for _, suggestion := range suggestions {
+		feedback = append(feedback, template(suggestion))
+	}
+
+	return strings.Join(feedback, template(PasswordFeedbackSeparator))
+}
+
+// englishTemplate returns the template of a message key in the English catalog
+func englishTemplate(key string) string {
+	return englishCatalog[key]
+}
+
+// This represents the strength of a password, see EvaluatePassword
+type PasswordStrength struct {
+	// The strength score, from PasswordTooGuessable (0) to PasswordVeryUnguessable (4)
+	Score int
+	// The estimated number of guesses needed to find the password
+	Guesses float64
+	// The patterns the password was found to be made of, in order
+	Matches []PasswordMatch
+	// Explains what makes the password weak, empty for strong passwords
+	Warning string
+	// Suggestions for a stronger password, empty for strong passwords
+	Suggestions []string
+}
+
+// This represents a part of a password matching a guessable pattern
+type PasswordMatch struct {
+	// One of dictionary, spatial, repeat, sequence, date or bruteforce
+	Pattern string
+	// The matched part of the password
+	Token string
+	// The character (code point) offsets of the match, End is exclusive
+	Start, End int
+	// The estimated number of guesses needed to find the token
+	Guesses float64
+
+	// Details used to give feedback
+	dictionary string
+	rank       int
+	reversed   bool
+	l33t       bool
+	turns      int
+	baseToken  string
+	ascending  bool
+	isYear     bool
+}
+
+// EvaluatePassword estimates how hard a password is to guess.
+// Like zxcvbn, the password is broken into the most guessable combination of patterns: common passwords,
+// dictionary words (also reversed or with l33t substitutions), keyboard walks, repeats, sequences and dates.
+// userInputs are treated as a dictionary of words that are easy to guess for this user, such as their name.
+func EvaluatePassword(password string, userInputs ...string) PasswordStrength {
+	runes := []rune(password)
+	if len(runes) > maxPasswordEvaluationLength {
+		runes = runes[:maxPasswordEvaluationLength]
+	}
+
+	inputs := make(map[string]int, len(userInputs))
+	for i, input := range userInputs {
+		if input = strings.ToLower(input); input != "" {
+			if _, ok := inputs[input]; !ok {
+				inputs[input] = i + 1
+			}
+		}
+	}
+
+	guesses, matches := mostGuessableSequence(runes, findPasswordMatches(runes, inputs))
+
+	strength := PasswordStrength{
+		Score:   passwordScore(guesses),
+		Guesses: guesses,
+		Matches: matches,
+	}
+	warning, suggestions := passwordFeedback(strength.Score, matches)
+	if warning != "" {
+		strength.Warning = englishCatalog[warning]
+	}
+	for _, suggestion := range suggestions {
+		strength.Suggestions = append(strength.Suggestions, englishCatalog[suggestion])
+	}
+
+	return strength
+}
+
+// passwordScore converts a number of guesses into a score from 0 to 4, using the thresholds of zxcvbn
+func passwordScore(guesses float64) int {
+	const delta = 5
+
+	switch {
+	case guesses < 1e3+delta:
+		return PasswordTooGuessable
+	case guesses < 1e6+delta:
+		return PasswordVeryGuessable
+	case guesses < 1e8+delta:
+		return PasswordSomewhatGuessable
+	case guesses < 1e10+delta:
+		return PasswordSafelyUnguessable
+	default:
+		return PasswordVeryUnguessable
+	}
+}
+
+// findPasswordMatches runs every pattern matcher over a password
+func findPasswordMatches(runes []rune, userInputs map[string]int) []PasswordMatch {
+	var matches []PasswordMatch
+
+	dictionaries := []struct {
+		name  string
+		ranks map[string]int
+	}{
+		{"passwords", commonPasswords},
+		{"english", englishWords},
+		{"user_inputs", userInputs},
+	}
+
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		// Lowercasing changed the number of code points, fall back to a per-rune mapping
+		lower = make([]rune, len(runes))
+		for i, r := range runes {
+			lower[i] = unicode.ToLower(r)
+		}
+	}
+
+	for _, dictionary := range dictionaries {
+		matches = append(matches, dictionaryMatches(runes, lower, dictionary.name, dictionary.ranks)...)
+		matches = append(matches, reversedDictionaryMatches(runes, lower, dictionary.name, dictionary.ranks)...)
+		matches = append(matches, l33tDictionaryMatches(runes, lower, dictionary.name, dictionary.ranks)...)
+	}
+
+	matches = append(matches, spatialMatches(runes)...)
+	matches = append(matches, repeatMatches(runes, userInputs)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, dateMatches(runes)...)
+
+	return matches
+}
+
+// dictionaryMatches finds every substring of the password that is a word in a ranked dictionary
+func dictionaryMatches(runes, lower []rune, name string, ranks map[string]int) []PasswordMatch {
+	var matches []PasswordMatch
+
+	for i := range lower {
+		for j := i + 1; j <= len(lower); j++ {
+			rank, ok := ranks[string(lower[i:j])]
+			if !ok {
+				continue
+			}
+
+			token := string(runes[i:j])
+			matches = append(matches, PasswordMatch{
+				Pattern:    "dictionary",
+				Token:      token,
+				Start:      i,
+				End:        j,
+				Guesses:    minSubmatchGuesses(j-i, float64(rank)*uppercaseVariations(token)),
+				dictionary: name,
+				rank:       rank,
+			})
+		}
+	}
+
+	return matches
+}
+
+// reversedDictionaryMatches finds dictionary words written backwards
+func reversedDictionaryMatches(runes, lower []rune, name string, ranks map[string]int) []PasswordMatch {
+	n := len(lower)
+	reversed := make([]rune, n)
+	reversedLower := make([]rune, n)
+	for i := range lower {
+		reversed[n-1-i] = runes[i]
+		reversedLower[n-1-i] = lower[i]
+	}
+
+	var matches []PasswordMatch
+
+	for _, match := range dictionaryMatches(reversed, reversedLower, name, ranks) {
+		// Palindromes are already found as regular words
+		if match.End-match.Start < 2 || string(reversedLower[match.Start:match.End]) == string(lower[n-match.End:n-match.Start]) {
+			continue
+		}
+
+		match.Start, match.End = n-match.End, n-match.Start
+		match.Token = string(runes[match.Start:match.End])
+		match.Guesses *= 2
+		match.reversed = true
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// l33tTable maps the common l33t substitutions to the letters they stand for
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'}, '6': {'g'}, '9': {'g'}, '1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'0': {'o'}, '$': {'s'}, '5': {'s'}, '+': {'t'}, '7': {'t'}, '%': {'x'}, '2': {'z'},
+}
+
+// Bounds the number of substitution combinations tried for a single password
+const maxL33tVariants = 32
+
+// l33tDictionaryMatches finds dictionary words written with l33t substitutions, such as p@ssw0rd
+func l33tDictionaryMatches(runes, lower []rune, name string, ranks map[string]int) []PasswordMatch {
+	// Collect the distinct l33t characters present, each with its possible meanings
+	var subs []rune
+	seen := make(map[rune]bool)
+	for _, r := range lower {
+		if _, ok := l33tTable[r]; ok && !seen[r] {
+			seen[r] = true
+			subs = append(subs, r)
+		}
+	}
+
+	if len(subs) == 0 {
+		return nil
+	}
+
+	// Every combination of meanings, applied consistently to each l33t character
+	variants := []map[rune]rune{{}}
+	for _, sub := range subs {
+		var next []map[rune]rune
+		for _, variant := range variants {
+			for _, letter := range l33tTable[sub] {
+				if len(next) == maxL33tVariants {
+					break
+				}
+				extended := make(map[rune]rune, len(variant)+1)
+				for k, v := range variant {
+					extended[k] = v
+				}
+				extended[sub] = letter
+				next = append(next, extended)
+			}
+		}
+		variants = next
+	}
+
+	var matches []PasswordMatch
+	found := make(map[[2]int]bool)
+
+	for _, variant := range variants {
+		unsubbed := make([]rune, len(lower))
+		for i, r := range lower {
+			if letter, ok := variant[r]; ok {
+				unsubbed[i] = letter
+			} else {
+				unsubbed[i] = r
+			}
+		}
+
+		for _, match := range dictionaryMatches(runes, unsubbed, name, ranks) {
+			substitutions := 0
+			for i := match.Start; i < match.End; i++ {
+				if unsubbed[i] != lower[i] {
+					substitutions++
+				}
+			}
+
+			// Matches without substitutions are found by dictionaryMatches
+			if substitutions == 0 || match.End-match.Start < 2 || found[[2]int{match.Start, match.End}] {
+				continue
+			}
+			found[[2]int{match.Start, match.End}] = true
+
+			match.Guesses *= math.Pow(2, float64(substitutions))
+			match.l33t = true
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// A key on the QWERTY keyboard
+type keyPosition struct {
+	row     int
+	column  int
+	shifted bool
+}
+
+// The rows of the QWERTY keyboard, unshifted and shifted, and how far each row is offset to the right
+var (
+	keyboardRows        = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+	shiftedKeyboardRows = []string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?"}
+	keyboardRowOffsets  = []float64{0, 0.5, 0.75, 1.25}
+	keyboardKeys        = keyboardLayout()
+)
+
+// The number of keys and average number of neighbours per key, used to estimate the guesses of a keyboard walk
+const (
+	keyboardStartingPositions = 47
+	keyboardAverageDegree     = 4.6
+)
+
+// keyboardLayout maps every character on the keyboard to its key
+func keyboardLayout() map[rune]keyPosition {
+	keys := make(map[rune]keyPosition)
+	for row := range keyboardRows {
+		for column, r := range keyboardRows[row] {
+			keys[r] = keyPosition{row: row, column: column}
+		}
+		for column, r := range shiftedKeyboardRows[row] {
+			keys[r] = keyPosition{row: row, column: column, shifted: true}
+		}
+	}
+	return keys
+}
+
+// keyDirection returns the direction from one key to an adjacent key, or false if the keys are not adjacent
+func keyDirection(from, to keyPosition) (int, bool) {
+	dy := to.row - from.row
+	dx := (float64(to.column) + keyboardRowOffsets[to.row]) - (float64(from.column) + keyboardRowOffsets[from.row])
+
+	switch {
+	case dy == 0 && (dx == 1 || dx == -1):
+		return int(dx), true
+	case (dy == 1 || dy == -1) && math.Abs(dx) <= 0.75:
+		// Directions 2 to 5 for the diagonal neighbours in the rows above and below
+		direction := 2
+		if dy == 1 {
+			direction += 2
+		}
+		if dx > 0 {
+			direction++
+		}
+		return direction, true
+	}
+
+	return 0, false
+}
+
+// spatialMatches finds keyboard walks of at least three keys, such as qwerty or zxcvfr
+func spatialMatches(runes []rune) []PasswordMatch {
+	var matches []PasswordMatch
+
+	for i := 0; i < len(runes)-2; {
+		j := i + 1
+		turns := 0
+		shifted := 0
+		lastDirection := -100
+
+		start, ok := keyboardKeys[runes[i]]
+		if ok && start.shifted {
+			shifted++
+		}
+
+		for ok && j < len(runes) {
+			next, isKey := keyboardKeys[runes[j]]
+			if !isKey {
+				break
+			}
+
+			direction, adjacent := keyDirection(keyboardKeys[runes[j-1]], next)
+			if !adjacent {
+				break
+			}
+
+			if direction != lastDirection {
+				turns++
+				lastDirection = direction
+			}
+			if next.shifted {
+				shifted++
+			}
+			j++
+		}
+
+		if ok && j-i >= 3 {
+			matches = append(matches, PasswordMatch{
+				Pattern: "spatial",
+				Token:   string(runes[i:j]),
+				Start:   i,
+				End:     j,
+				Guesses: minSubmatchGuesses(j-i, spatialGuesses(j-i, turns, shifted)),
+				turns:   turns,
+			})
+			i = j - 1
+			continue
+		}
+
+		i++
+	}
+
+	return matches
+}
+
+// spatialGuesses estimates the guesses of a keyboard walk of a given length with a number of turns and shifted keys
+func spatialGuesses(length, turns, shifted int) float64 {
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= turns && j <= i-1; j++ {
+			guesses += binomial(i-1, j-1) * keyboardStartingPositions * math.Pow(keyboardAverageDegree, float64(j))
+		}
+	}
+
+	if shifted > 0 {
+		unshifted := length - shifted
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			variations := 0.0
+			for i := 1; i <= shifted && i <= unshifted; i++ {
+				variations += binomial(shifted+unshifted, i)
+			}
+			guesses *= variations
+		}
+	}
+
+	return guesses
+}
+
+// repeatMatches finds repeated characters and repeated groups, such as aaa or abcabc
+func repeatMatches(runes []rune, userInputs map[string]int) []PasswordMatch {
+	var matches []PasswordMatch
+
+	for i := 0; i < len(runes); {
+		bestEnd, bestLength := i, 0
+
+		// Find the base length covering the most characters when repeated from i
+		for length := 1; i+2*length <= len(runes); length++ {
+			end := i + length
+			for end+length <= len(runes) && string(runes[end:end+length]) == string(runes[i:i+length]) {
+				end += length
+			}
+			if end >= i+2*length && end > bestEnd {
+				bestEnd, bestLength = end, length
+			}
+		}
+
+		count := 0
+		if bestLength > 0 {
+			count = (bestEnd - i) / bestLength
+		}
+
+		// Single characters must repeat at least three times
+		if bestLength == 0 || (bestLength == 1 && count < 3) {
+			i++
+			continue
+		}
+
+		base := runes[i : i+bestLength]
+		baseGuesses, _ := mostGuessableSequence(base, findPasswordMatches(base, userInputs))
+
+		matches = append(matches, PasswordMatch{
+			Pattern:   "repeat",
+			Token:     string(runes[i:bestEnd]),
+			Start:     i,
+			End:       bestEnd,
+			Guesses:   minSubmatchGuesses(bestEnd-i, baseGuesses*float64(count)),
+			baseToken: string(base),
+		})
+
+		i = bestEnd
+	}
+
+	return matches
+}
+
+// sequenceMatches finds sequences with a constant step, such as abcd, 9753 or ACEG
+func sequenceMatches(runes []rune) []PasswordMatch {
+	var matches []PasswordMatch
+
+	class := func(r rune) int {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 1
+		case r >= 'A' && r <= 'Z':
+			return 2
+		case r >= '0' && r <= '9':
+			return 3
+		}
+		return 0
+	}
+
+	for i := 0; i < len(runes)-2; {
+		delta := runes[i+1] - runes[i]
+		if delta == 0 || delta > 5 || delta < -5 || class(runes[i]) == 0 || class(runes[i]) != class(runes[i+1]) {
+			i++
+			continue
+		}
+
+		j := i + 2
+		for j < len(runes) && runes[j]-runes[j-1] == delta && class(runes[j]) == class(runes[i]) {
+			j++
+		}
+
+		if j-i < 3 {
+			i++
+			continue
+		}
+
+		base := 26.0
+		switch {
+		case strings.ContainsRune("aAzZ019", runes[i]):
+			// Obvious starting points
+			base = 4
+		case class(runes[i]) == 3:
+			base = 10
+		}
+		if delta < 0 {
+			base *= 2
+		}
+
+		matches = append(matches, PasswordMatch{
+			Pattern:   "sequence",
+			Token:     string(runes[i:j]),
+			Start:     i,
+			End:       j,
+			Guesses:   minSubmatchGuesses(j-i, base*float64(j-i)),
+			ascending: delta > 0,
+		})
+
+		i = j - 1
+	}
+
+	return matches
+}
+
+// The minimum distance from the current year of a date guess, and how far into the future dates are recognized
+const (
+	minYearSpace   = 20
+	maxFutureYears = 25
+)
+
+// passwordReferenceYear returns the year date guesses are measured from, the current year
+// A variable so that tests can pin it.
+var passwordReferenceYear = func() int {
+	return time.Now().Year()
+}
+
+// dateMatches finds years (1987) and dates with or without separators (13.05.1987, 130587)
+func dateMatches(runes []rune) []PasswordMatch {
+	var matches []PasswordMatch
+
+	for i := range runes {
+		for j := i + 4; j <= len(runes) && j-i <= 10; j++ {
+			token := string(runes[i:j])
+
+			if j-i == 4 && isAllDigits(token) {
+				if year := atoi(token); year >= 1900 && year <= passwordReferenceYear()+maxFutureYears {
+					matches = append(matches, PasswordMatch{
+						Pattern: "date",
+						Token:   token,
+						Start:   i,
+						End:     j,
+						Guesses: minSubmatchGuesses(4, yearSpace(year)),
+						isYear:  true,
+					})
+				}
+			}
+
+			if year, separated, ok := parseDate(token); ok {
+				guesses := yearSpace(year) * 365
+				if separated {
+					guesses *= 4
+				}
+
+				matches = append(matches, PasswordMatch{
+					Pattern: "date",
+					Token:   token,
+					Start:   i,
+					End:     j,
+					Guesses: minSubmatchGuesses(j-i, guesses),
+				})
+			}
+		}
+	}
+
+	return matches
+}
+
+// parseDate checks whether a token is a day, month and year in any order, returning the year
+func parseDate(token string) (int, bool, bool) {
+	var parts []string
+	separated := false
+
+	if isAllDigits(token) {
+		if len(token) > 8 {
+			return 0, false, false
+		}
+		// Try every way of splitting the digits into three parts with a two or four digit year
+		for a := 1; a <= 4 && a < len(token); a++ {
+			for b := a + 1; b <= a+4 && b < len(token); b++ {
+				if year, ok := dateFromParts([]string{token[:a], token[a:b], token[b:]}); ok {
+					return year, false, true
+				}
+			}
+		}
+		return 0, false, false
+	}
+
+	for _, separator := range []string{"/", "\\", "-", ".", "_", " "} {
+		if strings.Count(token, separator) == 2 {
+			parts = strings.Split(token, separator)
+			separated = true
+			break
+		}
+	}
+
+	if !separated {
+		return 0, false, false
+	}
+
+	for _, part := range parts {
+		if part == "" || len(part) > 4 || !isAllDigits(part) {
+			return 0, false, false
+		}
+	}
+
+	year, ok := dateFromParts(parts)
+	return year, true, ok
+}
+
+// dateFromParts checks whether three numbers are a day, month and year with the year first or last
+func dateFromParts(parts []string) (int, bool) {
+	for _, order := range [][3]int{{0, 1, 2}, {1, 0, 2}, {2, 1, 0}, {2, 0, 1}} {
+		day, month, yearPart := parts[order[0]], parts[order[1]], parts[order[2]]
+		if order[2] == 1 || len(day) > 2 || len(month) > 2 || (len(yearPart) != 2 && len(yearPart) != 4) {
+			continue
+		}
+
+		d, m, y := atoi(day), atoi(month), atoi(yearPart)
+		if d < 1 || d > 31 || m < 1 || m > 12 {
+			continue
+		}
+
+		if len(yearPart) == 2 {
+			// Two digit years are read the way people write them
+			if y > 50 {
+				y += 1900
+			} else {
+				y += 2000
+			}
+		}
+
+		if y >= 1000 && y <= passwordReferenceYear()+maxFutureYears {
+			return y, true
+		}
+	}
+
+	return 0, false
+}
+
+// yearSpace returns the number of years a guesser would try before reaching year
+func yearSpace(year int) float64 {
+	space := year - passwordReferenceYear()
+	if space < 0 {
+		space = -space
+	}
+	if space < minYearSpace {
+		space = minYearSpace
+	}
+	return float64(space)
+}
+
+// Guess estimates for characters not matching any pattern, and the growth penalty for long pattern sequences
+const (
+	bruteforceCardinality        = 10
+	minSubmatchGuessesSingleChar = 10
+	minSubmatchGuessesMultiChar  = 50
+	minGuessesBeforeGrowing      = 10000
+)
+
+// minSubmatchGuesses applies the minimum guesses of a pattern match of a given length
+func minSubmatchGuesses(length int, guesses float64) float64 {
+	minimum := float64(minSubmatchGuessesMultiChar)
+	if length == 1 {
+		minimum = minSubmatchGuessesSingleChar
+	}
+	return math.Max(guesses, minimum)
+}
+
+// bruteforceMatch creates a match for characters that are not covered by any pattern
+func bruteforceMatch(runes []rune, start, end int) PasswordMatch {
+	guesses := math.Pow(bruteforceCardinality, float64(end-start))
+	if math.IsInf(guesses, 0) {
+		guesses = math.MaxFloat64
+	}
+
+	return PasswordMatch{
+		Pattern: "bruteforce",
+		Token:   string(runes[start:end]),
+		Start:   start,
+		End:     end,
+		Guesses: minSubmatchGuesses(end-start, guesses+1),
+	}
+}
+
+// A step in the search for the most guessable sequence of matches
+type sequenceStep struct {
+	match   PasswordMatch
+	product float64
+	guesses float64
+}
+
+// mostGuessableSequence finds the sequence of non-overlapping matches covering the password that is the easiest to guess.
+// This is the search of zxcvbn: the guesses of a sequence of l matches is l! times the product of their guesses,
+// plus a penalty for long sequences. Characters not covered by a match are guessed by brute force.
+func mostGuessableSequence(runes []rune, matches []PasswordMatch) (float64, []PasswordMatch) {
+	n := len(runes)
+	if n == 0 {
+		return 1, nil
+	}
+
+	// optimal[k][l] is the best sequence of l matches covering the first k+1 characters
+	optimal := make([]map[int]sequenceStep, n)
+	for k := range optimal {
+		optimal[k] = make(map[int]sequenceStep)
+	}
+
+	update := func(match PasswordMatch, length int, previousProduct float64) {
+		k := match.End - 1
+		product := previousProduct * match.Guesses
+		guesses := factorial(length)*product + math.Pow(minGuessesBeforeGrowing, float64(length-1))
+
+		// Skip the sequence if a shorter or equally long one is at least as good
+		for otherLength, other := range optimal[k] {
+			if otherLength <= length && other.guesses <= guesses {
+				return
+			}
+		}
+
+		optimal[k][length] = sequenceStep{match: match, product: product, guesses: guesses}
+	}
+
+	byEnd := make([][]PasswordMatch, n)
+	for _, match := range matches {
+		byEnd[match.End-1] = append(byEnd[match.End-1], match)
+	}
+
+	for k := 0; k < n; k++ {
+		for _, match := range byEnd[k] {
+			if match.Start == 0 {
+				update(match, 1, 1)
+				continue
+			}
+			for length, step := range optimal[match.Start-1] {
+				update(match, length+1, step.product)
+			}
+		}
+
+		// Brute force the characters up to k, never following another brute force match
+		update(bruteforceMatch(runes, 0, k+1), 1, 1)
+		for i := 1; i <= k; i++ {
+			match := bruteforceMatch(runes, i, k+1)
+			for length, step := range optimal[i-1] {
+				if step.match.Pattern != "bruteforce" {
+					update(match, length+1, step.product)
+				}
+			}
+		}
+	}
+
+	// Unwind the best sequence covering the whole password
+	bestLength, best := 0, math.Inf(1)
+	for length, step := range optimal[n-1] {
+		if step.guesses < best || (step.guesses == best && length < bestLength) {
+			bestLength, best = length, step.guesses
+		}
+	}
+
+	sequence := make([]PasswordMatch, bestLength)
+	for k, length := n-1, bestLength; k >= 0 && length > 0; length-- {
+		step := optimal[k][length]
+		sequence[length-1] = step.match
+		k = step.match.Start - 1
+	}
+
+	return best, sequence
+}
+
+// passwordFeedback explains what makes a password weak and how to improve it, returning the message keys of the warning and suggestions
+func passwordFeedback(score int, sequence []PasswordMatch) (string, []string) {
+	if len(sequence) == 0 {
+		return "", []string{PasswordSuggestionFewWords, PasswordSuggestionNoComposition}
+	}
+
+	if score > PasswordSomewhatGuessable {
+		return "", nil
+	}
+
+	longest := sequence[0]
+	for _, match := range sequence[1:] {
+		if utf8.RuneCountInString(match.Token) > utf8.RuneCountInString(longest.Token) {
+			longest = match
+		}
+	}
+
+	suggestions := []string{PasswordSuggestionAddWords}
+	warning := ""
+
+	switch longest.Pattern {
+	case "dictionary":
+		warning = dictionaryWarning(longest, len(sequence) == 1)
+
+		switch {
+		case longest.Token == strings.ToUpper(longest.Token) && longest.Token != strings.ToLower(longest.Token):
+			suggestions = append(suggestions, PasswordSuggestionAllUppercase)
+		case startsWithUpper(longest.Token):
+			suggestions = append(suggestions, PasswordSuggestionCapitalization)
+		}
+		if longest.reversed && longest.End-longest.Start >= 4 {
+			suggestions = append(suggestions, PasswordSuggestionReversed)
+		}
+		if longest.l33t {
+			suggestions = append(suggestions, PasswordSuggestionSubstitutions)
+		}
+	case "spatial":
+		warning = PasswordWarningShortKeyboardPattern
+		if longest.turns == 1 {
+			warning = PasswordWarningStraightRow
+		}
+		suggestions = append(suggestions, PasswordSuggestionLongerKeyboardWalk)
+	case "repeat":
+		warning = PasswordWarningRepeatedPattern
+		if utf8.RuneCountInString(longest.baseToken) == 1 {
+			warning = PasswordWarningRepeatedCharacter
+		}
+		suggestions = append(suggestions, PasswordSuggestionAvoidRepeats)
+	case "sequence":
+		warning = PasswordWarningSequence
+		suggestions = append(suggestions, PasswordSuggestionAvoidSequences)
+	case "date":
+		warning = PasswordWarningDate
+		if longest.isYear {
+			warning = PasswordWarningRecentYear
+		}
+		suggestions = append(suggestions, PasswordSuggestionAvoidDates)
+	}
+
+	return warning, suggestions
+}
+
+// dictionaryWarning returns the key of the warning explaining why a dictionary match makes a password weak
+func dictionaryWarning(match PasswordMatch, soleMatch bool) string {
+	switch match.dictionary {
+	case "passwords":
+		switch {
+		case soleMatch && !match.l33t && !match.reversed && match.rank <= 10:
+			return PasswordWarningTop10
+		case soleMatch && !match.l33t && !match.reversed && match.rank <= 100:
+			return PasswordWarningTop100
+		case soleMatch && !match.l33t && !match.reversed:
+			return PasswordWarningVeryCommon
+		case match.Guesses <= 1e4:
+			return PasswordWarningSimilarToCommon
+		}
+	case "english":
+		if soleMatch {
+			return PasswordWarningSingleWord
+		}
+	case "user_inputs":
+		return PasswordWarningUserInputs
+	}
+
+	return ""
+}
+
+// uppercaseVariations estimates how many capitalizations of a word a guesser tries before reaching token
+func uppercaseVariations(token string) float64 {
+	upper, lower := 0, 0
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+
+	if upper == 0 {
+		return 1
+	}
+
+	// First letter, last letter or all letters uppercase are the usual variations
+	first, _ := utf8.DecodeRuneInString(token)
+	last, _ := utf8.DecodeLastRuneInString(token)
+	if lower == 0 || (upper == 1 && (unicode.IsUpper(first) || unicode.IsUpper(last))) {
+		return 2
+	}
+
+	variations := 0.0
+	for i := 1; i <= upper && i <= lower; i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return variations
+}
+
+// startsWithUpper checks if a string starts with an uppercase letter
+func startsWithUpper(str string) bool {
+	r, _ := utf8.DecodeRuneInString(str)
+	return unicode.IsUpper(r)
+}
+
+// parseRankedList parses an embedded word list, ranking each word by its line among the non-comment lines
+func parseRankedList(data string) map[string]int {
+	ranks := make(map[string]int)
+	rank := 0
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rank++
+		// Case variants keep the better rank of the first entry
+		word := strings.ToLower(line)
+		if _, ok := ranks[word]; !ok {
+			ranks[word] = rank
+		}
+	}
+
+	return ranks
+}
+
+// binomial returns n choose k
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+// factorial returns n!
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+	return result
+}
+
+// isAllDigits checks if a non-empty string only contains ASCII digits
+func isAllDigits(str string) bool {
+	if str == "" {
+		return false
+	}
+
+	for i := 0; i < len(str); i++ {
+		if !isASCIIDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// atoi converts a string of ASCII digits to an int
+func atoi(str string) int {
+	n := 0
+	for i := 0; i < len(str); i++ {
+		n = n*10 + int(str[i]-'0')
+	}
+	return n
+}
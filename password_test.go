@@ -0,0 +1,343 @@
+package strval
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests EvaluatePassword(password string, userInputs ...string) PasswordStrength
+func TestEvaluatePassword(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		password        string
+		userInputs      []string
+		maxScore        int
+		minScore        int
+		expectedPattern string
+		expectedWarning string
+	}{
+		{
+			name:            "top common password",
+			password:        "password",
+			maxScore:        0,
+			expectedPattern: "dictionary",
+			expectedWarning: "This is a top-10 common password",
+		},
+		{
+			name:            "l33t common password",
+			password:        "p@ssw0rd",
+			maxScore:        0,
+			expectedPattern: "dictionary",
+			expectedWarning: "This is similar to a commonly used password",
+		},
+		{
+			name:            "reversed common password",
+			password:        "drowssap",
+			maxScore:        0,
+			expectedPattern: "dictionary",
+		},
+		{
+			name:            "capitalized with digit and symbol",
+			password:        "Password1!",
+			maxScore:        1,
+			expectedPattern: "dictionary",
+		},
+		{
+			name:            "keyboard walk",
+			password:        "zxcvfr",
+			maxScore:        1,
+			expectedPattern: "spatial",
+			expectedWarning: "Short keyboard patterns are easy to guess",
+		},
+		{
+			name:            "repeated character",
+			password:        "aaaaaaaa",
+			maxScore:        0,
+			expectedPattern: "repeat",
+			expectedWarning: `Repeats like "aaa" are easy to guess`,
+		},
+		{
+			name:            "repeated group",
+			password:        "xyzxyzxyz",
+			maxScore:        0,
+			expectedPattern: "repeat",
+		},
+		{
+			name:            "sequence",
+			password:        "lmnopq",
+			maxScore:        0,
+			expectedPattern: "sequence",
+			expectedWarning: "Sequences like abc or 6543 are easy to guess",
+		},
+		{
+			name:            "date",
+			password:        "13.05.1987",
+			maxScore:        1,
+			expectedPattern: "date",
+			expectedWarning: "Dates are often easy to guess",
+		},
+		{
+			name:            "user input with year",
+			password:        "zoltan1987",
+			userInputs:      []string{"Zoltan"},
+			maxScore:        1,
+			expectedPattern: "dictionary",
+			expectedWarning: "Passwords based on your personal information are easy to guess",
+		},
+		{
+			name:     "passphrase",
+			password: "correcthorsebatterystaple",
+			minScore: 3,
+			maxScore: 4,
+		},
+		{
+			name:     "random characters",
+			password: "kX9#vLq2$mWz!pR7",
+			minScore: 4,
+			maxScore: 4,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strength := EvaluatePassword(tt.password, tt.userInputs...)
+
+			if strength.Score < tt.minScore || strength.Score > tt.maxScore {
+				t.Errorf("EvaluatePassword(%q) score = %d, want between %d and %d", tt.password, strength.Score, tt.minScore, tt.maxScore)
+			}
+
+			if tt.expectedPattern != "" {
+				found := false
+				for _, match := range strength.Matches {
+					found = found || match.Pattern == tt.expectedPattern
+				}
+				if !found {
+					t.Errorf("EvaluatePassword(%q) matches = %+v, expected a %s match", tt.password, strength.Matches, tt.expectedPattern)
+				}
+			}
+
+			if tt.expectedWarning != "" && strength.Warning != tt.expectedWarning {
+				t.Errorf("EvaluatePassword(%q) warning = %q, want %q", tt.password, strength.Warning, tt.expectedWarning)
+			}
+
+			// Weak passwords always come with suggestions, strong ones never do
+			if (strength.Score <= PasswordSomewhatGuessable) != (len(strength.Suggestions) > 0) {
+				t.Errorf("EvaluatePassword(%q) score = %d with suggestions %q", tt.password, strength.Score, strength.Suggestions)
+			}
+		})
+	}
+}
+
+// Tests that the matches returned by EvaluatePassword cover the password without gaps or overlaps
+func TestEvaluatePasswordMatchesCoverPassword(t *testing.T) {
+	for _, password := range []string{"Password1!", "jane1987qwerty", "ünïcödé-p@ss", "aaaXYZ123abcabc"} {
+		var rebuilt strings.Builder
+		end := 0
+
+		for _, match := range EvaluatePassword(password).Matches {
+			if match.Start != end {
+				t.Errorf("EvaluatePassword(%q) match %q starts at %d, want %d", password, match.Token, match.Start, end)
+			}
+			rebuilt.WriteString(match.Token)
+			end = match.End
+		}
+
+		if rebuilt.String() != password {
+			t.Errorf("EvaluatePassword(%q) matches rebuild %q", password, rebuilt.String())
+		}
+	}
+}
+
+// Tests StringValidationOption MustSatisfyPasswordPolicy()
+func TestMustSatisfyPasswordPolicy(t *testing.T) {
+	custom := PasswordPolicy{MinLength: 6, RequireUppercase: true, RequireDigit: true, RequireSymbol: true}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		policy        PasswordPolicy
+		str           string
+		errExpected   bool
+		expectedRules []string
+	}{
+		{
+			name:        "strong passphrase",
+			policy:      NISTPolicy(),
+			str:         "correct horse battery staple",
+			errExpected: false,
+		},
+		{
+			name:          "too short",
+			policy:        NISTPolicy(),
+			str:           "kX9#vL",
+			errExpected:   true,
+			expectedRules: []string{RuleMinLength, RulePasswordStrength},
+		},
+		{
+			name:          "common password",
+			policy:        NISTPolicy(),
+			str:           "Password",
+			errExpected:   true,
+			expectedRules: []string{RulePasswordCommon, RulePasswordStrength},
+		},
+		{
+			name:          "composition rules do not make a password strong",
+			policy:        NISTPolicy(),
+			str:           "Password1!",
+			errExpected:   true,
+			expectedRules: []string{RulePasswordStrength},
+		},
+		{
+			name:          "too long",
+			policy:        NISTPolicy(),
+			str:           strings.Repeat("kX9#vLq2$mWz!pR7", 5),
+			errExpected:   true,
+			expectedRules: []string{RuleMaxLength},
+		},
+		{
+			name:          "privileged policy",
+			policy:        NISTPrivilegedPolicy(),
+			str:           "kX9#vLq2$mWz",
+			errExpected:   true,
+			expectedRules: []string{RuleMinLength},
+		},
+		{
+			name:          "user inputs",
+			policy:        NISTPolicy().WithUserInputs("marguerite"),
+			str:           "marguerite2024",
+			errExpected:   true,
+			expectedRules: []string{RulePasswordStrength},
+		},
+		{
+			name:        "custom composition rules",
+			policy:      custom,
+			str:         "Abc12!",
+			errExpected: false,
+		},
+		{
+			name:          "custom composition rules violated",
+			policy:        custom,
+			str:           "abcdef",
+			errExpected:   true,
+			expectedRules: []string{RuleContainsUppercase, RuleContainsNumbers, RuleContainsAtLeastOne},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustSatisfyPasswordPolicy(tt.policy)(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Fatalf("MustSatisfyPasswordPolicy() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			if !errFound {
+				return
+			}
+
+			// Make sure the strName is in the error message
+			if !strings.Contains(err.Error(), "str") {
+				t.Errorf("MustSatisfyPasswordPolicy() strName error = %v, expected to contain strName str", err)
+			}
+
+			fieldErr := asFieldError(err, "str", tt.str)
+			causes := fieldErr.Causes
+			if len(causes) == 0 {
+				causes = []*FieldError{fieldErr}
+			}
+
+			var rules []string
+			for _, cause := range causes {
+				rules = append(rules, cause.Rule)
+			}
+
+			if strings.Join(rules, ",") != strings.Join(tt.expectedRules, ",") {
+				t.Errorf("MustSatisfyPasswordPolicy() rules = %v, want %v", rules, tt.expectedRules)
+			}
+		})
+	}
+}
+
+// Tests that a password strength error explains how to improve the password
+func TestMustSatisfyPasswordPolicyFeedback(t *testing.T) {
+	err := MustSatisfyPasswordPolicy(PasswordPolicy{MinScore: PasswordSafelyUnguessable})("qwerty123", "password")
+
+	fieldErr := asFieldError(err, "password", "qwerty123")
+	if fieldErr.Rule != RulePasswordStrength {
+		t.Fatalf("MustSatisfyPasswordPolicy() rule = %s, want %s", fieldErr.Rule, RulePasswordStrength)
+	}
+
+	if fieldErr.Params["min_score"] != PasswordSafelyUnguessable {
+		t.Errorf("MustSatisfyPasswordPolicy() params = %v, want min_score %d", fieldErr.Params, PasswordSafelyUnguessable)
+	}
+
+	if !strings.Contains(fieldErr.Message, "Add another word or two") {
+		t.Errorf("MustSatisfyPasswordPolicy() message = %q, expected suggestions", fieldErr.Message)
+	}
+}
+
+// Tests that case variants in a ranked list keep the rank of the first entry
+func TestParseRankedList(t *testing.T) {
+	ranks := parseRankedList("Password\nqwerty\npassword\nPASSWORD\n")
+
+	if ranks["password"] != 1 || ranks["qwerty"] != 2 || len(ranks) != 2 {
+		t.Errorf("parseRankedList() = %v, want password 1 and qwerty 2", ranks)
+	}
+}
+
+// Tests that date guesses are measured from the current year
+func TestPasswordReferenceYear(t *testing.T) {
+	defer func(year func() int) { passwordReferenceYear = year }(passwordReferenceYear)
+	passwordReferenceYear = func() int { return 2060 }
+
+	if space := yearSpace(2070); space != minYearSpace {
+		t.Errorf("yearSpace(2070) = %v, want %d", space, minYearSpace)
+	}
+	if space := yearSpace(2000); space != 60 {
+		t.Errorf("yearSpace(2000) = %v, want 60", space)
+	}
+
+	matches := dateMatches([]rune("2080"))
+	if len(matches) != 1 || matches[0].Pattern != "date" {
+		t.Errorf("dateMatches(2080) = %+v, want a date", matches)
+	}
+}
+
+// Tests that localized password strength errors include the feedback in the same language
+func TestPasswordStrengthFeedbackLocalized(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		locale           string
+		expectedFeedback string
+	}{
+		{locale: "en", expectedFeedback: "This is a top-100 common password. Add another word or two. Uncommon words are better."},
+		{locale: "de-AT", expectedFeedback: "Dies ist eines der 100 häufigsten Passwörter. Fügen Sie ein oder zwei weitere Wörter hinzu."},
+		{locale: "es", expectedFeedback: "Esta es una de las 100 contraseñas más comunes. Añada una o dos palabras más."},
+		{locale: "ja", expectedFeedback: "よく使われるパスワードの上位100位に入っています。単語を1つか2つ追加してください。"},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			result := ValidateStringWithLocale("qwerty123", "password", tt.locale, MustSatisfyPasswordPolicy(PasswordPolicy{MinScore: PasswordSafelyUnguessable}))
+
+			if result.Valid || !strings.Contains(result.Messages[0], tt.expectedFeedback) {
+				t.Fatalf("ValidateStringWithLocale() messages = %q, want the feedback %q", result.Messages, tt.expectedFeedback)
+			}
+			if tt.locale != "en" && strings.Contains(result.Messages[0], "Add another word") {
+				t.Errorf("ValidateStringWithLocale() message = %q, want no English feedback", result.Messages[0])
+			}
+		})
+	}
+
+	// The parameters of the error keep the English feedback
+	fieldErr := asFieldError(MustSatisfyPasswordPolicy(PasswordPolicy{MinScore: PasswordSafelyUnguessable})("qwerty123", "password"), "password", "qwerty123")
+	if fieldErr.Params["warning_key"] != PasswordWarningTop100 || !strings.HasPrefix(fieldErr.Params["feedback"].(string), "This is a top-100") {
+		t.Errorf("MustSatisfyPasswordPolicy() params = %v, want the warning key and the English feedback", fieldErr.Params)
+	}
+}
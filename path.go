@@ -0,0 +1,213 @@
+package strval
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// This represents a step of a Path: the key of an object field or map entry, or the index of an array item
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// A Path locates a value in a nested payload, e.g. orders[3].shipping.address.line1
+// The empty path is the payload itself.
+type Path []PathSegment
+
+// ParsePath parses a dotted path with bracketed indexes, e.g. orders[3].shipping.address.line1
+// Keys that are not made of letters, digits, _ and - are quoted in brackets, e.g. labels["app.kubernetes.io/name"].
+// path: The path to parse, the empty string for the root
+// Returns the Path, or an error if path is malformed
+func ParsePath(path string) (Path, error) {
+	var p Path
+
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, errors.New("strval: path " + strconv.Quote(path) + ": missing ]")
+			}
+			inner := path[i+1 : i+end]
+
+			if strings.HasPrefix(inner, `"`) {
+				// A quoted key may hold ], so its end is found by unquoting
+				quoted, err := strconv.QuotedPrefix(path[i+1:])
+				if err != nil || !strings.HasPrefix(path[i+1+len(quoted):], "]") {
+					return nil, errors.New("strval: path " + strconv.Quote(path) + ": malformed quoted key")
+				}
+				key, _ := strconv.Unquote(quoted)
+				p = append(p, PathSegment{Key: key})
+				i += len(quoted) + 2
+				break
+			}
+
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, errors.New("strval: path " + strconv.Quote(path) + ": malformed index " + strconv.Quote(inner))
+			}
+			p = append(p, PathSegment{Index: index, IsIndex: true})
+			i += end + 1
+		case path[i] == '.' && len(p) > 0:
+			i++
+			fallthrough
+		default:
+			end := i
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			if end == i {
+				return nil, errors.New("strval: path " + strconv.Quote(path) + ": empty key")
+			}
+			p = append(p, PathSegment{Key: path[i:end]})
+			i = end
+		}
+
+		// A segment is followed by the next one, so a key cannot directly follow a bracket as in a[0]x
+		if i < len(path) && path[i] != '.' && path[i] != '[' {
+			return nil, errors.New("strval: path " + strconv.Quote(path) + ": malformed segment at byte " + strconv.Itoa(i))
+		}
+	}
+
+	return p, nil
+}
+
+// ParseJSONPointer parses a JSON pointer (RFC 6901), e.g. /orders/3/shipping/address/line1
+// Tokens made of digits without a leading zero are indexes, as a JSON pointer does not tell them apart from keys.
+// pointer: The pointer to parse, the empty string for the root
+// Returns the Path, or an error if pointer is malformed
+func ParseJSONPointer(pointer string) (Path, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, errors.New("strval: JSON pointer " + strconv.Quote(pointer) + " must start with /")
+	}
+
+	var p Path
+	for _, token := range strings.Split(pointer[1:], "/") {
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return nil, errors.New("strval: JSON pointer " + strconv.Quote(pointer) + ": malformed escape in " + strconv.Quote(token))
+		}
+
+		if isArrayIndex(token) {
+			index, err := strconv.Atoi(token)
+			if err == nil {
+				p = append(p, PathSegment{Index: index, IsIndex: true})
+				continue
+			}
+		}
+
+		// ~1 is unescaped first, so that ~01 becomes ~1
+		p = append(p, PathSegment{Key: strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")})
+	}
+
+	return p, nil
+}
+
+// Key returns a copy of the path with an object key appended
+func (p Path) Key(key string) Path {
+	return append(p[:len(p):len(p)], PathSegment{Key: key})
+}
+
+// Index returns a copy of the path with an array index appended
+func (p Path) Index(index int) Path {
+	return append(p[:len(p):len(p)], PathSegment{Index: index, IsIndex: true})
+}
+
+// Join returns a copy of the path with the segments of another path appended
+func (p Path) Join(other Path) Path {
+	return append(p[:len(p):len(p)], other...)
+}
+
+// String returns the dotted form of the path, e.g. orders[3].shipping.address.line1, as parsed by ParsePath
+func (p Path) String() string {
+	var sb strings.Builder
+
+	for i, segment := range p {
+		switch {
+		case segment.IsIndex:
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Itoa(segment.Index))
+			sb.WriteByte(']')
+		case !isPlainKey(segment.Key):
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Quote(segment.Key))
+			sb.WriteByte(']')
+		default:
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(segment.Key)
+		}
+	}
+
+	return sb.String()
+}
+
+// JSONPointer returns the path as a JSON pointer (RFC 6901), e.g. /orders/3/shipping/address/line1
+func (p Path) JSONPointer() string {
+	var sb strings.Builder
+
+	for _, segment := range p {
+		sb.WriteByte('/')
+		if segment.IsIndex {
+			sb.WriteString(strconv.Itoa(segment.Index))
+		} else {
+			sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(segment.Key, "~", "~0"), "/", "~1"))
+		}
+	}
+
+	return sb.String()
+}
+
+// compare orders two paths segment by segment, indexes numerically before keys
+func (p Path) compare(other Path) int {
+	for i := 0; i < len(p) && i < len(other); i++ {
+		a, b := p[i], other[i]
+
+		switch {
+		case a.IsIndex && b.IsIndex && a.Index != b.Index:
+			if a.Index < b.Index {
+				return -1
+			}
+			return 1
+		case a.IsIndex != b.IsIndex:
+			if a.IsIndex {
+				return -1
+			}
+			return 1
+		case !a.IsIndex && a.Key != b.Key:
+			return strings.Compare(a.Key, b.Key)
+		}
+	}
+
+	return len(p) - len(other)
+}
+
+// isPlainKey reports whether a key can be written in a dotted path without quotes
+func isPlainKey(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; !isASCIIDigit(c) && !isASCIIUpper(c) && !isASCIILower(c) && c != '_' && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isArrayIndex reports whether a JSON pointer token is an array index: digits without a leading zero
+func isArrayIndex(token string) bool {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return false
+	}
+
+	return isAllDigits(token)
+}
@@ -0,0 +1,197 @@
+package strval
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Tests ParsePath(path string) (Path, error) and Path.String()
+func TestParsePath(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		path            string
+		expected        Path
+		expectedPointer string
+		errExpected     bool
+	}{
+		{
+			name:            "root",
+			path:            "",
+			expected:        nil,
+			expectedPointer: "",
+		},
+		{
+			name:            "nested fields and indexes",
+			path:            "orders[3].shipping.address.line1",
+			expected:        Path{{Key: "orders"}, {Index: 3, IsIndex: true}, {Key: "shipping"}, {Key: "address"}, {Key: "line1"}},
+			expectedPointer: "/orders/3/shipping/address/line1",
+		},
+		{
+			name:            "nested indexes",
+			path:            "matrix[0][12]",
+			expected:        Path{{Key: "matrix"}, {Index: 0, IsIndex: true}, {Index: 12, IsIndex: true}},
+			expectedPointer: "/matrix/0/12",
+		},
+		{
+			name:            "quoted key",
+			path:            `labels["app.kubernetes.io/name"]`,
+			expected:        Path{{Key: "labels"}, {Key: "app.kubernetes.io/name"}},
+			expectedPointer: "/labels/app.kubernetes.io~1name",
+		},
+		{
+			name:            "quoted key with a bracket",
+			path:            `["a]b"].c`,
+			expected:        Path{{Key: "a]b"}, {Key: "c"}},
+			expectedPointer: "/a]b/c",
+		},
+		{
+			name:        "missing bracket",
+			path:        "orders[3",
+			errExpected: true,
+		},
+		{
+			name:        "negative index",
+			path:        "orders[-1]",
+			errExpected: true,
+		},
+		{
+			name:        "empty key",
+			path:        "orders..id",
+			errExpected: true,
+		},
+		{
+			name:        "leading dot",
+			path:        ".orders",
+			errExpected: true,
+		},
+		{
+			name:        "key directly after an index",
+			path:        "a[0]x",
+			errExpected: true,
+		},
+		{
+			name:        "key directly after a quoted key",
+			path:        `["a"]b`,
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePath(tt.path)
+
+			if tt.errExpected {
+				if err == nil {
+					t.Fatalf("ParsePath(%q) = %v, want an error", tt.path, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePath(%q) returned error: %v", tt.path, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParsePath(%q) = %#v, want %#v", tt.path, got, tt.expected)
+			}
+			if got.String() != tt.path {
+				t.Errorf("String() = %q, want %q", got.String(), tt.path)
+			}
+			if got.JSONPointer() != tt.expectedPointer {
+				t.Errorf("JSONPointer() = %q, want %q", got.JSONPointer(), tt.expectedPointer)
+			}
+		})
+	}
+}
+
+// Tests ParseJSONPointer(pointer string) (Path, error)
+func TestParseJSONPointer(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		pointer        string
+		expected       Path
+		expectedDotted string
+		errExpected    bool
+	}{
+		{
+			name:           "root",
+			pointer:        "",
+			expected:       nil,
+			expectedDotted: "",
+		},
+		{
+			name:           "nested fields and indexes",
+			pointer:        "/orders/3/shipping/address/line1",
+			expected:       Path{{Key: "orders"}, {Index: 3, IsIndex: true}, {Key: "shipping"}, {Key: "address"}, {Key: "line1"}},
+			expectedDotted: "orders[3].shipping.address.line1",
+		},
+		{
+			name:           "escaped keys",
+			pointer:        "/a~1b/m~0n/~01",
+			expected:       Path{{Key: "a/b"}, {Key: "m~n"}, {Key: "~1"}},
+			expectedDotted: `["a/b"]["m~n"]["~1"]`,
+		},
+		{
+			name:           "leading zero is a key",
+			pointer:        "/codes/01",
+			expected:       Path{{Key: "codes"}, {Key: "01"}},
+			expectedDotted: "codes.01",
+		},
+		{
+			name:           "empty key",
+			pointer:        "/",
+			expected:       Path{{Key: ""}},
+			expectedDotted: `[""]`,
+		},
+		{
+			name:        "missing slash",
+			pointer:     "orders/3",
+			errExpected: true,
+		},
+		{
+			name:        "malformed escape",
+			pointer:     "/a~2",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSONPointer(tt.pointer)
+
+			if tt.errExpected {
+				if err == nil {
+					t.Fatalf("ParseJSONPointer(%q) = %v, want an error", tt.pointer, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseJSONPointer(%q) returned error: %v", tt.pointer, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseJSONPointer(%q) = %#v, want %#v", tt.pointer, got, tt.expected)
+			}
+			if got.String() != tt.expectedDotted {
+				t.Errorf("String() = %q, want %q", got.String(), tt.expectedDotted)
+			}
+			if got.JSONPointer() != tt.pointer {
+				t.Errorf("JSONPointer() = %q, want %q", got.JSONPointer(), tt.pointer)
+			}
+		})
+	}
+}
+
+// Tests that Path.Key() and Path.Index() do not modify the path they extend
+func TestPathAppend(t *testing.T) {
+	order := make(Path, 0, 8).Key("orders").Index(3)
+	shipping := order.Key("shipping")
+	billing := order.Key("billing")
+
+	if shipping.String() != "orders[3].shipping" || billing.String() != "orders[3].billing" {
+		t.Errorf("paths = %s and %s, want orders[3].shipping and orders[3].billing", shipping, billing)
+	}
+}
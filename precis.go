@@ -0,0 +1,498 @@
+package strval
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// The string classes of the PRECIS framework, RFC 8264 section 4
+type precisClass uint8
+
+const (
+	precisIdentifierClass precisClass = iota
+	precisFreeformClass
+)
+
+// The derived property of a code point in the PRECIS framework, RFC 8264 section 8
+type precisProperty uint8
+
+const (
+	precisPValid precisProperty = iota
+	precisContextJ
+	precisContextO
+	// ID_DIS in the IdentifierClass, FREE_PVAL in the FreeformClass
+	precisFreeformOnly
+	precisDisallowed
+	precisUnassigned
+)
+
+// The Bidi_Class values used by the Bidi Rule, with the explicit formatting classes merged
+type bidiClass uint8
+
+const (
+	bidiL bidiClass = iota
+	bidiR
+	bidiAL
+	bidiEN
+	bidiES
+	bidiET
+	bidiAN
+	bidiCS
+	bidiNSM
+	bidiBN
+	bidiB
+	bidiS
+	bidiWS
+	bidiON
+	bidiExplicit
+)
+
+// A range of code points sharing a Bidi_Class
+type bidiClassRange struct {
+	lo, hi rune
+	class  bidiClass
+}
+
+// A fullwidth or halfwidth code point and its decomposition
+type widthMapping struct {
+	r, mapped rune
+}
+
+// The code point of the virama combining class, used by the rules of the joiners
+const viramaCombiningClass = 9
+
+// This represents a profile of the PRECIS framework (RFC 8264), which prepares and enforces the strings
+// used as identifiers or free text in protocols: which code points are allowed, and how strings are mapped
+// to a canonical form. Use UsernameCaseMapped, UsernameCasePreserved, OpaqueString or Nickname.
+type PRECISProfile struct {
+	name  string
+	class precisClass
+	// Maps fullwidth and halfwidth characters to their decomposition
+	widthMapping bool
+	// Maps the non-ASCII spaces to U+0020
+	spaceMapping bool
+	// Removes leading and trailing spaces and collapses the others
+	trimSpaces bool
+	// Maps to lower case when enforcing, or only when comparing
+	caseMapping        bool
+	compareCaseMapping bool
+	// Normalizes to NFKC instead of NFC
+	compatibility bool
+	// Applies the Bidi Rule of RFC 5893
+	bidiRule bool
+}
+
+// UsernameCaseMapped returns the profile of RFC 8265 section 3.3 for usernames compared case-insensitively:
+// letters and digits only, fullwidth characters mapped to their usual width, lower case and NFC
+func UsernameCaseMapped() PRECISProfile {
+	return PRECISProfile{
+		name:         "UsernameCaseMapped",
+		class:        precisIdentifierClass,
+		widthMapping: true,
+		caseMapping:  true,
+		bidiRule:     true,
+	}
+}
+
+// UsernameCasePreserved returns the profile of RFC 8265 section 3.4 for usernames compared case-sensitively:
+// like UsernameCaseMapped without the mapping to lower case
+func UsernameCasePreserved() PRECISProfile {
+	return PRECISProfile{
+		name:         "UsernameCasePreserved",
+		class:        precisIdentifierClass,
+		widthMapping: true,
+		bidiRule:     true,
+	}
+}
+
+// OpaqueString returns the profile of RFC 8265 section 4.2 for passwords and other secrets:
+// any letter, digit, space, symbol or punctuation, with non-ASCII spaces mapped to U+0020, and NFC
+func OpaqueString() PRECISProfile {
+	return PRECISProfile{
+		name:         "OpaqueString",
+		class:        precisFreeformClass,
+		spaceMapping: true,
+	}
+}
+
+// Nickname returns the profile of RFC 8266 for display names: like OpaqueString, with leading and trailing
+// spaces removed, runs of spaces collapsed and NFKC. Nicknames are compared case-insensitively.
+func Nickname() PRECISProfile {
+	return PRECISProfile{
+		name:               "Nickname",
+		class:              precisFreeformClass,
+		spaceMapping:       true,
+		trimSpaces:         true,
+		compareCaseMapping: true,
+		compatibility:      true,
+	}
+}
+
+// Name returns the name of the profile, e.g. UsernameCaseMapped
+func (p PRECISProfile) Name() string {
+	return p.name
+}
+
+// This represents the reason a string does not satisfy a PRECIS profile
+type PRECISError struct {
+	// The name of the profile
+	Profile string
+	// The rejected character, and its position in the prepared string in characters from 1.
+	// Position is 0 when the whole string is rejected.
+	Rune     rune
+	Position int
+	// Why the string is rejected, e.g. "is a space, which identifiers do not allow"
+	Reason string
+}
+
+// Error returns the name of the profile and the explanation of the error
+func (e *PRECISError) Error() string {
+	return "strval: " + e.Profile + ": " + e.Explanation()
+}
+
+// Explanation returns the rejected character, its position and the reason it is rejected
+func (e *PRECISError) Explanation() string {
+	if e.Position == 0 {
+		return "the string " + e.Reason
+	}
+
+	return fmt.Sprintf("%#U at position %d %s", e.Rune, e.Position, e.Reason)
+}
+
+// Enforce prepares a string with the mapping rules of the profile and checks that the result is allowed
+// str: The string to enforce
+// Returns the string in the canonical form of the profile, to be stored and compared,
+// or a *PRECISError explaining which character was rejected and why
+func (p PRECISProfile) Enforce(str string) (string, error) {
+	enforced := p.prepare(str)
+
+	// The rules are applied again until the result is stable, at most three more times (RFC 8264 section 7)
+	for i := 0; ; i++ {
+		again := p.prepare(enforced)
+		if again == enforced {
+			break
+		}
+		if i == 3 {
+			return "", &PRECISError{Profile: p.name, Reason: "does not stabilize when the rules of the profile are applied again"}
+		}
+		enforced = again
+	}
+
+	if enforced == "" {
+		return "", &PRECISError{Profile: p.name, Reason: "is empty"}
+	}
+
+	if err := p.check([]rune(enforced)); err != nil {
+		return "", err
+	}
+
+	return enforced, nil
+}
+
+// Compare reports whether two strings are equal once enforced, and both satisfy the profile
+func (p PRECISProfile) Compare(a, b string) bool {
+	a, errA := p.Enforce(a)
+	b, errB := p.Enforce(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	if p.compareCaseMapping {
+		a, b = NFKC(strings.ToLower(a)), NFKC(strings.ToLower(b))
+	}
+
+	return a == b
+}
+
+// Transform returns a transform applying the mapping rules of the profile, without rejecting any character.
+// For a string that satisfies the profile it returns the enforced string, so that it can be combined
+// with MustSatisfyPRECISProfile in ValidateAndNormalize.
+func (p PRECISProfile) Transform() StringTransform {
+	return p.prepare
+}
+
+// This option will validate that the string satisfies a PRECIS profile once its mapping rules are applied
+// The error explains which character was rejected and why. Use Enforce or Transform to get the string
+// in the canonical form of the profile.
+func MustSatisfyPRECISProfile(profile PRECISProfile) StringValidationOption {
+	return func(str, strName string) error {
+		if _, err := profile.Enforce(str); err != nil {
+			return newFieldError(strName, str, RulePRECIS, map[string]any{
+				"profile": profile.name,
+				"reason":  err.(*PRECISError).Explanation(),
+			})
+		}
+
+		return nil
+	}
+}
+
+// prepare applies the width mapping, additional mapping, case mapping and normalization rules, in this order
+func (p PRECISProfile) prepare(str string) string {
+	if p.widthMapping {
+		str = strings.Map(mapWidth, str)
+	}
+
+	if p.spaceMapping {
+		str = strings.Map(func(r rune) rune {
+			if r != ' ' && unicode.Is(unicode.Zs, r) {
+				return ' '
+			}
+			return r
+		}, str)
+	}
+
+	if p.trimSpaces {
+		str = strings.Join(strings.FieldsFunc(str, func(r rune) bool { return r == ' ' }), " ")
+	}
+
+	if p.caseMapping {
+		str = strings.ToLower(str)
+	}
+
+	return normalize(str, p.compatibility)
+}
+
+// check checks every character of a prepared string against the string class and the rules of the profile
+func (p PRECISProfile) check(runes []rune) error {
+	for i, r := range runes {
+		property, reason := precisPropertyOf(r)
+
+		switch property {
+		case precisPValid:
+			continue
+		case precisFreeformOnly:
+			if p.class == precisFreeformClass {
+				continue
+			}
+			reason += ", which identifiers do not allow"
+		case precisContextJ, precisContextO:
+			if reason = precisContextError(runes, i); reason == "" {
+				continue
+			}
+		}
+
+		return &PRECISError{Profile: p.name, Rune: r, Position: i + 1, Reason: reason}
+	}
+
+	if p.bidiRule {
+		if i, reason := bidiRuleError(runes); reason != "" {
+			return &PRECISError{Profile: p.name, Rune: runes[i], Position: i + 1, Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+// precisPropertyOf returns the derived property of a code point and, unless it is PVALID, a description of why
+// it is restricted. The rules are applied in the order of RFC 8264 section 8.
+func precisPropertyOf(r rune) (precisProperty, string) {
+	// Exceptions, RFC 5892 section 2.6
+	switch {
+	case r == 0x00DF || r == 0x03C2 || r == 0x06FD || r == 0x06FE || r == 0x0F0B || r == 0x3007:
+		return precisPValid, ""
+	case r == 0x00B7 || r == 0x0375 || r == 0x05F3 || r == 0x05F4 || r == 0x30FB ||
+		(r >= 0x0660 && r <= 0x0669) || (r >= 0x06F0 && r <= 0x06F9):
+		return precisContextO, ""
+	case r == 0x0640 || r == 0x07FA || r == 0x302E || r == 0x302F || (r >= 0x3031 && r <= 0x3035) || r == 0x303B:
+		return precisDisallowed, "is excluded by the exceptions of RFC 5892"
+	}
+
+	switch {
+	case !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.C):
+		return precisUnassigned, "is unassigned"
+	case r >= 0x21 && r <= 0x7E:
+		return precisPValid, ""
+	case r == 0x200C || r == 0x200D:
+		return precisContextJ, ""
+	case isOldHangulJamo(r):
+		return precisDisallowed, "is a conjoining Hangul jamo"
+	case isDefaultIgnorable(r):
+		return precisDisallowed, "is an invisible character"
+	case unicode.Is(unicode.Noncharacter_Code_Point, r):
+		return precisDisallowed, "is a noncharacter"
+	case unicode.Is(unicode.Cc, r):
+		return precisDisallowed, "is a control character"
+	case NFKC(string(r)) != string(r):
+		return precisFreeformOnly, "has a compatibility equivalent"
+	case unicode.In(r, unicode.Ll, unicode.Lu, unicode.Lo, unicode.Nd, unicode.Lm, unicode.Mn, unicode.Mc):
+		return precisPValid, ""
+	case unicode.In(r, unicode.Lt, unicode.Nl, unicode.No, unicode.Me):
+		return precisFreeformOnly, "is not a letter or digit"
+	case unicode.Is(unicode.Zs, r):
+		return precisFreeformOnly, "is a space"
+	case unicode.Is(unicode.S, r):
+		return precisFreeformOnly, "is a symbol"
+	case unicode.Is(unicode.P, r):
+		return precisFreeformOnly, "is a punctuation character"
+	default:
+		return precisDisallowed, "is not allowed in any PRECIS string class"
+	}
+}
+
+// isOldHangulJamo reports whether a code point is a conjoining Hangul jamo (Hangul_Syllable_Type L, V or T)
+func isOldHangulJamo(r rune) bool {
+	return (r >= 0x1100 && r <= 0x11FF) || (r >= 0xA960 && r <= 0xA97C) || (r >= 0xD7B0 && r <= 0xD7C6) || (r >= 0xD7CB && r <= 0xD7FB)
+}
+
+// precisContextError checks the contextual rule of the character at index i, RFC 5892 appendix A
+// Returns why the character is not allowed in its context, or an empty string if it is.
+// The joiners are only allowed after a virama: the rule based on the joining types of Arabic letters is not applied.
+func precisContextError(runes []rune, i int) string {
+	var before, after rune = -1, -1
+	if i > 0 {
+		before = runes[i-1]
+	}
+	if i+1 < len(runes) {
+		after = runes[i+1]
+	}
+
+	switch r := runes[i]; {
+	case r == 0x200C || r == 0x200D:
+		if before < 0 || combiningClass(before) != viramaCombiningClass {
+			return "is only allowed after a virama"
+		}
+	case r == 0x00B7:
+		if before != 'l' || after != 'l' {
+			return "is only allowed between two l"
+		}
+	case r == 0x0375:
+		if after < 0 || !unicode.Is(unicode.Greek, after) {
+			return "is only allowed before a Greek character"
+		}
+	case r == 0x05F3 || r == 0x05F4:
+		if before < 0 || !unicode.Is(unicode.Hebrew, before) {
+			return "is only allowed after a Hebrew character"
+		}
+	case r == 0x30FB:
+		for _, other := range runes {
+			if unicode.In(other, unicode.Hiragana, unicode.Katakana, unicode.Han) && other != 0x30FB {
+				return ""
+			}
+		}
+		return "is only allowed in a string with Hiragana, Katakana or Han characters"
+	case r >= 0x0660 && r <= 0x0669:
+		for _, other := range runes {
+			if other >= 0x06F0 && other <= 0x06F9 {
+				return "cannot be mixed with Extended Arabic-Indic digits"
+			}
+		}
+	case r >= 0x06F0 && r <= 0x06F9:
+		for _, other := range runes {
+			if other >= 0x0660 && other <= 0x0669 {
+				return "cannot be mixed with Arabic-Indic digits"
+			}
+		}
+	}
+
+	return ""
+}
+
+// bidiRuleError checks the Bidi Rule of RFC 5893 section 2, for strings holding right-to-left characters
+// Returns the index of the first character breaking the rule and why, or an empty reason
+func bidiRuleError(runes []rune) (int, string) {
+	rtl := false
+	for _, r := range runes {
+		if class := bidiClassOf(r); class == bidiR || class == bidiAL || class == bidiAN {
+			rtl = true
+			break
+		}
+	}
+	if !rtl {
+		return 0, ""
+	}
+
+	first := bidiClassOf(runes[0])
+	if first != bidiL && first != bidiR && first != bidiAL {
+		return 0, "cannot start a string holding right-to-left characters"
+	}
+
+	// The last character that is not a non-spacing mark
+	last := len(runes) - 1
+	for last > 0 && bidiClassOf(runes[last]) == bidiNSM {
+		last--
+	}
+
+	if first == bidiL {
+		for i, r := range runes {
+			switch bidiClassOf(r) {
+			case bidiL, bidiEN, bidiES, bidiCS, bidiET, bidiON, bidiBN, bidiNSM:
+			default:
+				return i, "cannot be used in a left-to-right string"
+			}
+		}
+
+		if class := bidiClassOf(runes[last]); class != bidiL && class != bidiEN {
+			return last, "cannot end a left-to-right string"
+		}
+
+		return 0, ""
+	}
+
+	hasEN, hasAN := false, false
+	for i, r := range runes {
+		switch bidiClassOf(r) {
+		case bidiEN:
+			hasEN = true
+		case bidiAN:
+			hasAN = true
+		case bidiR, bidiAL, bidiES, bidiCS, bidiET, bidiON, bidiBN, bidiNSM:
+		default:
+			return i, "cannot be used in a right-to-left string"
+		}
+
+		if hasEN && hasAN {
+			return i, "cannot mix European and Arabic digits in a right-to-left string"
+		}
+	}
+
+	switch bidiClassOf(runes[last]) {
+	case bidiR, bidiAL, bidiEN, bidiAN:
+		return 0, ""
+	default:
+		return last, "cannot end a right-to-left string"
+	}
+}
+
+// bidiClassOf returns the Bidi_Class of a code point
+func bidiClassOf(r rune) bidiClass {
+	lo, hi := 0, len(bidiClassRanges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < bidiClassRanges[mid].lo:
+			hi = mid
+		case r > bidiClassRanges[mid].hi:
+			lo = mid + 1
+		default:
+			return bidiClassRanges[mid].class
+		}
+	}
+
+	return bidiL
+}
+
+// mapWidth maps a fullwidth or halfwidth code point to its decomposition
+func mapWidth(r rune) rune {
+	if r < 0x3000 {
+		return r
+	}
+
+	lo, hi := 0, len(widthMappings)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < widthMappings[mid].r:
+			hi = mid
+		case r > widthMappings[mid].r:
+			lo = mid + 1
+		default:
+			return widthMappings[mid].mapped
+		}
+	}
+
+	return r
+}
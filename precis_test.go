@@ -0,0 +1,418 @@
+package strval
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests PRECISProfile.Enforce()
+func TestPRECISProfileEnforce(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name             string
+		profile          PRECISProfile
+		str              string
+		expected         string
+		errExpected      bool
+		expectedRune     rune
+		expectedPosition int
+	}{
+		{
+			name:     "username case mapped lowercases",
+			profile:  UsernameCaseMapped(),
+			str:      "Juliet",
+			expected: "juliet",
+		},
+		{
+			name:     "username case mapped maps fullwidth letters",
+			profile:  UsernameCaseMapped(),
+			str:      "Ｊｕｌｉｅｔ",
+			expected: "juliet",
+		},
+		{
+			name:     "username case mapped keeps ascii punctuation",
+			profile:  UsernameCaseMapped(),
+			str:      "juliet@example.com",
+			expected: "juliet@example.com",
+		},
+		{
+			name:     "username case mapped keeps the sharp s",
+			profile:  UsernameCaseMapped(),
+			str:      "Straße",
+			expected: "straße",
+		},
+		{
+			name:             "username with a space",
+			profile:          UsernameCaseMapped(),
+			str:              "Juliet Capulet",
+			errExpected:      true,
+			expectedRune:     ' ',
+			expectedPosition: 7,
+		},
+		{
+			name:             "username with a compatibility character",
+			profile:          UsernameCaseMapped(),
+			str:              "henryⅧ",
+			errExpected:      true,
+			expectedRune:     0x2177,
+			expectedPosition: 6,
+		},
+		{
+			name:             "username with a symbol",
+			profile:          UsernameCaseMapped(),
+			str:              "love♥",
+			errExpected:      true,
+			expectedRune:     0x2665,
+			expectedPosition: 5,
+		},
+		{
+			name:             "username with a control character",
+			profile:          UsernameCaseMapped(),
+			str:              "juliet\t",
+			errExpected:      true,
+			expectedRune:     '\t',
+			expectedPosition: 7,
+		},
+		{
+			name:             "username with a zero width space",
+			profile:          UsernameCaseMapped(),
+			str:              "jul\u200biet",
+			errExpected:      true,
+			expectedRune:     0x200B,
+			expectedPosition: 4,
+		},
+		{
+			name:     "username case preserved keeps the case",
+			profile:  UsernameCasePreserved(),
+			str:      "Juliet",
+			expected: "Juliet",
+		},
+		{
+			name:     "username composes combining marks",
+			profile:  UsernameCasePreserved(),
+			str:      "Jose\u0301",
+			expected: "Jos\u00e9",
+		},
+		{
+			name:     "right-to-left username",
+			profile:  UsernameCasePreserved(),
+			str:      "אבג123",
+			expected: "אבג123",
+		},
+		{
+			name:             "left-to-right username with a hebrew letter",
+			profile:          UsernameCasePreserved(),
+			str:              "abcא",
+			errExpected:      true,
+			expectedRune:     0x05D0,
+			expectedPosition: 4,
+		},
+		{
+			name:             "right-to-left username starting with a digit",
+			profile:          UsernameCasePreserved(),
+			str:              "1א",
+			errExpected:      true,
+			expectedRune:     '1',
+			expectedPosition: 1,
+		},
+		{
+			name:     "middle dot between two l",
+			profile:  UsernameCasePreserved(),
+			str:      "col·lecció",
+			expected: "col·lecció",
+		},
+		{
+			name:             "middle dot elsewhere",
+			profile:          UsernameCasePreserved(),
+			str:              "a·b",
+			errExpected:      true,
+			expectedRune:     0x00B7,
+			expectedPosition: 2,
+		},
+		{
+			name:     "zero width non-joiner after a virama",
+			profile:  UsernameCasePreserved(),
+			str:      "क\u094d\u200cष",
+			expected: "क\u094d\u200cष",
+		},
+		{
+			name:             "zero width joiner without a virama",
+			profile:          UsernameCasePreserved(),
+			str:              "a\u200db",
+			errExpected:      true,
+			expectedRune:     0x200D,
+			expectedPosition: 2,
+		},
+		{
+			name:             "mixed arabic-indic digits",
+			profile:          UsernameCasePreserved(),
+			str:              "١۱",
+			errExpected:      true,
+			expectedRune:     0x0661,
+			expectedPosition: 1,
+		},
+		{
+			name:             "conjoining hangul jamo",
+			profile:          UsernameCasePreserved(),
+			str:              "ᄀ",
+			errExpected:      true,
+			expectedRune:     0x1100,
+			expectedPosition: 1,
+		},
+		{
+			name:        "empty username",
+			profile:     UsernameCaseMapped(),
+			str:         "",
+			errExpected: true,
+		},
+		{
+			name:     "opaque string allows spaces and symbols",
+			profile:  OpaqueString(),
+			str:      "correct horse ♥ battery",
+			expected: "correct horse ♥ battery",
+		},
+		{
+			name:     "opaque string maps non-ascii spaces",
+			profile:  OpaqueString(),
+			str:      "correct\u00a0horse\u3000battery",
+			expected: "correct horse battery",
+		},
+		{
+			name:     "opaque string keeps the case and fullwidth letters",
+			profile:  OpaqueString(),
+			str:      "Ｐass",
+			expected: "Ｐass",
+		},
+		{
+			name:     "opaque string keeps leading spaces",
+			profile:  OpaqueString(),
+			str:      "  secret",
+			expected: "  secret",
+		},
+		{
+			name:             "opaque string with a control character",
+			profile:          OpaqueString(),
+			str:              "secret\n",
+			errExpected:      true,
+			expectedRune:     '\n',
+			expectedPosition: 7,
+		},
+		{
+			name:     "opaque string with left-to-right and right-to-left text",
+			profile:  OpaqueString(),
+			str:      "abcא",
+			expected: "abcא",
+		},
+		{
+			name:     "nickname trims and collapses spaces",
+			profile:  Nickname(),
+			str:      "  Foo \u00a0 Bar  ",
+			expected: "Foo Bar",
+		},
+		{
+			name:     "nickname normalizes compatibility characters",
+			profile:  Nickname(),
+			str:      "Henry Ⅷ",
+			expected: "Henry VIII",
+		},
+		{
+			name:     "nickname normalizes characters of Unicode 15.0",
+			profile:  Nickname(),
+			str:      "\U0001E030\U0001E031",
+			expected: "\u0430\u0431",
+		},
+		{
+			name:     "nickname keeps the case",
+			profile:  Nickname(),
+			str:      "Juliet",
+			expected: "Juliet",
+		},
+		{
+			name:        "nickname of spaces only",
+			profile:     Nickname(),
+			str:         "   ",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.profile.Enforce(tt.str)
+
+			if !tt.errExpected {
+				if err != nil {
+					t.Fatalf("Enforce(%q) returned error: %v", tt.str, err)
+				}
+				if got != tt.expected {
+					t.Errorf("Enforce(%q) = %q, want %q", tt.str, got, tt.expected)
+				}
+				return
+			}
+
+			var precisErr *PRECISError
+			if !errors.As(err, &precisErr) {
+				t.Fatalf("Enforce(%q) error = %v, want a *PRECISError", tt.str, err)
+			}
+			if precisErr.Profile != tt.profile.Name() {
+				t.Errorf("Profile = %q, want %q", precisErr.Profile, tt.profile.Name())
+			}
+			if precisErr.Rune != tt.expectedRune || precisErr.Position != tt.expectedPosition {
+				t.Errorf("rejected %U at position %d, want %U at position %d",
+					precisErr.Rune, precisErr.Position, tt.expectedRune, tt.expectedPosition)
+			}
+			if precisErr.Reason == "" {
+				t.Error("Reason is empty")
+			}
+		})
+	}
+}
+
+// Tests PRECISProfile.Compare()
+func TestPRECISProfileCompare(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		profile  PRECISProfile
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "case mapped usernames",
+			profile:  UsernameCaseMapped(),
+			a:        "Juliet",
+			b:        "ｊｕｌｉｅｔ",
+			expected: true,
+		},
+		{
+			name:     "case preserved usernames",
+			profile:  UsernameCasePreserved(),
+			a:        "Juliet",
+			b:        "juliet",
+			expected: false,
+		},
+		{
+			name:     "invalid usernames",
+			profile:  UsernameCaseMapped(),
+			a:        "a b",
+			b:        "a b",
+			expected: false,
+		},
+		{
+			name:     "opaque strings are case-sensitive",
+			profile:  OpaqueString(),
+			a:        "Secret",
+			b:        "secret",
+			expected: false,
+		},
+		{
+			name:     "opaque strings with different spaces",
+			profile:  OpaqueString(),
+			a:        "a b",
+			b:        "a b",
+			expected: true,
+		},
+		{
+			name:     "nicknames are case-insensitive",
+			profile:  Nickname(),
+			a:        "  Foo  Bar",
+			b:        "foo bar",
+			expected: true,
+		},
+		{
+			name:     "different nicknames",
+			profile:  Nickname(),
+			a:        "Foo",
+			b:        "Bar",
+			expected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.Compare(tt.a, tt.b); got != tt.expected {
+				t.Errorf("Compare(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustSatisfyPRECISProfile()
+func TestMustSatisfyPRECISProfile(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		profile         PRECISProfile
+		str             string
+		errExpected     bool
+		expectedMessage string
+	}{
+		{
+			name:        "valid username",
+			profile:     UsernameCaseMapped(),
+			str:         "Juliet",
+			errExpected: false,
+		},
+		{
+			name:            "username with a space",
+			profile:         UsernameCaseMapped(),
+			str:             "a b",
+			errExpected:     true,
+			expectedMessage: "username is not a valid UsernameCaseMapped: U+0020 ' ' at position 2 is a space, which identifiers do not allow",
+		},
+		{
+			name:            "username with an invisible character",
+			profile:         UsernameCasePreserved(),
+			str:             "ab\u00ad",
+			errExpected:     true,
+			expectedMessage: "username is not a valid UsernameCasePreserved: U+00AD at position 3 is an invisible character",
+		},
+		{
+			name:            "empty nickname",
+			profile:         Nickname(),
+			str:             " ",
+			errExpected:     true,
+			expectedMessage: "username is not a valid Nickname: the string is empty",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateStringWithName(tt.str, "username", MustSatisfyPRECISProfile(tt.profile))
+
+			if result.Valid == tt.errExpected {
+				t.Fatalf("Valid = %v, want %v", result.Valid, !tt.errExpected)
+			}
+			if !tt.errExpected {
+				return
+			}
+
+			fieldErr := result.Errors[0]
+			if fieldErr.Rule != RulePRECIS {
+				t.Errorf("Rule = %q, want %q", fieldErr.Rule, RulePRECIS)
+			}
+			if fieldErr.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", fieldErr.Message, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// Tests PRECISProfile.Transform() with ValidateAndNormalize()
+func TestPRECISProfileTransform(t *testing.T) {
+	profile := UsernameCaseMapped()
+
+	got, result := ValidateAndNormalize("ＪULIET", "username",
+		[]StringTransform{Trim(), profile.Transform()},
+		MustSatisfyPRECISProfile(profile),
+	)
+
+	if !result.Valid {
+		t.Fatalf("ValidateAndNormalize() returned errors: %v", result.Errors)
+	}
+	if got != "juliet" {
+		t.Errorf("ValidateAndNormalize() = %q, want %q", got, "juliet")
+	}
+}
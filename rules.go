@@ -0,0 +1,712 @@
+package strval
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A Rule is a validation rule referenced by name, as written in a rule string such as
+// "required|min:3|max:64|oneof:red,green,blue"
+type Rule struct {
+	// The registered name of the rule, e.g. min
+	Name string
+	// The arguments of the rule, unescaped
+	Args []string
+
+	// The option built while parsing, if any
+	option StringValidationOption
+}
+
+// Rules is a list of rules, in the order they are validated
+type Rules []Rule
+
+// A RuleFactory builds the option for a rule from its arguments.
+// Return a *RuleArgumentError to have a parse error point at the offending argument.
+type RuleFactory func(args []string) (StringValidationOption, error)
+
+// A RuleArgumentError reports an invalid argument of a rule
+type RuleArgumentError struct {
+	// The index of the offending argument; an index past the last argument means an argument is missing
+	Index int
+	// A description of what was expected, e.g. non-negative integer
+	Expected string
+}
+
+// Error describes the offending argument
+func (e *RuleArgumentError) Error() string {
+	return fmt.Sprintf("argument %d: expected %s", e.Index+1, e.Expected)
+}
+
+// A ParseError reports a rule string that could not be parsed
+type ParseError struct {
+	// The rule string
+	Input string
+	// The byte offset of the problem in Input
+	Offset int
+	// A description of what was expected at Offset
+	Expected string
+	// A description of what was found at Offset
+	Found string
+	// The underlying error, e.g. from a RuleFactory or regexp.Compile
+	Err error
+}
+
+// Error describes the problem and where it is
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("strval: rule syntax error at offset %d: expected %s, found %s", e.Offset, e.Expected, e.Found)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ruleRegistry holds the factory of every rule that can be referenced by name, and the descriptions of rules
+var ruleRegistry = struct {
+	sync.RWMutex
+	factories    map[string]RuleFactory
+	descriptions map[string]string
+}{factories: builtinRules(), descriptions: builtinRuleDescriptions()}
+
+// builtinRules returns the factories of the rules shipped with the package.
+// The same names are used by the strval struct tag.
+func builtinRules() map[string]RuleFactory {
+	return map[string]RuleFactory{
+		"required":         noArgRule(MustNotBeEmpty),
+		"notempty":         noArgRule(MustNotBeEmpty),
+		"alphanum":         noArgRule(MustBeAlphaNumeric),
+		"numbers":          noArgRule(MustContainNumbers),
+		"upper":            noArgRule(MustContainUppercaseLetter),
+		"lower":            noArgRule(MustContainLowercaseLetter),
+		"printable":        noArgRule(MustOnlyContainPrintableCharacters),
+		"ascii":            noArgRule(MustOnlyContainASCIICharacters),
+		"email":            emailRule,
+		"min":              lengthRule(MustHaveMinLengthOfIn),
+		"max":              lengthRule(MustHaveMaxLengthOfIn),
+		"between":          betweenRule,
+		"contains":         charactersRule(MustContainAtLeastOne),
+		"excludes":         charactersRule(MustNotContainAnyOf),
+		"oneof":            oneOfRule,
+		"regex":            regexRule,
+		"not_disposable":   noArgRule(MustNotBeDisposableEmail),
+		"not_role":         noArgRule(MustNotBeRoleAccount),
+		"email_domain":     emailDomainRule,
+		"password":         passwordRule,
+		"not_mixed_script": noArgRule(MustNotBeMixedScript),
+		"script":           scriptRule,
+		"not_confusable":   notConfusableRule,
+		"precis":           precisRule,
+		"url":              noArgRule(MustBeAbsoluteURL),
+		"url_scheme":       urlSchemeRule,
+		"url_host":         urlHostRule,
+		"not_ip_host":      noArgRule(MustNotBeIPHost),
+		"no_userinfo":      noArgRule(MustNotHaveUserInfo),
+		"no_fragment":      noArgRule(MustNotHaveFragment),
+		"redirect_uri":     redirectURIRule,
+	}
+}
+
+// builtinRuleDescriptions returns the descriptions of the rules shipped with the package, used in generated documentation
+func builtinRuleDescriptions() map[string]string {
+	return map[string]string{
+		"required":         "Must contain a character that is not whitespace",
+		"notempty":         "Must contain a character that is not whitespace",
+		"alphanum":         "Must only contain ASCII letters and digits",
+		"numbers":          "Must contain a digit",
+		"upper":            "Must contain an uppercase letter",
+		"lower":            "Must contain a lowercase letter",
+		"printable":        "Must only contain printable characters",
+		"ascii":            "Must only contain ASCII characters",
+		"email":            "Must be an email address",
+		"min":              "Must have a minimum length",
+		"max":              "Must have a maximum length",
+		"between":          "Must have a length within a range",
+		"contains":         "Must contain at least one of the listed characters",
+		"excludes":         "Must not contain any of the listed characters",
+		"oneof":            "Must be one of the listed values",
+		"regex":            "Must match a regular expression",
+		"not_disposable":   "Must not be an email address of a disposable email provider",
+		"not_role":         "Must not be a role account email address, such as admin@ or support@",
+		"email_domain":     "Must be an email address at one of the listed domains",
+		"password":         "Must satisfy a password policy and be hard to guess",
+		"not_mixed_script": "Must not mix letters of different scripts, such as Latin and Cyrillic",
+		"script":           "Must be written in one of the listed scripts",
+		"not_confusable":   "Must not be visually confusable with any of the listed values",
+		"precis":           "Must satisfy a PRECIS profile for usernames, passwords or nicknames",
+		"url":              "Must be an absolute URL",
+		"url_scheme":       "Must be a URL using one of the listed schemes",
+		"url_host":         "Must be a URL with one of the listed hosts",
+		"not_ip_host":      "Must not be a URL with an IP address host",
+		"no_userinfo":      "Must not be a URL with a user name or password",
+		"no_fragment":      "Must not be a URL with a fragment",
+		"redirect_uri":     "Must be one of the listed OAuth redirect URIs, loopback IP URIs accepting any port",
+	}
+}
+
+// RegisterRule makes an option available by name to rule strings and struct tags.
+// Names are made of lowercase letters, digits and underscores and start with a letter.
+// RegisterRule panics if the name is invalid or already registered.
+func RegisterRule(name string, factory RuleFactory) {
+	if !isRuleName(name) {
+		panic(fmt.Sprintf("strval: invalid rule name %q", name))
+	}
+
+	ruleRegistry.Lock()
+	defer ruleRegistry.Unlock()
+
+	if _, ok := ruleRegistry.factories[name]; ok {
+		panic(fmt.Sprintf("strval: rule %q is already registered", name))
+	}
+	ruleRegistry.factories[name] = factory
+}
+
+// DescribeRule sets the description of a registered rule, used in generated documentation such as
+// the x-strval-rules extension of OpenAPI schemas. DescribeRule panics if the rule is not registered.
+func DescribeRule(name, description string) {
+	ruleRegistry.Lock()
+	defer ruleRegistry.Unlock()
+
+	if _, ok := ruleRegistry.factories[name]; !ok {
+		panic(fmt.Sprintf("strval: rule %q is not registered", name))
+	}
+	ruleRegistry.descriptions[name] = description
+}
+
+// RuleNames returns the names of every registered rule, sorted
+func RuleNames() []string {
+	ruleRegistry.RLock()
+	defer ruleRegistry.RUnlock()
+
+	names := make([]string, 0, len(ruleRegistry.factories))
+	for name := range ruleRegistry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// lookupRule returns the factory registered under a name
+func lookupRule(name string) (RuleFactory, bool) {
+	ruleRegistry.RLock()
+	defer ruleRegistry.RUnlock()
+
+	factory, ok := ruleRegistry.factories[name]
+	return factory, ok
+}
+
+// ParseRules parses a rule string into its rules, building the option of each rule to check its arguments
+// s: A list of rules separated by |, each a name optionally followed by : and a comma separated list of arguments
+// Returns the rules, or a *ParseError pointing at the first problem
+//
+// Within an argument a backslash escapes a following comma, pipe or backslash, so "regex:^a\,b$"
+// has the single argument ^a,b$. A backslash followed by any other character is kept as it is.
+// An empty string holds no rules.
+func ParseRules(s string) (Rules, error) {
+	var rules Rules
+
+	if s == "" {
+		return rules, nil
+	}
+
+	p := &ruleParser{input: s}
+	for {
+		rule, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+
+		if p.pos == len(s) {
+			return rules, nil
+		}
+
+		// parseRule stops at the end of the input or at a separator
+		p.pos++
+	}
+}
+
+// CompileRules parses a rule string into the options it declares, see ParseRules for the syntax
+func CompileRules(s string) ([]StringValidationOption, error) {
+	rules, err := ParseRules(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules.Options()
+}
+
+// Option returns the option the rule declares
+func (r Rule) Option() (StringValidationOption, error) {
+	if r.option != nil {
+		return r.option, nil
+	}
+
+	factory, ok := lookupRule(r.Name)
+	if !ok {
+		return nil, fmt.Errorf("strval: unknown rule %q", r.Name)
+	}
+
+	option, err := factory(r.Args)
+	if err != nil {
+		return nil, fmt.Errorf("strval: rule %q: %w", r.Name, err)
+	}
+
+	return option, nil
+}
+
+// Description returns the description of the rule set with DescribeRule, empty if there is none
+func (r Rule) Description() string {
+	ruleRegistry.RLock()
+	defer ruleRegistry.RUnlock()
+
+	return ruleRegistry.descriptions[r.Name]
+}
+
+// Options returns the options the rules declare, in order
+func (r Rules) Options() ([]StringValidationOption, error) {
+	options := make([]StringValidationOption, 0, len(r))
+	for _, rule := range r {
+		option, err := rule.Option()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+// String returns the rule in the syntax accepted by ParseRules, escaping its arguments where needed
+func (r Rule) String() string {
+	if len(r.Args) == 0 {
+		return r.Name
+	}
+
+	var b strings.Builder
+	b.WriteString(r.Name)
+	b.WriteByte(':')
+	for i, arg := range r.Args {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		for j := 0; j < len(arg); j++ {
+			if isRuleEscapable(arg[j]) {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(arg[j])
+		}
+	}
+
+	return b.String()
+}
+
+// String returns the rules in the syntax accepted by ParseRules
+func (r Rules) String() string {
+	parts := make([]string, len(r))
+	for i, rule := range r {
+		parts[i] = rule.String()
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// This represents the state of ParseRules
+type ruleParser struct {
+	input string
+	pos   int
+}
+
+// parseRule parses a single rule starting at the current position
+func (p *ruleParser) parseRule() (Rule, error) {
+	nameStart := p.pos
+	for p.pos < len(p.input) && isRuleNameChar(p.input[p.pos], p.pos == nameStart) {
+		p.pos++
+	}
+
+	if p.pos == nameStart {
+		return Rule{}, p.errorf(p.pos, "rule name")
+	}
+
+	rule := Rule{Name: p.input[nameStart:p.pos]}
+
+	factory, ok := lookupRule(rule.Name)
+	if !ok {
+		return Rule{}, &ParseError{
+			Input:    p.input,
+			Offset:   nameStart,
+			Expected: "registered rule name",
+			Found:    strconv.Quote(rule.Name),
+		}
+	}
+
+	var argOffsets []int
+	if p.pos < len(p.input) && p.input[p.pos] == ':' {
+		p.pos++
+		for {
+			argOffsets = append(argOffsets, p.pos)
+			arg, err := p.parseArg()
+			if err != nil {
+				return Rule{}, err
+			}
+			rule.Args = append(rule.Args, arg)
+
+			if p.pos == len(p.input) || p.input[p.pos] != ',' {
+				break
+			}
+			p.pos++
+		}
+	}
+
+	if p.pos < len(p.input) && p.input[p.pos] != '|' {
+		expected := `":", "|" or end of input`
+		if len(rule.Args) > 0 {
+			expected = `"|" or end of input`
+		}
+		return Rule{}, p.errorf(p.pos, expected)
+	}
+
+	option, err := factory(rule.Args)
+	if err != nil {
+		var argErr *RuleArgumentError
+		if !errors.As(err, &argErr) {
+			return Rule{}, &ParseError{
+				Input:    p.input,
+				Offset:   nameStart,
+				Expected: "valid arguments for rule " + rule.Name,
+				Found:    strconv.Quote(strings.TrimPrefix(rule.String(), rule.Name)),
+				Err:      err,
+			}
+		}
+
+		if argErr.Index < len(rule.Args) {
+			return Rule{}, &ParseError{
+				Input:    p.input,
+				Offset:   argOffsets[argErr.Index],
+				Expected: argErr.Expected,
+				Found:    strconv.Quote(rule.Args[argErr.Index]),
+			}
+		}
+
+		return Rule{}, p.errorf(p.pos, argErr.Expected)
+	}
+
+	rule.option = option
+	return rule, nil
+}
+
+// parseArg parses a single argument starting at the current position, removing escapes
+func (p *ruleParser) parseArg() (string, error) {
+	var b strings.Builder
+
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == ',' || c == '|' {
+			break
+		}
+
+		if c == '\\' {
+			if p.pos+1 == len(p.input) {
+				return "", p.errorf(p.pos+1, `escaped ",", "|" or "\"`)
+			}
+			if isRuleEscapable(p.input[p.pos+1]) {
+				p.pos++
+				c = p.input[p.pos]
+			}
+		}
+
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	return b.String(), nil
+}
+
+// errorf creates a ParseError describing what was found at an offset
+func (p *ruleParser) errorf(offset int, expected string) *ParseError {
+	found := "end of input"
+	if offset < len(p.input) {
+		found = strconv.QuoteRune([]rune(p.input[offset:])[0])
+	}
+
+	return &ParseError{Input: p.input, Offset: offset, Expected: expected, Found: found}
+}
+
+// isRuleName checks if a string is a valid rule name
+func isRuleName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		if !isRuleNameChar(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRuleNameChar checks if a byte can appear in a rule name, names start with a letter
+func isRuleNameChar(c byte, first bool) bool {
+	return isASCIILower(c) || (!first && (isASCIIDigit(c) || c == '_'))
+}
+
+// isRuleEscapable checks if a byte must be escaped inside a rule argument
+func isRuleEscapable(c byte) bool {
+	return c == ',' || c == '|' || c == '\\'
+}
+
+// ruleArgCount checks that a rule has between minArgs and maxArgs arguments, maxArgs < 0 for no maximum
+func ruleArgCount(args []string, minArgs, maxArgs int) error {
+	switch {
+	case len(args) < minArgs:
+		return &RuleArgumentError{Index: len(args), Expected: fmt.Sprintf(`":" and %d argument(s)`, minArgs)}
+	case maxArgs == 0 && len(args) > 0:
+		return &RuleArgumentError{Index: 0, Expected: "no arguments"}
+	case maxArgs >= 0 && len(args) > maxArgs:
+		return &RuleArgumentError{Index: maxArgs, Expected: fmt.Sprintf("at most %d argument(s)", maxArgs)}
+	}
+	return nil
+}
+
+// intRuleArg parses a non-negative integer argument
+func intRuleArg(args []string, i int) (int, error) {
+	n, err := strconv.Atoi(args[i])
+	if err != nil || n < 0 {
+		return 0, &RuleArgumentError{Index: i, Expected: "non-negative integer"}
+	}
+	return n, nil
+}
+
+// unitRuleArg parses an optional length unit argument, bytes when absent
+func unitRuleArg(args []string, i int) (LengthUnit, error) {
+	if i >= len(args) {
+		return UnitBytes, nil
+	}
+
+	for _, unit := range []LengthUnit{UnitBytes, UnitRunes, UnitGraphemes} {
+		if args[i] == unit.String() {
+			return unit, nil
+		}
+	}
+	return 0, &RuleArgumentError{Index: i, Expected: "bytes, runes or graphemes"}
+}
+
+// noArgRule adapts an option constructor without parameters to a rule
+func noArgRule(option func() StringValidationOption) RuleFactory {
+	return func(args []string) (StringValidationOption, error) {
+		if err := ruleArgCount(args, 0, 0); err != nil {
+			return nil, err
+		}
+		return option(), nil
+	}
+}
+
+// lengthRule adapts a length option constructor to a rule taking a length and an optional unit
+func lengthRule(option func(int, LengthUnit) StringValidationOption) RuleFactory {
+	return func(args []string) (StringValidationOption, error) {
+		if err := ruleArgCount(args, 1, 2); err != nil {
+			return nil, err
+		}
+
+		n, err := intRuleArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		unit, err := unitRuleArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return option(n, unit), nil
+	}
+}
+
+// betweenRule builds the option for between:min,max[,unit]
+func betweenRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 2, 3); err != nil {
+		return nil, err
+	}
+
+	minLength, err := intRuleArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	maxLength, err := intRuleArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxLength < minLength {
+		return nil, &RuleArgumentError{Index: 1, Expected: "maximum of at least " + args[0]}
+	}
+
+	unit, err := unitRuleArg(args, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return MustHaveLengthBetweenIn(minLength, maxLength, unit), nil
+}
+
+// charactersRule adapts an option constructor with a character set parameter to a rule
+func charactersRule(option func([]rune) StringValidationOption) RuleFactory {
+	return func(args []string) (StringValidationOption, error) {
+		if err := ruleArgCount(args, 1, 1); err != nil {
+			return nil, err
+		}
+		if args[0] == "" {
+			return nil, &RuleArgumentError{Index: 0, Expected: "list of characters"}
+		}
+		return option([]rune(args[0])), nil
+	}
+}
+
+// emailRule builds the option for email, or email:mode for one of the EmailMode parsers
+func emailRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 0, 1); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		return MustBeValidEmailFormat(), nil
+	}
+
+	for _, mode := range []EmailMode{EmailHTML5, EmailRFC5322, EmailRFC6531} {
+		if args[0] == mode.String() {
+			return MustBeValidEmail(mode), nil
+		}
+	}
+	return nil, &RuleArgumentError{Index: 0, Expected: "html5, rfc5322 or rfc6531"}
+}
+
+// oneOfRule builds the option for oneof:value,...
+func oneOfRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+	return MustBeOneOf(args...), nil
+}
+
+// regexRule builds the option for regex:pattern
+func regexRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	re, err := regexp.Compile(args[0])
+	if err != nil {
+		return nil, &RuleArgumentError{Index: 0, Expected: "regular expression (" + err.Error() + ")"}
+	}
+	return MustMatchRegex(re), nil
+}
+
+// emailDomainRule builds the option for email_domain:domain,...
+func emailDomainRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+	return MustHaveEmailDomainIn(args), nil
+}
+
+// passwordRule builds the option for password, or password:preset with nist (the default) or nist_privileged
+func passwordRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 0, 1); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 || args[0] == "nist" {
+		return MustSatisfyPasswordPolicy(NISTPolicy()), nil
+	}
+	if args[0] == "nist_privileged" {
+		return MustSatisfyPasswordPolicy(NISTPrivilegedPolicy()), nil
+	}
+	return nil, &RuleArgumentError{Index: 0, Expected: "nist or nist_privileged"}
+}
+
+// scriptRule builds the option for script:name,..., with script names such as Latin or Cyrillic
+func scriptRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+	if _, err := scriptSetOf(args); err != nil {
+		return nil, err
+	}
+	return MustBeSingleScript(args...), nil
+}
+
+// notConfusableRule builds the option for not_confusable:value,...
+func notConfusableRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+	return MustNotBeConfusableWith(args), nil
+}
+
+// precisProfiles holds the PRECIS profiles by their name in rule strings
+var precisProfiles = map[string]func() PRECISProfile{
+	"username_case_mapped":    UsernameCaseMapped,
+	"username_case_preserved": UsernameCasePreserved,
+	"opaque_string":           OpaqueString,
+	"nickname":                Nickname,
+}
+
+// precisRule builds the option for precis:profile, with username_case_mapped, username_case_preserved,
+// opaque_string or nickname
+func precisRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	profile, ok := precisProfiles[args[0]]
+	if !ok {
+		return nil, &RuleArgumentError{Index: 0, Expected: "username_case_mapped, username_case_preserved, opaque_string or nickname"}
+	}
+	return MustSatisfyPRECISProfile(profile()), nil
+}
+
+// urlSchemeRule builds the option for url_scheme:scheme,...
+func urlSchemeRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+	for i, scheme := range args {
+		if uriScheme(scheme+":") != scheme {
+			return nil, &RuleArgumentError{Index: i, Expected: "URL scheme"}
+		}
+	}
+	return MustHaveSchemeIn(args...), nil
+}
+
+// urlHostRule builds the option for url_host:host,...
+func urlHostRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+	return MustHaveHostIn(args...), nil
+}
+
+// redirectURIRule builds the option for redirect_uri:uri,..., with the registered redirect URIs of an OAuth client
+func redirectURIRule(args []string) (StringValidationOption, error) {
+	if err := ruleArgCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+
+	for i, uri := range args {
+		if _, err := NewRedirectURIProfile(uri); err != nil {
+			return nil, &RuleArgumentError{Index: i, Expected: "registered redirect URI (" + err.Error() + ")"}
+		}
+	}
+	return MustBeRegisteredRedirectURI(MustNewRedirectURIProfile(args...)), nil
+}
@@ -0,0 +1,451 @@
+package strval
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Tests ParseRules(s string) (Rules, error)
+func TestParseRules(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		input    string
+		expected []Rule
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:  "names and arguments",
+			input: "required|min:3|max:64,runes|ascii|oneof:red,green,blue",
+			expected: []Rule{
+				{Name: "required"},
+				{Name: "min", Args: []string{"3"}},
+				{Name: "max", Args: []string{"64", "runes"}},
+				{Name: "ascii"},
+				{Name: "oneof", Args: []string{"red", "green", "blue"}},
+			},
+		},
+		{
+			name:  "escaped separators",
+			input: `regex:^[a-z]{1\,3}$|oneof:a\|b,c\\d`,
+			expected: []Rule{
+				{Name: "regex", Args: []string{"^[a-z]{1,3}$"}},
+				{Name: "oneof", Args: []string{"a|b", `c\d`}},
+			},
+		},
+		{
+			name:  "other backslashes are kept",
+			input: `regex:^\d+$`,
+			expected: []Rule{
+				{Name: "regex", Args: []string{`^\d+$`}},
+			},
+		},
+		{
+			name:  "empty argument",
+			input: "oneof:,none",
+			expected: []Rule{
+				{Name: "oneof", Args: []string{"", "none"}},
+			},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRules(%q) error = %v", tt.input, err)
+			}
+
+			if len(rules) != len(tt.expected) {
+				t.Fatalf("ParseRules(%q) = %v, want %v", tt.input, rules, tt.expected)
+			}
+
+			for i, rule := range rules {
+				if rule.Name != tt.expected[i].Name || strings.Join(rule.Args, "\x00") != strings.Join(tt.expected[i].Args, "\x00") {
+					t.Errorf("ParseRules(%q) rule %d = %q %q, want %q %q", tt.input, i, rule.Name, rule.Args, tt.expected[i].Name, tt.expected[i].Args)
+				}
+			}
+		})
+	}
+}
+
+// Tests the *ParseError returned by ParseRules(s string) (Rules, error)
+func TestParseRulesErrors(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name             string
+		input            string
+		expectedOffset   int
+		expectedExpected string
+		expectedFound    string
+	}{
+		{
+			name:             "empty rule",
+			input:            "required||min:3",
+			expectedOffset:   9,
+			expectedExpected: "rule name",
+			expectedFound:    `'|'`,
+		},
+		{
+			name:             "trailing separator",
+			input:            "required|",
+			expectedOffset:   9,
+			expectedExpected: "rule name",
+			expectedFound:    "end of input",
+		},
+		{
+			name:             "unknown rule",
+			input:            "required|bogus:1",
+			expectedOffset:   9,
+			expectedExpected: "registered rule name",
+			expectedFound:    `"bogus"`,
+		},
+		{
+			name:             "space after a name",
+			input:            "required |min:3",
+			expectedOffset:   8,
+			expectedExpected: `":", "|" or end of input`,
+			expectedFound:    `' '`,
+		},
+		{
+			name:             "bad integer",
+			input:            "min:3|max:lots",
+			expectedOffset:   10,
+			expectedExpected: "non-negative integer",
+			expectedFound:    `"lots"`,
+		},
+		{
+			name:             "bad unit",
+			input:            "max:3,words",
+			expectedOffset:   6,
+			expectedExpected: "bytes, runes or graphemes",
+			expectedFound:    `"words"`,
+		},
+		{
+			name:             "missing argument",
+			input:            "min|max:3",
+			expectedOffset:   3,
+			expectedExpected: `":" and 1 argument(s)`,
+			expectedFound:    `'|'`,
+		},
+		{
+			name:             "unexpected argument",
+			input:            "ascii:yes",
+			expectedOffset:   6,
+			expectedExpected: "no arguments",
+			expectedFound:    `"yes"`,
+		},
+		{
+			name:             "unescaped comma in a pattern",
+			input:            "regex:^a{1,2}$",
+			expectedOffset:   11,
+			expectedExpected: "at most 1 argument(s)",
+			expectedFound:    `"2}$"`,
+		},
+		{
+			name:             "invalid second redirect URI",
+			input:            "redirect_uri:https://a.example/cb,/cb",
+			expectedOffset:   34,
+			expectedExpected: `registered redirect URI (strval: registered redirect URI "/cb": missing scheme)`,
+			expectedFound:    `"/cb"`,
+		},
+		{
+			name:             "dangling escape",
+			input:            `oneof:a\`,
+			expectedOffset:   8,
+			expectedExpected: `escaped ",", "|" or "\"`,
+			expectedFound:    "end of input",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules(tt.input)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseRules(%q) error = %v, want a *ParseError", tt.input, err)
+			}
+
+			if parseErr.Offset != tt.expectedOffset || parseErr.Expected != tt.expectedExpected || parseErr.Found != tt.expectedFound {
+				t.Errorf("ParseRules(%q) error at %d expected %s found %s, want at %d expected %s found %s", tt.input,
+					parseErr.Offset, parseErr.Expected, parseErr.Found, tt.expectedOffset, tt.expectedExpected, tt.expectedFound)
+			}
+		})
+	}
+}
+
+// Tests that every built-in rule round trips through Rule.String() and ParseRules()
+func TestRulesStringRoundTrip(t *testing.T) {
+	examples := map[string]string{
+		"required":         "required",
+		"notempty":         "notempty",
+		"alphanum":         "alphanum",
+		"numbers":          "numbers",
+		"upper":            "upper",
+		"lower":            "lower",
+		"printable":        "printable",
+		"ascii":            "ascii",
+		"email":            "email:rfc6531",
+		"min":              "min:3,graphemes",
+		"max":              "max:64",
+		"between":          "between:2,8,runes",
+		"contains":         `contains:!\,\|\\`,
+		"excludes":         "excludes:<>",
+		"oneof":            `oneof:red,green\,blue`,
+		"regex":            `regex:^[a-z]{1\,3}\\d$`,
+		"not_disposable":   "not_disposable",
+		"not_role":         "not_role",
+		"email_domain":     "email_domain:example.com,example.org",
+		"password":         "password:nist_privileged",
+		"not_mixed_script": "not_mixed_script",
+		"script":           "script:Latin,Cyrillic",
+		"not_confusable":   "not_confusable:admin,paypal",
+		"precis":           "precis:username_case_mapped",
+		"url":              "url",
+		"url_scheme":       "url_scheme:https,wss",
+		"url_host":         "url_host:example.com,api.example.com",
+		"not_ip_host":      "not_ip_host",
+		"no_userinfo":      "no_userinfo",
+		"no_fragment":      "no_fragment",
+		"redirect_uri":     "redirect_uri:https://app.example.com/callback,http://127.0.0.1/callback",
+	}
+
+	for _, name := range RuleNames() {
+		example, ok := examples[name]
+		if !ok {
+			if _, builtin := builtinRules()[name]; builtin {
+				t.Errorf("built-in rule %s has no round trip example", name)
+			}
+			continue
+		}
+
+		rules, err := ParseRules(example)
+		if err != nil {
+			t.Errorf("ParseRules(%q) error = %v", example, err)
+			continue
+		}
+
+		if got := rules.String(); got != example {
+			t.Errorf("ParseRules(%q).String() = %q", example, got)
+		}
+
+		again, err := ParseRules(rules.String())
+		if err != nil || again.String() != example {
+			t.Errorf("ParseRules(%q) does not round trip: %v", rules.String(), err)
+		}
+	}
+}
+
+// Tests CompileRules(s string) ([]StringValidationOption, error)
+func TestCompileRules(t *testing.T) {
+	options, err := CompileRules(`required|between:3,8|regex:^[a-z]+$|oneof:red,green,blue`)
+	if err != nil {
+		t.Fatalf("CompileRules() error = %v", err)
+	}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		str           string
+		expectedRules []string
+	}{
+		{
+			name: "valid",
+			str:  "green",
+		},
+		{
+			name:          "not allowed",
+			str:           "purple",
+			expectedRules: []string{RuleOneOf},
+		},
+		{
+			name:          "several failures",
+			str:           "BLUE-ISH!",
+			expectedRules: []string{RuleLengthBetween, RuleRegex, RuleOneOf},
+		},
+		{
+			name:          "empty",
+			str:           "",
+			expectedRules: []string{RuleNotEmpty, RuleLengthBetween, RuleRegex, RuleOneOf},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateStringWithName(tt.str, "color", options...)
+
+			var rules []string
+			for _, fieldErr := range result.Errors {
+				rules = append(rules, fieldErr.Rule)
+			}
+
+			if strings.Join(rules, ",") != strings.Join(tt.expectedRules, ",") {
+				t.Errorf("CompileRules() rules = %v, want %v", rules, tt.expectedRules)
+			}
+		})
+	}
+}
+
+// Tests RegisterRule(name string, factory RuleFactory)
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("test_prefix", func(args []string) (StringValidationOption, error) {
+		if len(args) != 1 {
+			return nil, &RuleArgumentError{Index: len(args), Expected: "a prefix"}
+		}
+		return func(str, strName string) error {
+			if !strings.HasPrefix(str, args[0]) {
+				return errors.New(strName + " must start with " + args[0])
+			}
+			return nil
+		}, nil
+	})
+	defer func() {
+		ruleRegistry.Lock()
+		delete(ruleRegistry.factories, "test_prefix")
+		delete(ruleRegistry.descriptions, "test_prefix")
+		ruleRegistry.Unlock()
+	}()
+
+	options, err := CompileRules("required|test_prefix:sku-")
+	if err != nil {
+		t.Fatalf("CompileRules() error = %v", err)
+	}
+
+	if result := ValidateStringWithName("abc", "code", options...); result.Valid || result.Messages[0] != "code must start with sku-" {
+		t.Errorf("registered rule result = %+v", result)
+	}
+
+	// Registered rules are available to struct tags as well
+	type product struct {
+		Code string `strval:"test_prefix=sku-"`
+	}
+
+	result, err := ValidateStruct(product{Code: "sku-1"})
+	if err != nil || !result.Valid {
+		t.Errorf("ValidateStruct() = %+v, %v, expected the registered rule to pass", result, err)
+	}
+
+	if _, err := ParseRules("test_prefix"); err == nil || !strings.Contains(err.Error(), "a prefix") {
+		t.Errorf("ParseRules() error = %v, expected the factory error", err)
+	}
+
+	for _, name := range []string{"test_prefix", "Bad-Name", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterRule(%q) expected a panic", name)
+				}
+			}()
+			RegisterRule(name, oneOfRule)
+		}()
+	}
+
+	DescribeRule("test_prefix", "Must start with a prefix")
+	if got := (Rule{Name: "test_prefix"}).Description(); got != "Must start with a prefix" {
+		t.Errorf("Description() = %q, want the description set with DescribeRule", got)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("DescribeRule() expected a panic for an unregistered rule")
+			}
+		}()
+		DescribeRule("test_missing", "")
+	}()
+}
+
+// Tests StringValidationOption MustBeOneOf()
+func TestMustBeOneOf(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "allowed value",
+			str:         "green",
+			errExpected: false,
+		},
+		{
+			name:        "different case",
+			str:         "Green",
+			errExpected: true,
+		},
+		{
+			name:        "empty",
+			str:         "",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustBeOneOf("red", "green", "blue")(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Errorf("MustBeOneOf() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			// Make sure the allowed values are in the error message
+			if errFound && !strings.Contains(err.Error(), "str must be one of the following values: red, green, blue") {
+				t.Errorf("MustBeOneOf() error = %v, expected to list the values", err)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustMatchRegex()
+func TestMustMatchRegex(t *testing.T) {
+	option := MustMatchRegex(regexp.MustCompile(`^[a-z]+-\d+$`))
+
+	// Test cases
+	tests := []struct {
+		name        string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "match",
+			str:         "sku-42",
+			errExpected: false,
+		},
+		{
+			name:        "no match",
+			str:         "SKU-42",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := option(tt.str, "str")
+
+			errFound := err != nil
+
+			if errFound != tt.errExpected {
+				t.Errorf("MustMatchRegex() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			// Make sure the strName is in the error message
+			if errFound && !strings.Contains(err.Error(), "str") {
+				t.Errorf("MustMatchRegex() strName error = %v, expected to contain strName str", err)
+			}
+		})
+	}
+}
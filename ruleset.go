@@ -0,0 +1,377 @@
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dmars8047/strval/internal/yamlite"
+)
+
+// RuleSetVersion is the version of the rule set document format understood by ParseRuleSet
+const RuleSetVersion = 1
+
+// A RuleSet holds the compiled rules of every field of a form, loaded from a JSON or YAML document:
+//
+//	version: 1
+//	fields:
+//	  username:
+//	    - required|alphanum
+//	    - rule: between
+//	      args: [3, 32, runes]
+//	      message: "{field} must have between {min} and {max} characters"
+//	  email:
+//	    - email
+//	    - not_disposable
+//
+// Each field holds a list of entries. An entry is either a rule string as accepted by ParseRules,
+// or an object with the rule name, its arguments and an optional message replacing the default one.
+// Messages can use {field} and the parameters of the rule, e.g. {min}.
+type RuleSet struct {
+	version int
+	fields  map[string][]ruleSetRule
+	options map[string][]StringValidationOption
+	names   []string
+}
+
+// A compiled rule of a RuleSet
+type ruleSetRule struct {
+	rule    Rule
+	message string
+	option  StringValidationOption
+}
+
+// The document read by ParseRuleSet
+type ruleSetDocument struct {
+	Version int                       `json:"version"`
+	Fields  map[string][]ruleSetEntry `json:"fields"`
+}
+
+// An entry in the rule list of a field, either a rule string or an object
+type ruleSetEntry struct {
+	Rule    string `json:"rule"`
+	Args    []any  `json:"args"`
+	Message string `json:"message"`
+}
+
+// UnmarshalJSON accepts a rule string or an object with rule, args and message
+func (e *ruleSetEntry) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &e.Rule)
+	}
+
+	// Decode into a type without this method, rejecting misspelled keys
+	type entry ruleSetEntry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode((*entry)(e))
+}
+
+// ParseRuleSet parses and compiles a rule set document
+// data: A JSON document, or a YAML document using block and single line flow collections
+// Returns the compiled RuleSet, or an error naming the field and entry that could not be compiled
+func ParseRuleSet(data []byte) (*RuleSet, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	// JSON documents are objects; anything else is read as YAML and converted to JSON
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		v, err := yamlite.Unmarshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("strval: rule set: %w", err)
+		}
+
+		if data, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("strval: rule set: %w", err)
+		}
+	}
+
+	var doc ruleSetDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("strval: rule set: %w", err)
+	}
+
+	switch {
+	case doc.Version == 0:
+		return nil, errors.New("strval: rule set: missing version")
+	case doc.Version > RuleSetVersion || doc.Version < 0:
+		return nil, fmt.Errorf("strval: rule set: unsupported version %d, expected at most %d", doc.Version, RuleSetVersion)
+	}
+
+	rs := &RuleSet{
+		version: doc.Version,
+		fields:  make(map[string][]ruleSetRule, len(doc.Fields)),
+		options: make(map[string][]StringValidationOption, len(doc.Fields)),
+	}
+
+	for field := range doc.Fields {
+		rs.names = append(rs.names, field)
+	}
+	sort.Strings(rs.names)
+
+	for _, field := range rs.names {
+		for i, entry := range doc.Fields[field] {
+			rules, err := compileRuleSetEntry(entry)
+			if err != nil {
+				return nil, fmt.Errorf("strval: rule set field %q entry %d: %w", field, i+1, err)
+			}
+
+			for _, rule := range rules {
+				rs.fields[field] = append(rs.fields[field], rule)
+				rs.options[field] = append(rs.options[field], rule.option)
+			}
+		}
+	}
+
+	return rs, nil
+}
+
+// LoadRuleSetFile reads and compiles a rule set document from a local file, see ParseRuleSet
+func LoadRuleSetFile(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRuleSet(data)
+}
+
+// compileRuleSetEntry compiles the rules of a single entry
+func compileRuleSetEntry(entry ruleSetEntry) ([]ruleSetRule, error) {
+	var rules Rules
+
+	if entry.Args != nil {
+		// The arguments are given separately, so the rule must be a bare name
+		if !isRuleName(entry.Rule) {
+			return nil, fmt.Errorf("rule %q must be a single rule name when args are given", entry.Rule)
+		}
+
+		rule := Rule{Name: entry.Rule}
+		for _, arg := range entry.Args {
+			rule.Args = append(rule.Args, ruleSetArg(arg))
+		}
+		rules = Rules{rule}
+	} else {
+		if entry.Rule == "" {
+			return nil, errors.New("missing rule")
+		}
+
+		var err error
+		if rules, err = ParseRules(entry.Rule); err != nil {
+			return nil, err
+		}
+	}
+
+	compiled := make([]ruleSetRule, 0, len(rules))
+	for _, rule := range rules {
+		option, err := rule.Option()
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Message != "" {
+			option = withCustomMessage(option, entry.Message)
+		}
+
+		compiled = append(compiled, ruleSetRule{rule: rule, message: entry.Message, option: option})
+	}
+
+	return compiled, nil
+}
+
+// ruleSetArg converts a JSON argument to the string form rules take
+func ruleSetArg(arg any) string {
+	switch v := arg.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// withCustomMessage replaces the message of the errors returned by an option with a template
+func withCustomMessage(option StringValidationOption, message string) StringValidationOption {
+	return func(str, strName string) error {
+		if err := option(str, strName); err != nil {
+			return withMessage(asFieldError(err, strName, str), message)
+		}
+
+		return nil
+	}
+}
+
+// Version returns the version of the document the rule set was loaded from
+func (rs *RuleSet) Version() int {
+	return rs.version
+}
+
+// Fields returns the names of the fields that have rules, sorted
+func (rs *RuleSet) Fields() []string {
+	return append([]string(nil), rs.names...)
+}
+
+// Rules returns the rules of a field
+func (rs *RuleSet) Rules(field string) Rules {
+	rules := make(Rules, len(rs.fields[field]))
+	for i, compiled := range rs.fields[field] {
+		rules[i] = compiled.rule
+	}
+
+	return rules
+}
+
+// Options returns the compiled options of a field, including custom messages
+func (rs *RuleSet) Options(field string) []StringValidationOption {
+	return append([]StringValidationOption(nil), rs.options[field]...)
+}
+
+// Validate validates every field of the rule set in a payload
+// payload: The submitted form, keyed by field name; a missing field is validated as an empty string
+// Returns a StructValidationResult keyed by field name. Fields of the payload without rules are ignored.
+func (rs *RuleSet) Validate(payload map[string]string) StructValidationResult {
+	result := StructValidationResult{
+		Valid:  true,
+		Fields: make(map[string]StringValidationResult, len(rs.names)),
+	}
+
+	for _, field := range rs.names {
+		result.Add(field, ValidateStringWithName(payload[field], field, rs.options[field]...))
+	}
+
+	return result
+}
+
+// A RuleSetWatcher keeps a RuleSet in sync with a local file, see WatchRuleSetFile
+type RuleSetWatcher struct {
+	path    string
+	current atomic.Pointer[RuleSet]
+	onError func(error)
+
+	// Guards the state of the last file that was loaded
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchRuleSetFile loads a rule set file and polls it for changes every interval.
+// When the file changes it is loaded again and the new rules replace the old ones atomically:
+// validations already running finish with the rules they started with.
+// If the changed file cannot be loaded the previous rules stay in place and onError, if not nil, is called.
+// Returns an error if the interval is not positive or the file cannot be loaded initially. Call Close to stop watching.
+func WatchRuleSetFile(path string, interval time.Duration, onError func(error)) (*RuleSetWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("strval: rule set watch interval must be positive, got %s", interval)
+	}
+
+	w := &RuleSetWatcher{
+		path:    path,
+		onError: onError,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	go w.poll(interval)
+
+	return w, nil
+}
+
+// RuleSet returns the current rules
+func (w *RuleSetWatcher) RuleSet() *RuleSet {
+	return w.current.Load()
+}
+
+// Validate validates a payload against the current rules, see RuleSet.Validate
+func (w *RuleSetWatcher) Validate(payload map[string]string) StructValidationResult {
+	return w.current.Load().Validate(payload)
+}
+
+// Reload loads the file immediately, keeping the current rules if it cannot be loaded
+func (w *RuleSetWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+
+	return w.load(info)
+}
+
+// Close stops watching the file; the current rules remain usable
+func (w *RuleSetWatcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+	})
+}
+
+// load loads the file described by info, recording its state so that the same version is not loaded twice
+// The state is only recorded once the file is loaded, so that a file that was caught half-written is loaded again.
+func (w *RuleSetWatcher) load(info os.FileInfo) error {
+	rs, err := LoadRuleSetFile(w.path)
+	if err != nil {
+		return err
+	}
+
+	w.modTime, w.size = info.ModTime(), info.Size()
+	w.current.Store(rs)
+	return nil
+}
+
+// poll checks the file for changes until the watcher is closed
+func (w *RuleSetWatcher) poll(interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.reloadIfChanged(); err != nil && w.onError != nil {
+				w.onError(err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged loads the file if its modification time or size changed since it was last loaded
+func (w *RuleSetWatcher) reloadIfChanged() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return nil
+	}
+
+	return w.load(info)
+}
@@ -0,0 +1,279 @@
+package strval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testRuleSetYAML = `# Signup form
+version: 1
+fields:
+  username:
+    - required|alphanum
+    - rule: between
+      args: [3, 16, runes]
+      message: "{field} must have between {min} and {max} characters"
+  email:
+    - email
+    - not_disposable
+  plan:
+    - "oneof:free,pro"
+`
+
+const testRuleSetJSON = `{
+	"version": 1,
+	"fields": {
+		"username": [
+			"required|alphanum",
+			{"rule": "between", "args": [3, 16, "runes"], "message": "{field} must have between {min} and {max} characters"}
+		],
+		"email": ["email", "not_disposable"],
+		"plan": ["oneof:free,pro"]
+	}
+}`
+
+// Tests ParseRuleSet(data []byte) (*RuleSet, error) and RuleSet.Validate(payload map[string]string) StructValidationResult
+func TestRuleSetValidate(t *testing.T) {
+	for format, document := range map[string]string{"yaml": testRuleSetYAML, "json": testRuleSetJSON} {
+		rs, err := ParseRuleSet([]byte(document))
+		if err != nil {
+			t.Fatalf("ParseRuleSet(%s) error = %v", format, err)
+		}
+
+		if rs.Version() != 1 || strings.Join(rs.Fields(), ",") != "email,plan,username" {
+			t.Errorf("ParseRuleSet(%s) version = %d, fields = %v", format, rs.Version(), rs.Fields())
+		}
+
+		if got := rs.Rules("username").String(); got != "required|alphanum|between:3,16,runes" {
+			t.Errorf("ParseRuleSet(%s) username rules = %s", format, got)
+		}
+
+		// Test cases
+		tests := []struct {
+			name            string
+			payload         map[string]string
+			expectValid     bool
+			invalidFields   []string
+			expectedMessage string
+		}{
+			{
+				name:        "valid payload",
+				payload:     map[string]string{"username": "jane", "email": "jane@example.com", "plan": "pro", "extra": "ignored"},
+				expectValid: true,
+			},
+			{
+				name:            "custom message",
+				payload:         map[string]string{"username": "jo", "email": "jo@example.com", "plan": "free"},
+				invalidFields:   []string{"username"},
+				expectedMessage: "username must have between 3 and 16 characters",
+			},
+			{
+				name:          "missing fields",
+				payload:       map[string]string{"email": "jane@mailinator.com"},
+				invalidFields: []string{"username", "email", "plan"},
+			},
+		}
+
+		// Run tests
+		for _, tt := range tests {
+			t.Run(format+"/"+tt.name, func(t *testing.T) {
+				result := rs.Validate(tt.payload)
+
+				if result.Valid != tt.expectValid {
+					t.Errorf("Validate() Valid = %v, want %v", result.Valid, tt.expectValid)
+				}
+
+				for _, field := range tt.invalidFields {
+					if result.Fields[field].Valid {
+						t.Errorf("Validate() expected field %s to be invalid", field)
+					}
+				}
+
+				if _, ok := result.Fields["extra"]; ok {
+					t.Errorf("Validate() reported a field without rules")
+				}
+
+				if tt.expectedMessage != "" && result.Fields[tt.invalidFields[0]].Messages[0] != tt.expectedMessage {
+					t.Errorf("Validate() message = %q, want %q", result.Fields[tt.invalidFields[0]].Messages[0], tt.expectedMessage)
+				}
+			})
+		}
+	}
+}
+
+// Tests ParseRuleSet(data []byte) (*RuleSet, error) with unusable documents
+func TestParseRuleSetErrors(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name          string
+		document      string
+		expectedError string
+	}{
+		{
+			name:          "missing version",
+			document:      "fields:\n  a: [required]\n",
+			expectedError: "missing version",
+		},
+		{
+			name:          "future version",
+			document:      `{"version": 2, "fields": {}}`,
+			expectedError: "unsupported version 2",
+		},
+		{
+			name:          "misspelled key",
+			document:      "version: 1\nfeilds: {}\n",
+			expectedError: `unknown field "feilds"`,
+		},
+		{
+			name:          "misspelled entry key",
+			document:      "version: 1\nfields:\n  a:\n    - rule: min\n      arg: [3]\n",
+			expectedError: `unknown field "arg"`,
+		},
+		{
+			name:          "bad rule string",
+			document:      "version: 1\nfields:\n  a: [required]\n  b:\n    - required\n    - min:x\n",
+			expectedError: `field "b" entry 2: strval: rule syntax error at offset 4`,
+		},
+		{
+			name:          "rule string with args",
+			document:      `{"version": 1, "fields": {"a": [{"rule": "min:3", "args": [3]}]}}`,
+			expectedError: "must be a single rule name",
+		},
+		{
+			name:          "bad argument",
+			document:      `{"version": 1, "fields": {"a": [{"rule": "max", "args": [-1]}]}}`,
+			expectedError: "non-negative integer",
+		},
+		{
+			name:          "invalid YAML",
+			document:      "version: 1\nfields:\n\t- a\n",
+			expectedError: "yaml: line 3",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRuleSet([]byte(tt.document))
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("ParseRuleSet() error = %v, want an error containing %q", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+// Tests WatchRuleSetFile(path string, interval time.Duration, onError func(error)) (*RuleSetWatcher, error)
+func TestWatchRuleSetFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	modTime := time.Now()
+
+	write := func(document string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(document), 0o600); err != nil {
+			t.Fatalf("failed to write rule set: %v", err)
+		}
+		// Make every write visible to the watcher, even on file systems with a coarse modification time
+		modTime = modTime.Add(time.Second)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+
+	waitFor := func(condition func() bool) bool {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if condition() {
+				return true
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		return false
+	}
+
+	write("version: 1\nfields:\n  code: [required]\n")
+
+	var mu sync.Mutex
+	var watchErrors []error
+
+	w, err := WatchRuleSetFile(path, 5*time.Millisecond, func(err error) {
+		mu.Lock()
+		watchErrors = append(watchErrors, err)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("WatchRuleSetFile() error = %v", err)
+	}
+	defer w.Close()
+
+	payload := map[string]string{"code": "abc"}
+	if !w.Validate(payload).Valid {
+		t.Fatalf("Validate() expected the initial rules to pass")
+	}
+
+	// Validations keep running while the rules are swapped
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					w.Validate(payload)
+				}
+			}
+		}()
+	}
+
+	write("version: 1\nfields:\n  code: ['required|min:5']\n")
+
+	if !waitFor(func() bool { return !w.Validate(payload).Valid }) {
+		t.Errorf("WatchRuleSetFile() did not load the changed rules")
+	}
+
+	close(stop)
+	wg.Wait()
+
+	// A broken file keeps the previous rules and is reported
+	write("version: 1\nfields:\n  code: ['min:x']\n")
+
+	if !waitFor(func() bool { mu.Lock(); defer mu.Unlock(); return len(watchErrors) > 0 }) {
+		t.Errorf("WatchRuleSetFile() did not report the broken rules")
+	}
+
+	if w.Validate(payload).Valid || w.RuleSet().Rules("code").String() != "required|min:5" {
+		t.Errorf("WatchRuleSetFile() replaced the rules with a broken file")
+	}
+
+	// A file that failed to load is retried even if it is completed without changing its size or modification time
+	if err := os.WriteFile(path, []byte("version: 1\nfields:\n  code: ['min:9']\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rule set: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set modification time: %v", err)
+	}
+
+	if !waitFor(func() bool { return w.RuleSet().Rules("code").String() == "min:9" }) {
+		t.Errorf("WatchRuleSetFile() did not retry the file that failed to load")
+	}
+
+	w.Close()
+	w.Close()
+
+	if _, err := WatchRuleSetFile(filepath.Join(t.TempDir(), "missing.yaml"), time.Second, nil); err == nil {
+		t.Errorf("WatchRuleSetFile() expected an error for a missing file")
+	}
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := WatchRuleSetFile(path, interval, nil); err == nil {
+			t.Errorf("WatchRuleSetFile() expected an error for the interval %s", interval)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SchemaDialect is the JSON Schema dialect of the schemas produced by RulesSchema and StructSchema
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// A Schema is a JSON Schema 2020-12 document, limited to the keywords needed to describe strings and the structs holding them
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Comment     string `json:"$comment,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+
+	// String keywords
+	Format    string   `json:"format,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+	Const     *string  `json:"const,omitempty"`
+
+	// Applicators
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	// Object and array keywords
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+
+	// Annotations that are accepted and ignored by Options
+	Default    any   `json:"default,omitempty"`
+	Examples   []any `json:"examples,omitempty"`
+	Deprecated bool  `json:"deprecated,omitempty"`
+	ReadOnly   bool  `json:"readOnly,omitempty"`
+	WriteOnly  bool  `json:"writeOnly,omitempty"`
+
+	// The rules of a string, listed by OpenAPIComponents
+	Rules []SchemaRule `json:"x-strval-rules,omitempty"`
+}
+
+// A SchemaRule documents a strval rule in the x-strval-rules extension of OpenAPI schemas
+type SchemaRule struct {
+	// The rule in the syntax accepted by ParseRules, e.g. max:64,runes
+	Rule string `json:"rule"`
+	// The description of the rule, see DescribeRule
+	Description string `json:"description,omitempty"`
+}
+
+// The patterns describing the built-in rules; they use the syntax common to RE2 and ECMA-262
+const (
+	notEmptyPattern    = `[^\t\n\f\r ]`
+	alphaNumPattern    = `^[a-zA-Z0-9]+$`
+	numbersPattern     = `[0-9]`
+	upperPattern       = `[A-Z]`
+	lowerPattern       = `[a-z]`
+	printablePattern   = `^[\p{L}\p{M}\p{N}\p{P}\p{S} ]*$`
+	asciiPattern       = `^[\x00-\x7F]*$`
+	emailFormatPattern = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+)
+
+// Schema describes the rule with JSON Schema keywords
+// Returns false for rules that cannot be expressed in JSON Schema, such as not_disposable or password,
+// and for lengths in graphemes. JSON Schema measures lengths in code points, so a length in bytes
+// is described by the same number of code points, which only agrees with the rule for ASCII strings.
+func (r Rule) Schema() (*Schema, bool) {
+	schema := &Schema{}
+
+	intArg := func(i int) (*int, bool) {
+		if i >= len(r.Args) {
+			return nil, false
+		}
+		n, err := strconv.Atoi(r.Args[i])
+		return &n, err == nil && n >= 0
+	}
+
+	lengthUnit := func(i int) bool {
+		return i >= len(r.Args) || r.Args[i] == UnitBytes.String() || r.Args[i] == UnitRunes.String()
+	}
+
+	var ok bool
+	switch r.Name {
+	case "required", "notempty":
+		schema.Pattern = notEmptyPattern
+	case "alphanum":
+		schema.Pattern = alphaNumPattern
+	case "numbers":
+		schema.Pattern = numbersPattern
+	case "upper":
+		schema.Pattern = upperPattern
+	case "lower":
+		schema.Pattern = lowerPattern
+	case "printable":
+		schema.Pattern = printablePattern
+	case "ascii":
+		schema.Pattern = asciiPattern
+	case "email":
+		schema.Format = "email"
+		switch {
+		case len(r.Args) == 0:
+			schema.Pattern = emailFormatPattern
+		case r.Args[0] == EmailRFC6531.String():
+			schema.Format = "idn-email"
+		}
+	case "min":
+		if schema.MinLength, ok = intArg(0); !ok || !lengthUnit(1) {
+			return nil, false
+		}
+	case "max":
+		if schema.MaxLength, ok = intArg(0); !ok || !lengthUnit(1) {
+			return nil, false
+		}
+	case "between":
+		if schema.MinLength, ok = intArg(0); !ok || !lengthUnit(2) {
+			return nil, false
+		}
+		if schema.MaxLength, ok = intArg(1); !ok {
+			return nil, false
+		}
+	case "contains":
+		if len(r.Args) != 1 || r.Args[0] == "" {
+			return nil, false
+		}
+		schema.Pattern = "[" + escapeCharacterClass(r.Args[0]) + "]"
+	case "excludes":
+		if len(r.Args) != 1 || r.Args[0] == "" {
+			return nil, false
+		}
+		schema.Pattern = "^[^" + escapeCharacterClass(r.Args[0]) + "]*$"
+	case "oneof":
+		if len(r.Args) == 0 {
+			return nil, false
+		}
+		schema.Enum = append([]string(nil), r.Args...)
+	case "url":
+		schema.Format = "uri"
+	case "regex":
+		if len(r.Args) != 1 {
+			return nil, false
+		}
+		schema.Pattern = r.Args[0]
+	default:
+		return nil, false
+	}
+
+	return schema, true
+}
+
+// RulesSchema describes a rule chain as a JSON Schema for a string
+// Rules that cannot be expressed in JSON Schema are left out, see Rule.Schema
+func RulesSchema(rules Rules) *Schema {
+	schema := (&schemaBuilder{}).stringSchema(rules)
+	schema.Schema = SchemaDialect
+
+	return schema
+}
+
+// mergeSchema adds the keywords of a rule to a schema.
+// Length limits are tightened; keywords already in use are added as an allOf subschema.
+func mergeSchema(schema, rule *Schema) {
+	if rule.MinLength != nil {
+		if schema.MinLength == nil || *rule.MinLength > *schema.MinLength {
+			schema.MinLength = rule.MinLength
+		}
+	}
+
+	if rule.MaxLength != nil {
+		if schema.MaxLength == nil || *rule.MaxLength < *schema.MaxLength {
+			schema.MaxLength = rule.MaxLength
+		}
+	}
+
+	conflict := (rule.Pattern != "" && schema.Pattern != "") ||
+		(rule.Format != "" && schema.Format != "") ||
+		(rule.Enum != nil && schema.Enum != nil)
+
+	if conflict {
+		schema.AllOf = append(schema.AllOf, &Schema{Pattern: rule.Pattern, Format: rule.Format, Enum: rule.Enum})
+		return
+	}
+
+	if rule.Pattern != "" {
+		schema.Pattern = rule.Pattern
+	}
+	if rule.Format != "" {
+		schema.Format = rule.Format
+	}
+	if rule.Enum != nil {
+		schema.Enum = rule.Enum
+	}
+}
+
+// escapeCharacterClass escapes the characters that are special inside a regular expression character class
+func escapeCharacterClass(chars string) string {
+	var b strings.Builder
+	for _, char := range chars {
+		if strings.ContainsRune(`\]^-[`, char) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(char)
+	}
+
+	return b.String()
+}
+
+// StructSchema describes a struct and the strval rules of its fields as a JSON Schema for an object
+// v: The struct, or pointer to a struct, to describe; only its type is used
+// Returns the schema, or an error if v is not a struct or a tag is malformed
+//
+// Properties are named like the keys of ValidateStruct results and JSON encoding. Fields with a
+// required or notempty rule are listed as required. Fields of other basic kinds are described by their type.
+func StructSchema(v any) (*Schema, error) {
+	rt := reflect.TypeOf(v)
+	if rt == nil || indirectType(rt).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("strval: StructSchema expects a struct, got %T", v)
+	}
+
+	b := &schemaBuilder{visiting: map[reflect.Type]bool{}}
+	schema, err := b.typeSchema(indirectType(rt), nil)
+	if err != nil {
+		return nil, err
+	}
+	schema.Schema = SchemaDialect
+
+	return schema, nil
+}
+
+// This represents the state of StructSchema and OpenAPIComponents while they describe Go types
+type schemaBuilder struct {
+	// The struct types being described, to detect recursive types
+	visiting map[reflect.Type]bool
+	// When not nil, named struct types are described once as components and referenced with $ref,
+	// and string schemas list their rules in the x-strval-rules extension
+	components *OpenAPIComponents
+}
+
+// stringSchema describes a rule chain without the $schema keyword
+func (b *schemaBuilder) stringSchema(rules Rules) *Schema {
+	schema := &Schema{Type: "string"}
+
+	for _, rule := range rules {
+		if ruleSchema, ok := rule.Schema(); ok {
+			mergeSchema(schema, ruleSchema)
+		}
+
+		if b.components != nil {
+			schema.Rules = append(schema.Rules, SchemaRule{Rule: rule.String(), Description: rule.Description()})
+		}
+	}
+
+	return schema
+}
+
+// typeSchema describes a Go type; rules are the strval rules of the field holding it
+func (b *schemaBuilder) typeSchema(rt reflect.Type, rules Rules) (*Schema, error) {
+	rt = indirectType(rt)
+
+	switch rt.Kind() {
+	case reflect.String:
+		return b.stringSchema(rules), nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := b.typeSchema(rt.Elem(), rules)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		return &Schema{Type: "object"}, nil
+	case reflect.Struct:
+		if b.components != nil && rt.Name() != "" {
+			return b.components.ref(b, rt, "")
+		}
+		return b.structSchema(rt)
+	default:
+		return &Schema{}, nil
+	}
+}
+
+// structSchema describes the fields of a struct type
+func (b *schemaBuilder) structSchema(rt reflect.Type) (*Schema, error) {
+	if b.visiting[rt] {
+		return nil, fmt.Errorf("strval: StructSchema does not support the recursive type %s", rt)
+	}
+	b.visiting[rt] = true
+	defer delete(b.visiting, rt)
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if err := b.addStructProperties(schema, rt); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// addStructProperties adds the fields of a struct to an object schema, flattening embedded structs
+func (b *schemaBuilder) addStructProperties(schema *Schema, rt reflect.Type) error {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		embedded := field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct
+
+		if !field.IsExported() && !embedded {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup(tagName)
+		if tag == "-" || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		rules, err := parseTagRules(tag)
+		if err != nil {
+			return fmt.Errorf("strval: field %s: %w", field.Name, err)
+		}
+
+		if embedded && !hasTag {
+			// Embedded structs are flattened the same way ValidateStruct flattens them
+			if err := b.addStructProperties(schema, indirectType(field.Type)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		property, err := b.typeSchema(field.Type, rules)
+		if err != nil {
+			return err
+		}
+
+		name := fieldName(field)
+		schema.Properties[name] = property
+
+		for _, rule := range rules {
+			if rule.Name == "required" || rule.Name == "notempty" {
+				schema.Required = append(schema.Required, name)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseSchema parses a JSON Schema document, rejecting keywords that Options does not support
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("strval: schema: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// SchemaOptions builds the options validating the strings accepted by a JSON Schema string subschema, see Schema.Options
+func SchemaOptions(data []byte) ([]StringValidationOption, error) {
+	schema, err := ParseSchema(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.Options()
+}
+
+// Options builds the options validating the strings accepted by a string schema
+// Returns an error if the schema is not for strings or uses a keyword or format that cannot be checked
+//
+// minLength and maxLength count code points, as JSON Schema does. The email format is checked with
+// EmailRFC5322, idn-email with EmailRFC6531 and uri with MustBeAbsoluteURL. anyOf and not are supported;
+// annotations, including x-strval-rules, are ignored.
+func (s *Schema) Options() ([]StringValidationOption, error) {
+	if s.Type != "" && s.Type != "string" {
+		return nil, fmt.Errorf("strval: schema: expected type string, got %s", s.Type)
+	}
+
+	if s.Ref != "" {
+		return nil, fmt.Errorf("strval: schema: $ref %q cannot be resolved", s.Ref)
+	}
+
+	if s.Properties != nil || s.Required != nil || s.Items != nil {
+		return nil, errors.New("strval: schema: object and array keywords cannot apply to a string")
+	}
+
+	var options []StringValidationOption
+
+	if s.MinLength != nil {
+		options = append(options, MustHaveMinLengthOfIn(*s.MinLength, UnitRunes))
+	}
+
+	if s.MaxLength != nil {
+		options = append(options, MustHaveMaxLengthOfIn(*s.MaxLength, UnitRunes))
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("strval: schema: pattern %q: %w", s.Pattern, err)
+		}
+		options = append(options, MustMatchRegex(re))
+	}
+
+	switch s.Format {
+	case "":
+	case "email":
+		options = append(options, MustBeValidEmail(EmailRFC5322))
+	case "idn-email":
+		options = append(options, MustBeValidEmail(EmailRFC6531))
+	case "uri":
+		options = append(options, MustBeAbsoluteURL())
+	default:
+		return nil, fmt.Errorf("strval: schema: unsupported format %q", s.Format)
+	}
+
+	if s.Enum != nil {
+		options = append(options, MustBeOneOf(s.Enum...))
+	}
+
+	if s.Const != nil {
+		options = append(options, MustBeOneOf(*s.Const))
+	}
+
+	for _, sub := range s.AllOf {
+		subOptions, err := sub.Options()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, subOptions...)
+	}
+
+	if s.AnyOf != nil {
+		alternatives := make([]StringValidationOption, 0, len(s.AnyOf))
+		for _, sub := range s.AnyOf {
+			subOptions, err := sub.Options()
+			if err != nil {
+				return nil, err
+			}
+			alternatives = append(alternatives, AllOf(subOptions...))
+		}
+		options = append(options, AnyOf(alternatives...))
+	}
+
+	if s.Not != nil {
+		subOptions, err := s.Not.Options()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, Not(AllOf(subOptions...), ""))
+	}
+
+	return options, nil
+}
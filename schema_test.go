@@ -0,0 +1,326 @@
+package strval
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Tests Rule.Schema() (*Schema, bool)
+func TestRuleSchema(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		rule       string
+		expected   string
+		expectedOK bool
+	}{
+		{rule: "required", expected: `{"pattern":"[^\\t\\n\\f\\r ]"}`, expectedOK: true},
+		{rule: "alphanum", expected: `{"pattern":"^[a-zA-Z0-9]+$"}`, expectedOK: true},
+		{rule: "ascii", expected: `{"pattern":"^[\\x00-\\x7F]*$"}`, expectedOK: true},
+		{rule: "email", expected: `{"format":"email","pattern":"^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$"}`, expectedOK: true},
+		{rule: "email:rfc6531", expected: `{"format":"idn-email"}`, expectedOK: true},
+		{rule: "min:3", expected: `{"minLength":3}`, expectedOK: true},
+		{rule: "max:64,runes", expected: `{"maxLength":64}`, expectedOK: true},
+		{rule: "between:2,8", expected: `{"minLength":2,"maxLength":8}`, expectedOK: true},
+		{rule: `contains:!^\,-`, expected: `{"pattern":"[!\\^,\\-]"}`, expectedOK: true},
+		{rule: "excludes:<>", expected: `{"pattern":"^[^\u003c\u003e]*$"}`, expectedOK: true},
+		{rule: "oneof:red,green", expected: `{"enum":["red","green"]}`, expectedOK: true},
+		{rule: "regex:^[a-z]+$", expected: `{"pattern":"^[a-z]+$"}`, expectedOK: true},
+		{rule: "url", expected: `{"format":"uri"}`, expectedOK: true},
+		{rule: "max:8,graphemes", expectedOK: false},
+		{rule: "not_disposable", expectedOK: false},
+		{rule: "password", expectedOK: false},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rules, err := ParseRules(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRules(%q) error = %v", tt.rule, err)
+			}
+
+			schema, ok := rules[0].Schema()
+			if ok != tt.expectedOK {
+				t.Fatalf("Schema() ok = %v, want %v", ok, tt.expectedOK)
+			}
+
+			if !ok {
+				return
+			}
+
+			if got, _ := json.Marshal(schema); string(got) != tt.expected {
+				t.Errorf("Schema() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests RulesSchema(rules Rules) *Schema
+func TestRulesSchema(t *testing.T) {
+	rules, err := ParseRules("required|min:3|max:64|min:5|ascii|regex:^[a-z]+$|not_role|oneof:abcde,fghij")
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"string","minLength":5,"maxLength":64,` +
+		`"pattern":"[^\\t\\n\\f\\r ]","enum":["abcde","fghij"],"allOf":[{"pattern":"^[\\x00-\\x7F]*$"},{"pattern":"^[a-z]+$"}]}`
+
+	if got, _ := json.Marshal(RulesSchema(rules)); string(got) != expected {
+		t.Errorf("RulesSchema() = %s, want %s", got, expected)
+	}
+}
+
+// Tests that the patterns describing the built-in rules agree with the rules
+func TestRuleSchemaPatternsMatchRules(t *testing.T) {
+	samples := []string{"", " \t", "abc", "ABC", "abc123", "a b", "héllo", "tab\there", "\x00", "jane@example.com", "x@y", "<b>", "!"}
+
+	for _, name := range []string{"required", "alphanum", "numbers", "upper", "lower", "printable", "ascii", "email", "contains:!<", "excludes:<>"} {
+		rules, err := ParseRules(name)
+		if err != nil {
+			t.Fatalf("ParseRules(%q) error = %v", name, err)
+		}
+
+		schema, _ := rules[0].Schema()
+		re := regexp.MustCompile(schema.Pattern)
+		option, _ := rules[0].Option()
+
+		for _, sample := range samples {
+			if re.MatchString(sample) != (option(sample, "str") == nil) {
+				t.Errorf("rule %s and pattern %q disagree on %q", name, schema.Pattern, sample)
+			}
+		}
+	}
+}
+
+type schemaAddress struct {
+	Line1   string `json:"line1" strval:"notempty,max=100"`
+	Country string `json:"country" strval:"oneof=US"`
+}
+
+type schemaBase struct {
+	ID string `json:"id" strval:"alphanum"`
+}
+
+type schemaUser struct {
+	schemaBase
+	Email    string          `json:"email" strval:"required,email"`
+	Nickname *string         `json:"nickname,omitempty" strval:"max=32"`
+	Tags     []string        `json:"tags" strval:"lower"`
+	Address  schemaAddress   `json:"address"`
+	Previous []schemaAddress `json:"previous"`
+	Age      int             `json:"age"`
+	Admin    bool            `json:"-"`
+}
+
+// Tests StructSchema(v any) (*Schema, error)
+func TestStructSchema(t *testing.T) {
+	schema, err := StructSchema(&schemaUser{})
+	if err != nil {
+		t.Fatalf("StructSchema() error = %v", err)
+	}
+
+	got, _ := json.MarshalIndent(schema, "", "  ")
+
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "address": {
+      "type": "object",
+      "properties": {
+        "country": {
+          "type": "string",
+          "enum": [
+            "US"
+          ]
+        },
+        "line1": {
+          "type": "string",
+          "maxLength": 100,
+          "pattern": "[^\\t\\n\\f\\r ]"
+        }
+      },
+      "required": [
+        "line1"
+      ]
+    },
+    "age": {
+      "type": "integer"
+    },
+    "email": {
+      "type": "string",
+      "pattern": "[^\\t\\n\\f\\r ]",
+      "allOf": [
+        {
+          "format": "email",
+          "pattern": "^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$"
+        }
+      ]
+    },
+    "id": {
+      "type": "string",
+      "pattern": "^[a-zA-Z0-9]+$"
+    },
+    "nickname": {
+      "type": "string",
+      "maxLength": 32
+    },
+    "previous": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "country": {
+            "type": "string",
+            "enum": [
+              "US"
+            ]
+          },
+          "line1": {
+            "type": "string",
+            "maxLength": 100,
+            "pattern": "[^\\t\\n\\f\\r ]"
+          }
+        },
+        "required": [
+          "line1"
+        ]
+      }
+    },
+    "tags": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "pattern": "[a-z]"
+      }
+    }
+  },
+  "required": [
+    "email"
+  ]
+}`
+
+	if string(got) != expected {
+		t.Errorf("StructSchema() = %s\nwant %s", got, expected)
+	}
+
+	if _, err := StructSchema("not a struct"); err == nil {
+		t.Errorf("StructSchema() expected an error for a string")
+	}
+
+	type recursive struct {
+		Children []recursive `json:"children"`
+	}
+	if _, err := StructSchema(recursive{}); err == nil {
+		t.Errorf("StructSchema() expected an error for a recursive type")
+	}
+}
+
+// Tests SchemaOptions(data []byte) ([]StringValidationOption, error)
+func TestSchemaOptions(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		schema      string
+		valid       []string
+		invalid     []string
+		errExpected bool
+	}{
+		{
+			name:    "lengths count code points",
+			schema:  `{"type": "string", "minLength": 2, "maxLength": 3}`,
+			valid:   []string{"ab", "héé"},
+			invalid: []string{"a", "abcd"},
+		},
+		{
+			name:    "pattern and enum",
+			schema:  `{"pattern": "^[a-z]+$", "enum": ["red", "green", "Blue"], "title": "Color"}`,
+			valid:   []string{"red"},
+			invalid: []string{"Blue", "purple"},
+		},
+		{
+			name:    "email format",
+			schema:  `{"format": "email"}`,
+			valid:   []string{`"jane doe"@example.com`},
+			invalid: []string{"jane"},
+		},
+		{
+			name:    "uri format",
+			schema:  `{"format": "uri"}`,
+			valid:   []string{"https://example.com/a?b#c", "urn:isbn:0451450523"},
+			invalid: []string{"/relative", "https://exa mple.com"},
+		},
+		{
+			name:    "applicators",
+			schema:  `{"anyOf": [{"const": "none"}, {"minLength": 5}], "not": {"pattern": "^admin"}, "allOf": [{"maxLength": 8}]}`,
+			valid:   []string{"none", "abcde"},
+			invalid: []string{"abc", "administrator", "abcdefghi"},
+		},
+		{
+			name:        "not a string schema",
+			schema:      `{"type": "integer"}`,
+			errExpected: true,
+		},
+		{
+			name:        "unsupported keyword",
+			schema:      `{"oneOf": [{"minLength": 1}]}`,
+			errExpected: true,
+		},
+		{
+			name:        "unsupported format",
+			schema:      `{"format": "hostname"}`,
+			errExpected: true,
+		},
+		{
+			name:        "pattern RE2 cannot compile",
+			schema:      `{"pattern": "^(?!admin)"}`,
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := SchemaOptions([]byte(tt.schema))
+			if (err != nil) != tt.errExpected {
+				t.Fatalf("SchemaOptions() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			for _, str := range tt.valid {
+				if result := ValidateString(str, options...); !result.Valid {
+					t.Errorf("SchemaOptions() rejected %q: %v", str, result.Messages)
+				}
+			}
+
+			for _, str := range tt.invalid {
+				if result := ValidateString(str, options...); result.Valid {
+					t.Errorf("SchemaOptions() accepted %q", str)
+				}
+			}
+		})
+	}
+}
+
+// Tests that a rule chain exported with RulesSchema validates the same strings when imported with Schema.Options
+func TestRulesSchemaRoundTrip(t *testing.T) {
+	rules, err := ParseRules("required|between:3,10|lower|excludes:!|oneof:alpha,beta,gamma!,DELTA")
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	data, _ := json.Marshal(RulesSchema(rules))
+	imported, err := SchemaOptions(data)
+	if err != nil {
+		t.Fatalf("SchemaOptions(%s) error = %v", data, err)
+	}
+
+	original, _ := rules.Options()
+
+	for _, str := range []string{"", "alpha", "beta", "gamma!", "DELTA", "epsilon", strings.Repeat("a", 11)} {
+		if ValidateString(str, original...).Valid != ValidateString(str, imported...).Valid {
+			t.Errorf("exported schema %s disagrees with the rules on %q", data, str)
+		}
+	}
+}
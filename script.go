@@ -0,0 +1,241 @@
+package strval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// The augmented script sets of UTS #39, for writing systems that mix several scripts
+const (
+	ScriptHanWithBopomofo = "Han with Bopomofo"
+	ScriptJapanese        = "Japanese"
+	ScriptKorean          = "Korean"
+)
+
+// The script of unassigned code points
+const scriptUnknown = "Unknown"
+
+// A set of scripts, one bit per script of scriptData
+type scriptSet [4]uint64
+
+// A range of code points sharing a script
+type scriptRange struct {
+	lo, hi rune
+	script int
+}
+
+// scriptData holds the scripts of unicode.Scripts, the augmented scripts and Unknown, built on first use
+var scriptData struct {
+	once      sync.Once
+	names     []string
+	indexes   map[string]int
+	ranges    []scriptRange
+	augmented []scriptSet
+	common    int
+	inherited int
+	unknown   int
+}
+
+// This option will validate that the string is single-script as defined by UTS #39, section 5.1:
+// its letters are all of the same script, with characters shared by several scripts such as digits
+// and punctuation allowed everywhere. Japanese mixing Han, Hiragana and Katakana, and Korean mixing
+// Han and Hangul, are single-script. Use it to reject lookalike identifiers mixing Latin and Cyrillic.
+func MustNotBeMixedScript() StringValidationOption {
+	return func(str, strName string) error {
+		if resolved, seen := resolveScripts(str); resolved.isEmpty() {
+			return newFieldError(strName, str, RuleMixedScript, map[string]any{"scripts": strings.Join(seen.names(), ", ")})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the string is single-script, like MustNotBeMixedScript, and that its script
+// is one of the provided scripts. Scripts are named like the tables of unicode.Scripts, e.g. Latin or Cyrillic,
+// or with ScriptHanWithBopomofo, ScriptJapanese and ScriptKorean. When no scripts are provided any script is allowed.
+// MustBeSingleScript panics if a script name is unknown.
+func MustBeSingleScript(scripts ...string) StringValidationOption {
+	if len(scripts) == 0 {
+		return MustNotBeMixedScript()
+	}
+
+	allowed, err := scriptSetOf(scripts)
+	if err != nil {
+		panic("strval: MustBeSingleScript: " + err.Error())
+	}
+	names := strings.Join(scripts, ", ")
+
+	return func(str, strName string) error {
+		if resolved, _ := resolveScripts(str); resolved.intersect(allowed).isEmpty() {
+			return newFieldError(strName, str, RuleSingleScript, map[string]any{"scripts": names})
+		}
+
+		return nil
+	}
+}
+
+// scriptSetOf returns the set of named scripts
+func scriptSetOf(names []string) (scriptSet, error) {
+	loadScriptData()
+
+	var set scriptSet
+	for _, name := range names {
+		i, ok := scriptData.indexes[name]
+		if !ok || i == scriptData.common || i == scriptData.inherited {
+			return set, fmt.Errorf("unknown script %q", name)
+		}
+		set.add(i)
+	}
+
+	return set, nil
+}
+
+// resolveScripts returns the resolved script set of a string, the scripts all of its characters can belong to,
+// and the scripts of its characters. Characters of the Common and Inherited scripts belong to every script.
+func resolveScripts(str string) (resolved, seen scriptSet) {
+	loadScriptData()
+
+	for i := range resolved {
+		resolved[i] = ^uint64(0)
+	}
+
+	for _, r := range str {
+		script := scriptOf(r)
+		if script == scriptData.common || script == scriptData.inherited {
+			continue
+		}
+
+		seen.add(script)
+		resolved = resolved.intersect(scriptData.augmented[script])
+	}
+
+	return resolved, seen
+}
+
+// scriptOf returns the index of the script of a code point
+func scriptOf(r rune) int {
+	ranges := scriptData.ranges
+
+	lo, hi := 0, len(ranges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		switch {
+		case r < ranges[mid].lo:
+			hi = mid
+		case r > ranges[mid].hi:
+			lo = mid + 1
+		default:
+			return ranges[mid].script
+		}
+	}
+
+	return scriptData.unknown
+}
+
+// loadScriptData builds the script ranges from unicode.Scripts the first time it is called
+func loadScriptData() {
+	scriptData.once.Do(func() {
+		names := make([]string, 0, len(unicode.Scripts)+4)
+		for name := range unicode.Scripts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		names = append(names, ScriptHanWithBopomofo, ScriptJapanese, ScriptKorean, scriptUnknown)
+
+		if len(names) > len(scriptSet{})*64 {
+			panic("strval: too many scripts for scriptSet")
+		}
+
+		indexes := make(map[string]int, len(names))
+		for i, name := range names {
+			indexes[name] = i
+		}
+
+		var ranges []scriptRange
+		for name, table := range unicode.Scripts {
+			script := indexes[name]
+			for _, r := range table.R16 {
+				ranges = appendScriptRanges(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride), script)
+			}
+			for _, r := range table.R32 {
+				ranges = appendScriptRanges(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride), script)
+			}
+		}
+		sort.Slice(ranges, func(i, j int) bool {
+			return ranges[i].lo < ranges[j].lo
+		})
+
+		// Every script is its own augmented set, except the scripts that combine into a writing system
+		augmented := make([]scriptSet, len(names))
+		for i := range augmented {
+			augmented[i].add(i)
+		}
+		augment := func(script string, sets ...string) {
+			for _, set := range sets {
+				augmented[indexes[script]].add(indexes[set])
+			}
+		}
+		augment("Han", ScriptHanWithBopomofo, ScriptJapanese, ScriptKorean)
+		augment("Hiragana", ScriptJapanese)
+		augment("Katakana", ScriptJapanese)
+		augment("Hangul", ScriptKorean)
+		augment("Bopomofo", ScriptHanWithBopomofo)
+
+		scriptData.names = names
+		scriptData.indexes = indexes
+		scriptData.ranges = ranges
+		scriptData.augmented = augmented
+		scriptData.common = indexes["Common"]
+		scriptData.inherited = indexes["Inherited"]
+		scriptData.unknown = indexes[scriptUnknown]
+	})
+}
+
+// appendScriptRanges appends the code points of a range table entry, one range per code point when they are not contiguous
+func appendScriptRanges(ranges []scriptRange, lo, hi, stride rune, script int) []scriptRange {
+	if stride == 1 {
+		return append(ranges, scriptRange{lo, hi, script})
+	}
+
+	for r := lo; r <= hi; r += stride {
+		ranges = append(ranges, scriptRange{r, r, script})
+	}
+
+	return ranges
+}
+
+// add adds a script to the set
+func (s *scriptSet) add(script int) {
+	s[script/64] |= 1 << (script % 64)
+}
+
+// intersect returns the scripts of both sets
+func (s scriptSet) intersect(other scriptSet) scriptSet {
+	for i := range s {
+		s[i] &= other[i]
+	}
+
+	return s
+}
+
+// isEmpty reports whether the set has no script
+func (s scriptSet) isEmpty() bool {
+	return s == scriptSet{}
+}
+
+// names returns the names of the scripts of the set
+func (s scriptSet) names() []string {
+	var names []string
+
+	for i, name := range scriptData.names {
+		if s[i/64]&(1<<(i%64)) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
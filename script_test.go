@@ -0,0 +1,170 @@
+package strval
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests StringValidationOption MustNotBeMixedScript()
+func TestMustNotBeMixedScript(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		str             string
+		errExpected     bool
+		expectedScripts string
+	}{
+		{
+			name:        "latin",
+			str:         "paypal",
+			errExpected: false,
+		},
+		{
+			name:        "cyrillic",
+			str:         "пайпал",
+			errExpected: false,
+		},
+		{
+			name:            "latin and cyrillic",
+			str:             "p\u0430ypal",
+			errExpected:     true,
+			expectedScripts: "Cyrillic, Latin",
+		},
+		{
+			name:        "digits and punctuation",
+			str:         "user_42.dev",
+			errExpected: false,
+		},
+		{
+			name:        "only common characters",
+			str:         "2024-01-01",
+			errExpected: false,
+		},
+		{
+			name:        "combining marks",
+			str:         "Jose\u0301",
+			errExpected: false,
+		},
+		{
+			name:        "japanese",
+			str:         "東京とトウキョウ",
+			errExpected: false,
+		},
+		{
+			name:        "korean",
+			str:         "한국韓國",
+			errExpected: false,
+		},
+		{
+			name:            "hiragana and hangul",
+			str:             "ひ한",
+			errExpected:     true,
+			expectedScripts: "Hangul, Hiragana",
+		},
+		{
+			name:            "greek and latin",
+			str:             "αlpha",
+			errExpected:     true,
+			expectedScripts: "Greek, Latin",
+		},
+		{
+			name:        "empty",
+			str:         "",
+			errExpected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustNotBeMixedScript()(tt.str, "username")
+
+			if (err != nil) != tt.errExpected {
+				t.Fatalf("MustNotBeMixedScript() error = %v, wantErr %v", err, tt.errExpected)
+			}
+
+			var fieldErr *FieldError
+			if err != nil && (!errors.As(err, &fieldErr) || fieldErr.Rule != RuleMixedScript || fieldErr.Params["scripts"] != tt.expectedScripts) {
+				t.Errorf("MustNotBeMixedScript() error = %+v, want mixed_script error with scripts %q", err, tt.expectedScripts)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption MustBeSingleScript()
+func TestMustBeSingleScript(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		scripts     []string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "allowed script",
+			scripts:     []string{"Latin", "Cyrillic"},
+			str:         "пайпал",
+			errExpected: false,
+		},
+		{
+			name:        "other script",
+			scripts:     []string{"Latin", "Cyrillic"},
+			str:         "αβγ",
+			errExpected: true,
+		},
+		{
+			name:        "mixed allowed scripts",
+			scripts:     []string{"Latin", "Cyrillic"},
+			str:         "p\u0430ypal",
+			errExpected: true,
+		},
+		{
+			name:        "japanese",
+			scripts:     []string{ScriptJapanese},
+			str:         "東京タワー",
+			errExpected: false,
+		},
+		{
+			name:        "han alone is japanese",
+			scripts:     []string{ScriptJapanese},
+			str:         "東京",
+			errExpected: false,
+		},
+		{
+			name:        "hangul is not japanese",
+			scripts:     []string{ScriptJapanese},
+			str:         "한국",
+			errExpected: true,
+		},
+		{
+			name:        "no scripts allows any single script",
+			scripts:     nil,
+			str:         "αβγ",
+			errExpected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MustBeSingleScript(tt.scripts...)(tt.str, "username")
+			if (err != nil) != tt.errExpected {
+				t.Errorf("MustBeSingleScript(%v) error = %v, wantErr %v", tt.scripts, err, tt.errExpected)
+			}
+		})
+	}
+}
+
+// Tests that MustBeSingleScript panics for unknown scripts
+func TestMustBeSingleScriptUnknown(t *testing.T) {
+	for _, script := range []string{"Klingon", "Common", "Inherited"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MustBeSingleScript(%q) did not panic", script)
+				}
+			}()
+			MustBeSingleScript(script)
+		}()
+	}
+}
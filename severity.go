@@ -0,0 +1,164 @@
+package strval
+
+import (
+	"fmt"
+	"sort"
+)
+
+// This represents how serious a failed rule is
+// Only failures with SeverityError make a string invalid; warnings and infos are reported separately.
+type Severity int
+
+const (
+	// The string is invalid, the default severity
+	SeverityError Severity = iota
+	// The string is valid but should be improved, e.g. a weak password
+	SeverityWarning
+	// The string is valid, the failure is for information only
+	SeverityInfo
+)
+
+// String returns the name of the severity: error, warning or info
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < SeverityError || s > SeverityInfo {
+		return nil, fmt.Errorf("strval: unknown severity %d", int(s))
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity from its name
+func (s *Severity) UnmarshalText(text []byte) error {
+	for _, severity := range []Severity{SeverityError, SeverityWarning, SeverityInfo} {
+		if string(text) == severity.String() {
+			*s = severity
+			return nil
+		}
+	}
+
+	return fmt.Errorf("strval: unknown severity %q", text)
+}
+
+// This option will report the failures of the provided option with the given severity
+// A warning such as a weak password is listed in the Warnings of the result and does not make the string invalid.
+func WithSeverity(severity Severity, option StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		err := option(str, strName)
+		if err == nil {
+			return nil
+		}
+
+		fieldErr := asFieldError(err, strName, str)
+		fieldErr.Severity = severity
+
+		return fieldErr
+	}
+}
+
+// This option will stop the evaluation of the options that follow it when the provided option fails,
+// for prerequisites such as MustNotBeEmpty: an empty password then fails once instead of once per rule.
+// The evaluation continues when the failure is a warning or an info.
+func Bail(option StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		err := option(str, strName)
+		if err == nil {
+			return nil
+		}
+
+		fieldErr := asFieldError(err, strName, str)
+		fieldErr.bail = true
+
+		return fieldErr
+	}
+}
+
+// FailFast marks every provided option with Bail, so that the evaluation stops at the first failure
+//
+//	result := strval.ValidateStringWithName(password, "password", strval.FailFast(options...)...)
+func FailFast(options ...StringValidationOption) []StringValidationOption {
+	bailing := make([]StringValidationOption, len(options))
+	for i, option := range options {
+		bailing[i] = Bail(option)
+	}
+
+	return bailing
+}
+
+// This represents options evaluated at a priority, see WithPriority and Prioritize
+type PrioritizedOptions struct {
+	// Options with a higher priority are evaluated first
+	Priority int
+	// The options, evaluated in order
+	Options []StringValidationOption
+}
+
+// WithPriority groups options to be evaluated at the given priority by Prioritize
+func WithPriority(priority int, options ...StringValidationOption) PrioritizedOptions {
+	return PrioritizedOptions{Priority: priority, Options: options}
+}
+
+// Prioritize orders groups of options by priority, the highest first, so that a prerequisite marked with Bail
+// stops the evaluation wherever it is listed. Groups with the same priority keep their order, and so do the options of a group.
+//
+//	result := strval.ValidateStringWithName(password, "password", strval.Prioritize(
+//		strval.WithPriority(0, options...),
+//		strval.WithPriority(1, strval.Bail(strval.MustNotBeEmpty())),
+//	)...)
+func Prioritize(groups ...PrioritizedOptions) []StringValidationOption {
+	ordered := append([]PrioritizedOptions(nil), groups...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority > ordered[j].Priority
+	})
+
+	var options []StringValidationOption
+	for _, group := range ordered {
+		options = append(options, group.Options...)
+	}
+
+	return options
+}
+
+// stopsEvaluation reports whether a failure stops the evaluation of the options that follow
+func (e *FieldError) stopsEvaluation() bool {
+	return e.bail && e.Severity == SeverityError
+}
+
+// mostSeriousSeverity returns the most serious severity of a list of failures
+func mostSeriousSeverity(causes []*FieldError) Severity {
+	severity := SeverityInfo
+	for _, cause := range causes {
+		if cause.Severity < severity {
+			severity = cause.Severity
+		}
+	}
+
+	return severity
+}
+
+// add records a failure in the list of its severity
+func (r *StringValidationResult) add(fieldErr *FieldError) {
+	switch fieldErr.Severity {
+	case SeverityWarning:
+		r.Warnings = append(r.Warnings, fieldErr)
+	case SeverityInfo:
+		r.Infos = append(r.Infos, fieldErr)
+	default:
+		r.Messages = append(r.Messages, fieldErr.Message)
+		r.Errors = append(r.Errors, fieldErr)
+		r.Valid = false
+	}
+}
@@ -0,0 +1,249 @@
+package strval
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// Tests Bail, FailFast and WithSeverity with ValidateStringWithName()
+func TestValidateStringWithNameModes(t *testing.T) {
+	passwordOptions := []StringValidationOption{
+		MustNotBeEmpty(),
+		MustHaveMinLengthOf(8),
+		MustContainNumbers(),
+		MustContainUppercaseLetter(),
+	}
+
+	// Test cases
+	tests := []struct {
+		name             string
+		str              string
+		options          []StringValidationOption
+		expectedValid    bool
+		expectedErrors   []string
+		expectedWarnings []string
+		expectedInfos    []string
+	}{
+		{
+			name:           "every option runs by default",
+			str:            "",
+			options:        passwordOptions,
+			expectedValid:  false,
+			expectedErrors: []string{RuleNotEmpty, RuleMinLength, RuleContainsNumbers, RuleContainsUppercase},
+		},
+		{
+			name:           "bail on a prerequisite",
+			str:            "",
+			options:        append([]StringValidationOption{Bail(MustNotBeEmpty())}, passwordOptions[1:]...),
+			expectedValid:  false,
+			expectedErrors: []string{RuleNotEmpty},
+		},
+		{
+			name:           "bail on a satisfied prerequisite",
+			str:            "secret",
+			options:        append([]StringValidationOption{Bail(MustNotBeEmpty())}, passwordOptions[1:]...),
+			expectedValid:  false,
+			expectedErrors: []string{RuleMinLength, RuleContainsNumbers, RuleContainsUppercase},
+		},
+		{
+			name:           "fail fast",
+			str:            "secret",
+			options:        FailFast(passwordOptions...),
+			expectedValid:  false,
+			expectedErrors: []string{RuleMinLength},
+		},
+		{
+			name:          "fail fast on a valid string",
+			str:           "Secret123",
+			options:       FailFast(passwordOptions...),
+			expectedValid: true,
+		},
+		{
+			name: "higher priorities first",
+			str:  "",
+			options: Prioritize(
+				WithPriority(0, passwordOptions[1:]...),
+				WithPriority(1, Bail(MustNotBeEmpty())),
+			),
+			expectedValid:  false,
+			expectedErrors: []string{RuleNotEmpty},
+		},
+		{
+			name: "same priorities keep their order",
+			str:  "secret",
+			options: Prioritize(
+				WithPriority(0, MustContainUppercaseLetter()),
+				WithPriority(2, MustContainNumbers(), MustHaveMinLengthOf(8)),
+				WithPriority(0, MustContainAtLeastOne([]rune("!?"))),
+				WithPriority(2, MustNotBeEmpty()),
+			),
+			expectedValid:  false,
+			expectedErrors: []string{RuleContainsNumbers, RuleMinLength, RuleContainsUppercase, RuleContainsAtLeastOne},
+		},
+		{
+			name: "priorities inside all of",
+			str:  "",
+			options: []StringValidationOption{
+				AllOf(Prioritize(
+					WithPriority(-1, MustContainNumbers()),
+					WithPriority(0, Bail(MustNotBeEmpty())),
+				)...),
+			},
+			expectedValid:  false,
+			expectedErrors: []string{RuleNotEmpty},
+		},
+		{
+			name: "warnings do not make the string invalid",
+			str:  "secretpassword",
+			options: []StringValidationOption{
+				MustNotBeEmpty(),
+				WithSeverity(SeverityWarning, MustContainNumbers()),
+				WithSeverity(SeverityInfo, MustContainUppercaseLetter()),
+			},
+			expectedValid:    true,
+			expectedWarnings: []string{RuleContainsNumbers},
+			expectedInfos:    []string{RuleContainsUppercase},
+		},
+		{
+			name: "warnings do not stop a fail fast chain",
+			str:  "secret",
+			options: FailFast(
+				WithSeverity(SeverityWarning, MustContainNumbers()),
+				MustHaveMinLengthOf(8),
+				MustContainUppercaseLetter(),
+			),
+			expectedValid:    false,
+			expectedErrors:   []string{RuleMinLength},
+			expectedWarnings: []string{RuleContainsNumbers},
+		},
+		{
+			name: "bail inside all of",
+			str:  "",
+			options: []StringValidationOption{
+				AllOf(Bail(MustNotBeEmpty()), MustContainNumbers()),
+				MustContainUppercaseLetter(),
+			},
+			expectedValid:  false,
+			expectedErrors: []string{RuleNotEmpty, RuleContainsUppercase},
+		},
+		{
+			name: "all of with a warning and an error",
+			str:  "a",
+			options: []StringValidationOption{
+				AllOf(WithSeverity(SeverityWarning, MustContainNumbers()), MustHaveMinLengthOf(3)),
+			},
+			expectedValid:  false,
+			expectedErrors: []string{RuleAllOf},
+		},
+		{
+			name: "any of with a warning and an error",
+			str:  "a",
+			options: []StringValidationOption{
+				AnyOf(WithSeverity(SeverityWarning, MustContainNumbers()), MustHaveMinLengthOf(3)),
+			},
+			expectedValid:    true,
+			expectedWarnings: []string{RuleAnyOf},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateStringWithName(tt.str, "password", tt.options...)
+
+			if result.Valid != tt.expectedValid {
+				t.Errorf("Valid = %v, want %v", result.Valid, tt.expectedValid)
+			}
+			checkRules(t, "Errors", result.Errors, tt.expectedErrors)
+			checkRules(t, "Warnings", result.Warnings, tt.expectedWarnings)
+			checkRules(t, "Infos", result.Infos, tt.expectedInfos)
+
+			if len(result.Messages) != len(result.Errors) {
+				t.Errorf("%d messages for %d errors", len(result.Messages), len(result.Errors))
+			}
+		})
+	}
+}
+
+// checkRules checks the rules of a list of failures
+func checkRules(t *testing.T, list string, failures []*FieldError, expected []string) {
+	t.Helper()
+
+	if len(failures) != len(expected) {
+		t.Errorf("%s has %d failures, want %v", list, len(failures), expected)
+		return
+	}
+	for i, rule := range expected {
+		if failures[i].Rule != rule {
+			t.Errorf("%s[%d].Rule = %q, want %q", list, i, failures[i].Rule, rule)
+		}
+	}
+}
+
+// Tests that a failed prerequisite skips the ContextOption checks of ValidateStringContext()
+func TestValidateStringContextBail(t *testing.T) {
+	called := false
+	result, err := ValidateStringContext(context.Background(), "", "username",
+		Bail(MustNotBeEmpty()),
+		ContextOption(func(ctx context.Context, str, strName string) error {
+			called = true
+			return nil
+		}),
+	)
+
+	if err != nil {
+		t.Fatalf("ValidateStringContext() returned error: %v", err)
+	}
+	if called {
+		t.Error("a ContextOption ran after a failed prerequisite")
+	}
+	checkRules(t, "Errors", result.Errors, []string{RuleNotEmpty})
+}
+
+// Tests that LocalizeResult() renders warnings in the given locale
+func TestLocalizeResultWarnings(t *testing.T) {
+	result := ValidateStringWithName("abc", "Passwort", WithSeverity(SeverityWarning, MustContainNumbers()))
+	localized := LocalizeResult(result, "de", DefaultTranslator)
+
+	if !localized.Valid {
+		t.Error("a result with a warning only is invalid")
+	}
+	if len(localized.Warnings) != 1 || localized.Warnings[0].Message != "Passwort muss Ziffern enthalten" {
+		t.Errorf("Warnings = %+v, want the German message", localized.Warnings)
+	}
+}
+
+// Tests the JSON encoding of Severity
+func TestSeverityJSON(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		severity Severity
+		expected string
+	}{
+		{severity: SeverityError, expected: `{"field":"f","rule":"not_empty","value":"","message":""}`},
+		{severity: SeverityWarning, expected: `{"field":"f","rule":"not_empty","value":"","message":"","severity":"warning"}`},
+		{severity: SeverityInfo, expected: `{"field":"f","rule":"not_empty","value":"","message":"","severity":"info"}`},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.severity.String(), func(t *testing.T) {
+			data, err := json.Marshal(&FieldError{Field: "f", Rule: RuleNotEmpty, Severity: tt.severity})
+			if err != nil {
+				t.Fatalf("json.Marshal() returned error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.expected)
+			}
+
+			var decoded FieldError
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() returned error: %v", err)
+			}
+			if decoded.Severity != tt.severity {
+				t.Errorf("decoded Severity = %v, want %v", decoded.Severity, tt.severity)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package strval
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The name of the struct tag that holds validation rules
+const tagName = "strval"
+
+// This represents the result of a struct validation operation
+// The zero value is empty and becomes valid when the first field is added, like the result of ValidateStruct.
+type StructValidationResult struct {
+	Valid  bool
+	Fields map[string]StringValidationResult
+}
+
+// ValidateStruct validates the exported string fields of a struct against the rules declared in their strval tags
+// v: The struct, or pointer to a struct, to validate
+// Returns a StructValidationResult keyed by each field's JSON name, or an error if v is not a struct or a tag is malformed
+//
+// Rules are declared as a comma separated list, e.g. `strval:"notempty,min=3,max=64,email"`.
+// The rule names are those accepted by ParseRules, including registered ones, with at most one argument after =.
+// The tag is split on every comma, so an argument cannot contain one: use ParseRules, which accepts \, in arguments,
+// or the options themselves for such rules.
+// Nested structs, pointers and slices of strings are walked as well; nested fields are keyed
+// by their dotted path (address.line1) and slice elements by their index (tags[0]).
+// A tag of "-" excludes the field from validation, as does a json tag of "-" like in StructSchema.
+// A pointer back to a value being walked, as in a cyclic list, is not followed again.
+func ValidateStruct(v any) (StructValidationResult, error) {
+	result := StructValidationResult{
+		Valid:  true,
+		Fields: make(map[string]StringValidationResult),
+	}
+
+	w := &structWalker{result: &result, visiting: map[visitedPointer]bool{}}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return result, fmt.Errorf("strval: ValidateStruct called with a nil %s", rv.Type())
+		}
+		w.visiting[visitedPointer{rv.Type().Elem(), rv.Pointer()}] = true
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return result, fmt.Errorf("strval: ValidateStruct expects a struct, got %T", v)
+	}
+
+	if err := w.structValue(rv, ""); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// This represents the state of ValidateStruct while it walks a value
+type structWalker struct {
+	result *StructValidationResult
+	// The pointers followed on the current path, to detect cyclic values
+	visiting map[visitedPointer]bool
+}
+
+// A followed pointer; the type of the value it points to tells a struct from its first field
+type visitedPointer struct {
+	elem    reflect.Type
+	address uintptr
+}
+
+// structValue walks the fields of a struct value, recording the result of every tagged string
+func (w *structWalker) structValue(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		embedded := field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct
+
+		// Exported fields of embedded structs are promoted even when the embedded type is not
+		if !field.IsExported() && !embedded {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup(tagName)
+		if tag == "-" || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		name := fieldName(field)
+		if embedded && !hasTag {
+			// Embedded structs are flattened the same way encoding/json flattens them
+			name = ""
+		}
+
+		options, err := parseTag(tag)
+		if err != nil {
+			return fmt.Errorf("strval: field %s: %w", joinPath(prefix, field.Name), err)
+		}
+
+		if err := w.value(rv.Field(i), joinPath(prefix, name), options, hasTag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// value validates a single field value, descending into pointers, structs and slices
+func (w *structWalker) value(rv reflect.Value, path string, options []StringValidationOption, hasTag bool) error {
+	switch rv.Kind() {
+	case reflect.String:
+		if hasTag {
+			w.result.Add(path, ValidateStringWithName(rv.String(), path, options...))
+		}
+	case reflect.Pointer:
+		if rv.IsNil() {
+			// A missing string is validated as an empty one so that notempty still applies
+			if hasTag && rv.Type().Elem().Kind() == reflect.String {
+				w.result.Add(path, ValidateStringWithName("", path, options...))
+			}
+			return nil
+		}
+
+		// A value already being walked is validated under the path it was first reached by
+		visited := visitedPointer{rv.Type().Elem(), rv.Pointer()}
+		if w.visiting[visited] {
+			return nil
+		}
+		w.visiting[visited] = true
+		defer delete(w.visiting, visited)
+
+		return w.value(rv.Elem(), path, options, hasTag)
+	case reflect.Struct:
+		return w.structValue(rv, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := w.value(rv.Index(i), path+"["+strconv.Itoa(i)+"]", options, hasTag); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Add records the result of a single field, marking the struct invalid if the field is invalid
+func (r *StructValidationResult) Add(path string, fieldResult StringValidationResult) {
+	if r.Fields == nil {
+		r.Fields = make(map[string]StringValidationResult)
+		r.Valid = true
+	}
+
+	r.Fields[path] = fieldResult
+	if !fieldResult.Valid {
+		r.Valid = false
+	}
+}
+
+// AddStruct validates the fields of a value the way ValidateStruct does, recording their results under prefix
+// It lets the code generated by strvalgen validate the fields whose types are declared in other packages.
+// prefix: The path of the value, the empty string for the root
+// v: A struct, a pointer to one or a slice of them; other values have nothing to validate
+// Returns an error if a tag is malformed
+func (r *StructValidationResult) AddStruct(prefix string, v any) error {
+	w := &structWalker{result: r, visiting: map[visitedPointer]bool{}}
+	return w.value(reflect.ValueOf(v), prefix, nil, false)
+}
+
+// parseTag converts a strval tag into the options it declares
+func parseTag(tag string) ([]StringValidationOption, error) {
+	rules, err := parseTagRules(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules.Options()
+}
+
+// parseTagRules converts a strval tag into the rules it declares, building each rule to check its argument
+func parseTagRules(tag string) (Rules, error) {
+	var rules Rules
+
+	for _, token := range strings.Split(tag, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		// Tags use the rule names of RegisterRule, with a single argument after =
+		name, arg, hasArg := strings.Cut(token, "=")
+
+		factory, ok := lookupRule(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+
+		rule := Rule{Name: name}
+		if hasArg {
+			rule.Args = []string{arg}
+		}
+
+		option, err := factory(rule.Args)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", name, err)
+		}
+
+		rule.option = option
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// fieldName returns the JSON name of a struct field, falling back to the Go field name
+func fieldName(field reflect.StructField) string {
+	if jsonTag, ok := field.Tag.Lookup("json"); ok {
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// joinPath appends a field name to a dotted path
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	if name == "" {
+		return prefix
+	}
+	return prefix + "." + name
+}
+
+// indirectType strips any pointer indirection from a type
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
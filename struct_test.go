@@ -0,0 +1,235 @@
+package strval
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	Line1 string `json:"line1" strval:"notempty,max=32"`
+	Line2 string `json:"line2,omitempty" strval:"max=32"`
+}
+
+type testAudit struct {
+	CreatedBy string `json:"created_by" strval:"notempty"`
+}
+
+type testSignupRequest struct {
+	testAudit
+	Username string       `json:"username" strval:"notempty,min=3,max=16,alphanum"`
+	Email    string       `json:"email" strval:"email"`
+	Nickname *string      `json:"nickname" strval:"notempty"`
+	Address  *testAddress `json:"address"`
+	Tags     []string     `json:"tags" strval:"notempty,lower"`
+	Ignored  string       `json:"ignored" strval:"-"`
+	Secret   string       `json:"-" strval:"notempty"`
+	Untagged string       `json:"untagged"`
+	internal string       `strval:"notempty"`
+}
+
+// Test ValidateStruct(v any) (StructValidationResult, error)
+func TestValidateStruct(t *testing.T) {
+	nickname := "nick"
+
+	valid := testSignupRequest{
+		testAudit: testAudit{CreatedBy: "admin"},
+		Username:  "gopher",
+		Email:     "gopher@example.com",
+		Nickname:  &nickname,
+		Address:   &testAddress{Line1: "1 Main St"},
+		Tags:      []string{"go", "strval"},
+	}
+
+	invalid := testSignupRequest{
+		Username: "g!",
+		Email:    "not-an-email",
+		Address:  &testAddress{Line1: " "},
+		Tags:     []string{"go", "STRVAL"},
+	}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		value         any
+		expectValid   bool
+		invalidFields []string
+		validFields   []string
+	}{
+		{
+			name:        "valid struct",
+			value:       valid,
+			expectValid: true,
+			validFields: []string{"created_by", "username", "email", "nickname", "address.line1", "address.line2", "tags[0]", "tags[1]"},
+		},
+		{
+			name:        "pointer to valid struct",
+			value:       &valid,
+			expectValid: true,
+		},
+		{
+			name:          "invalid struct",
+			value:         invalid,
+			expectValid:   false,
+			invalidFields: []string{"created_by", "username", "email", "nickname", "address.line1", "tags[1]"},
+			validFields:   []string{"address.line2", "tags[0]"},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateStruct(tt.value)
+			if err != nil {
+				t.Fatalf("ValidateStruct() unexpected error = %v", err)
+			}
+
+			if result.Valid != tt.expectValid {
+				t.Errorf("ValidateStruct() Valid = %v, want %v", result.Valid, tt.expectValid)
+			}
+
+			for _, field := range tt.invalidFields {
+				fieldResult, ok := result.Fields[field]
+				if !ok || fieldResult.Valid {
+					t.Errorf("ValidateStruct() expected field %s to be invalid, got %+v", field, fieldResult)
+					continue
+				}
+
+				// Make sure the field name is in the error message
+				if !strings.Contains(fieldResult.Messages[0], field) {
+					t.Errorf("ValidateStruct() message = %v, expected to contain field name %v", fieldResult.Messages[0], field)
+				}
+			}
+
+			for _, field := range tt.validFields {
+				if fieldResult, ok := result.Fields[field]; !ok || !fieldResult.Valid {
+					t.Errorf("ValidateStruct() expected field %s to be valid, got %+v", field, fieldResult)
+				}
+			}
+
+			for _, field := range []string{"ignored", "Secret", "untagged", "internal"} {
+				if _, ok := result.Fields[field]; ok {
+					t.Errorf("ValidateStruct() expected field %s to be skipped", field)
+				}
+			}
+		})
+	}
+}
+
+// Test ValidateStruct(v any) (StructValidationResult, error) with unusable input
+func TestValidateStructErrors(t *testing.T) {
+	type unknownRule struct {
+		Name string `strval:"notempty,bogus"`
+	}
+
+	type badArgument struct {
+		Name string `strval:"min=abc"`
+	}
+
+	var nilPointer *testSignupRequest
+
+	// Test cases
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name:  "not a struct",
+			value: "string",
+		},
+		{
+			name:  "nil pointer",
+			value: nilPointer,
+		},
+		{
+			name:  "unknown rule",
+			value: unknownRule{},
+		},
+		{
+			name:  "bad argument",
+			value: badArgument{},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateStruct(tt.value); err == nil {
+				t.Errorf("ValidateStruct() expected an error")
+			}
+		})
+	}
+}
+
+// Test ValidateStruct(v any) (StructValidationResult, error) with cyclic values
+func TestValidateStructCycle(t *testing.T) {
+	type node struct {
+		Name string `json:"name" strval:"notempty"`
+		Next *node  `json:"next"`
+	}
+
+	loop := &node{Name: "a"}
+	loop.Next = loop
+
+	ring := &node{Name: "a", Next: &node{}}
+	ring.Next.Next = ring
+
+	shared := &node{Name: "leaf"}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		value          any
+		expectedFields []string
+	}{
+		{name: "self reference", value: loop, expectedFields: []string{"name"}},
+		{name: "ring", value: ring, expectedFields: []string{"name", "next.name"}},
+		{name: "ring passed by value", value: *ring, expectedFields: []string{"name", "next.name", "next.next.name"}},
+		{name: "shared pointer is not a cycle", value: node{Name: "a", Next: &node{Name: "b", Next: shared}}, expectedFields: []string{"name", "next.name", "next.next.name"}},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan StructValidationResult)
+			go func() {
+				result, err := ValidateStruct(tt.value)
+				if err != nil {
+					t.Errorf("ValidateStruct() unexpected error = %v", err)
+				}
+				done <- result
+			}()
+
+			var result StructValidationResult
+			select {
+			case result = <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("ValidateStruct() did not return")
+			}
+
+			if len(result.Fields) != len(tt.expectedFields) {
+				t.Errorf("ValidateStruct() fields = %v, want %v", result.Fields, tt.expectedFields)
+			}
+			for _, field := range tt.expectedFields {
+				if _, ok := result.Fields[field]; !ok {
+					t.Errorf("ValidateStruct() expected field %s", field)
+				}
+			}
+		})
+	}
+}
+
+// Test StructValidationResult.Add() on the zero value
+func TestStructValidationResultZeroValue(t *testing.T) {
+	var result StructValidationResult
+	result.Add("name", ValidateStringWithName("ok", "name", MustNotBeEmpty()))
+
+	if !result.Valid || len(result.Fields) != 1 {
+		t.Errorf("Add() = %+v, want a valid result with one field", result)
+	}
+
+	result.Add("email", ValidateStringWithName("", "email", MustNotBeEmpty()))
+	if result.Valid {
+		t.Errorf("Add() Valid = true after an invalid field, want false")
+	}
+}
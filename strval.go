@@ -1,19 +1,21 @@
 package strval
 
 import (
-	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
 // Strings
+// A StringValidationOption validates a string, given the string and its name.
+// The built-in options return a *FieldError describing the failed rule.
 type StringValidationOption func(string, string) error
 
 // This option will validate that the string is at least minLength characters long
 func MustHaveMinLengthOf(minLength int) StringValidationOption {
 	return func(str, strName string) error {
 		if !isWithMinLength(str, minLength) {
-			return fmt.Errorf("%s must have a minimum length of %d", strName, minLength)
+			return newFieldError(strName, str, RuleMinLength, map[string]any{"min": minLength})
 		}
 
 		return nil
@@ -24,7 +26,7 @@ func MustHaveMinLengthOf(minLength int) StringValidationOption {
 func MustHaveMaxLengthOf(maxLength int) StringValidationOption {
 	return func(str, strName string) error {
 		if !isWithMaxLength(str, maxLength) {
-			return fmt.Errorf("%s must have a maximum length of %d", strName, maxLength)
+			return newFieldError(strName, str, RuleMaxLength, map[string]any{"max": maxLength})
 		}
 
 		return nil
@@ -35,7 +37,7 @@ func MustHaveMaxLengthOf(maxLength int) StringValidationOption {
 func MustNotBeEmpty() StringValidationOption {
 	return func(str, strName string) error {
 		if isEmpty(str) {
-			return fmt.Errorf("%s must not be empty", strName)
+			return newFieldError(strName, str, RuleNotEmpty, nil)
 		}
 
 		return nil
@@ -43,10 +45,13 @@ func MustNotBeEmpty() StringValidationOption {
 }
 
 // This option will validate that the string is alphanumeric
+// The offending characters are listed in the Characters of the FieldError
 func MustBeAlphaNumeric() StringValidationOption {
 	return func(str, strName string) error {
 		if !isAlphaNumeric(str) {
-			return fmt.Errorf("%s must be alphanumeric", strName)
+			return withOffendingCharacters(newFieldError(strName, str, RuleAlphaNumeric, nil), func(r rune) bool {
+				return r > unicode.MaxASCII || !isASCIIDigit(byte(r)) && !isASCIIUpper(byte(r)) && !isASCIILower(byte(r))
+			})
 		}
 
 		return nil
@@ -57,7 +62,7 @@ func MustBeAlphaNumeric() StringValidationOption {
 func MustContainNumbers() StringValidationOption {
 	return func(str, strName string) error {
 		if !containsNumbers(str) {
-			return fmt.Errorf("%s must contain numbers", strName)
+			return newFieldError(strName, str, RuleContainsNumbers, nil)
 		}
 
 		return nil
@@ -68,7 +73,7 @@ func MustContainNumbers() StringValidationOption {
 func MustContainAtLeastOne(characters []rune) StringValidationOption {
 	return func(str, strName string) error {
 		if !containsAtLeastOneOf(str, characters) {
-			return fmt.Errorf("%s must contain at least one of the following characters: %s", strName, string(characters))
+			return newFieldError(strName, str, RuleContainsAtLeastOne, map[string]any{"characters": string(characters)})
 		}
 
 		return nil
@@ -76,10 +81,14 @@ func MustContainAtLeastOne(characters []rune) StringValidationOption {
 }
 
 // This option will validate that the string does not contain any of the disallowed characters
+// The offending characters are listed in the Characters of the FieldError
 func MustNotContainAnyOf(disallowedCharacters []rune) StringValidationOption {
 	return func(str, strName string) error {
 		if containsAny(str, disallowedCharacters) {
-			return fmt.Errorf("%s must not contain any of the following characters: %s", strName, string(disallowedCharacters))
+			fieldErr := newFieldError(strName, str, RuleNotContainAnyOf, map[string]any{"characters": string(disallowedCharacters)})
+			return withOffendingCharacters(fieldErr, func(r rune) bool {
+				return containsAny(string(r), disallowedCharacters)
+			})
 		}
 
 		return nil
@@ -90,7 +99,7 @@ func MustNotContainAnyOf(disallowedCharacters []rune) StringValidationOption {
 func MustContainUppercaseLetter() StringValidationOption {
 	return func(str, strName string) error {
 		if !containsUppercaseLetter(str) {
-			return fmt.Errorf("%s must contain at least one uppercase letter", strName)
+			return newFieldError(strName, str, RuleContainsUppercase, nil)
 		}
 
 		return nil
@@ -101,7 +110,7 @@ func MustContainUppercaseLetter() StringValidationOption {
 func MustContainLowercaseLetter() StringValidationOption {
 	return func(str, strName string) error {
 		if !containsLowercaseLetter(str) {
-			return fmt.Errorf("%s must contain at least one lowercase letter", strName)
+			return newFieldError(strName, str, RuleContainsLowercase, nil)
 		}
 
 		return nil
@@ -109,10 +118,13 @@ func MustContainLowercaseLetter() StringValidationOption {
 }
 
 // This option will validate that the string only contains printable characters
+// The offending characters are listed in the Characters of the FieldError
 func MustOnlyContainPrintableCharacters() StringValidationOption {
 	return func(str, strName string) error {
 		if containsNonPrintableCharacters(str) {
-			return fmt.Errorf("%s must only contain printable characters", strName)
+			return withOffendingCharacters(newFieldError(strName, str, RulePrintable, nil), func(r rune) bool {
+				return !unicode.IsPrint(r)
+			})
 		}
 
 		return nil
@@ -120,10 +132,13 @@ func MustOnlyContainPrintableCharacters() StringValidationOption {
 }
 
 // This option will validate that the string only contains ASCII characters
+// The offending characters are listed in the Characters of the FieldError
 func MustOnlyContainASCIICharacters() StringValidationOption {
 	return func(str, strName string) error {
 		if containsNonASCIICharacters(str) {
-			return fmt.Errorf("%s must only contain ASCII characters", strName)
+			return withOffendingCharacters(newFieldError(strName, str, RuleASCII, nil), func(r rune) bool {
+				return r > unicode.MaxASCII
+			})
 		}
 
 		return nil
@@ -134,7 +149,34 @@ func MustOnlyContainASCIICharacters() StringValidationOption {
 func MustBeValidEmailFormat() StringValidationOption {
 	return func(str, strName string) error {
 		if !isValidEmailFormat(str) {
-			return fmt.Errorf("%s must be a valid email format", strName)
+			return newFieldError(strName, str, RuleEmail, nil)
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the string is exactly one of the allowed values
+func MustBeOneOf(values ...string) StringValidationOption {
+	allowed := strings.Join(values, ", ")
+
+	return func(str, strName string) error {
+		for _, value := range values {
+			if str == value {
+				return nil
+			}
+		}
+
+		return newFieldError(strName, str, RuleOneOf, map[string]any{"values": allowed})
+	}
+}
+
+// This option will validate that the string matches a regular expression
+// The expression is not anchored; use ^ and $ to match the whole string
+func MustMatchRegex(re *regexp.Regexp) StringValidationOption {
+	return func(str, strName string) error {
+		if !re.MatchString(str) {
+			return newFieldError(strName, str, RuleRegex, map[string]any{"pattern": re.String()})
 		}
 
 		return nil
@@ -142,9 +184,14 @@ func MustBeValidEmailFormat() StringValidationOption {
 }
 
 // This represents the result of a string validation operation
+// Errors holds a FieldError for every message in Messages, in the same order.
+// Failures with SeverityWarning or SeverityInfo are listed in Warnings and Infos and do not make the string invalid.
 type StringValidationResult struct {
 	Valid    bool
 	Messages []string
+	Errors   []*FieldError
+	Warnings []*FieldError
+	Infos    []*FieldError
 }
 
 // ValidateStringWithName validates a string against the provided options
@@ -152,21 +199,22 @@ type StringValidationResult struct {
 // strName: The name of the string to validate (used in error messages)
 // options: The options to validate the string against
 // Returns a StringValidationResult
+// The evaluation stops after a failed option marked with Bail.
 func ValidateStringWithName(str, strName string, options ...StringValidationOption) StringValidationResult {
-	var messages []string
-	var isValid = true
+	result := StringValidationResult{Valid: true}
 
 	for _, option := range options {
 		if err := option(str, strName); err != nil {
-			messages = append(messages, err.Error())
-			isValid = false
+			fieldErr := asFieldError(err, strName, str)
+			result.add(fieldErr)
+
+			if fieldErr.stopsEvaluation() {
+				break
+			}
 		}
 	}
 
-	return StringValidationResult{
-		Valid:    isValid,
-		Messages: messages,
-	}
+	return result
 }
 
 // ValidateString validates a string against the provided options
@@ -179,8 +227,10 @@ func ValidateString(str string, options ...StringValidationOption) StringValidat
 
 // containsNumbers checks if a string contains a number
 func containsNumbers(password string) bool {
-	if matched, _ := regexp.MatchString(`[0-9]`, password); matched {
-		return true
+	for i := 0; i < len(password); i++ {
+		if isASCIIDigit(password[i]) {
+			return true
+		}
 	}
 	return false
 }
@@ -221,16 +271,20 @@ func containsAny(value string, query []rune) bool {
 
 // containsUppercaseLetter checks if a string contains an uppercase letter
 func containsUppercaseLetter(password string) bool {
-	if matched, _ := regexp.MatchString(`[A-Z]`, password); matched {
-		return true
+	for i := 0; i < len(password); i++ {
+		if isASCIIUpper(password[i]) {
+			return true
+		}
 	}
 	return false
 }
 
 // containsLowercaseLetter checks if a string contains a lowercase letter
 func containsLowercaseLetter(password string) bool {
-	if matched, _ := regexp.MatchString(`[a-z]`, password); matched {
-		return true
+	for i := 0; i < len(password); i++ {
+		if isASCIILower(password[i]) {
+			return true
+		}
 	}
 	return false
 }
@@ -257,38 +311,86 @@ func containsNonASCIICharacters(password string) bool {
 
 // IsAlphaNumeric checks if a string contains only alphanumeric characters
 func isAlphaNumeric(str string) bool {
-	// Regular expression pattern for alphanumeric validation
-	pattern := "^[a-zA-Z0-9]+$"
-
-	// Compile the regular expression pattern
-	regex := regexp.MustCompile(pattern)
+	if len(str) == 0 {
+		return false
+	}
 
-	// Check if the string matches the pattern
-	return regex.MatchString(str)
+	for i := 0; i < len(str); i++ {
+		if c := str[i]; !isASCIIDigit(c) && !isASCIIUpper(c) && !isASCIILower(c) {
+			return false
+		}
+	}
+	return true
 }
 
-// isValidEmailFormat checks if a string contains only alphanumeric characters and spaces
+// isValidEmailFormat checks if a string is in the format local@domain.tld
+// It is equivalent to matching ^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$ without the cost of a regular expression
 func isValidEmailFormat(email string) bool {
-	// Regular expression pattern for email validation
-	pattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+	at := strings.IndexByte(email, '@')
+	if at < 1 {
+		return false
+	}
+
+	for i := 0; i < at; i++ {
+		switch c := email[i]; {
+		case isASCIIDigit(c), isASCIIUpper(c), isASCIILower(c):
+		case c == '.', c == '_', c == '%', c == '+', c == '-':
+		default:
+			return false
+		}
+	}
 
-	// Compile the regular expression pattern
-	regex := regexp.MustCompile(pattern)
+	// The top level domain is everything after the last dot and must be at least two letters
+	domain := email[at+1:]
+	dot := strings.LastIndexByte(domain, '.')
+	if dot < 1 || len(domain)-dot-1 < 2 {
+		return false
+	}
 
-	// Check if the email matches the pattern
-	return regex.MatchString(email)
+	for i := 0; i < dot; i++ {
+		switch c := domain[i]; {
+		case isASCIIDigit(c), isASCIIUpper(c), isASCIILower(c):
+		case c == '.', c == '-':
+		default:
+			return false
+		}
+	}
+
+	for i := dot + 1; i < len(domain); i++ {
+		if c := domain[i]; !isASCIIUpper(c) && !isASCIILower(c) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // isEmpty checks to see if a string is empty or all whitespace
+// Whitespace is the ASCII set matched by \s: tab, newline, form feed, carriage return and space
 func isEmpty(str string) bool {
-	// Regular expression pattern for email validation
-	pattern := `^\s*$`
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '\t', '\n', '\f', '\r', ' ':
+		default:
+			return false
+		}
+	}
+	return true
+}
 
-	// Compile the regular expression pattern
-	regex := regexp.MustCompile(pattern)
+// isASCIIDigit checks if a byte is an ASCII digit
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isASCIIUpper checks if a byte is an ASCII uppercase letter
+func isASCIIUpper(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
 
-	// Check if the email matches the pattern
-	return regex.MatchString(str)
+// isASCIILower checks if a byte is an ASCII lowercase letter
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
 }
 
 // isWithMinLength checks to see if a string is at least minLength characters long
@@ -1,6 +1,7 @@
 package strval
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -755,6 +756,23 @@ func TestMustBeValidEmailFormat(t *testing.T) {
 	}
 }
 
+// Tests that isValidEmailFormat() accepts exactly what the original email pattern accepts
+func TestIsValidEmailFormatMatchesPattern(t *testing.T) {
+	pattern := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+	inputs := []string{
+		"", "@", "a@b", "a@b.c", "a@b.co", "a@.co", "@b.co", "a@b.c0", "a@b.co.", "a@b..co", "a@@b.co",
+		"a@b@c.co", "a b@c.co", "first.last+tag@sub.example.co.uk", "a%b_c-d@x-y.z1.abc", "a@b.co\n",
+		"a@-b.co", "a..b@c.co", "ä@b.co", "a@bä.co", "a@b.cä", "A@B.CO", "a@b.c-o", "a@1.22",
+	}
+
+	for _, input := range inputs {
+		if got, want := isValidEmailFormat(input), pattern.MatchString(input); got != want {
+			t.Errorf("isValidEmailFormat(%q) = %v, pattern match = %v", input, got, want)
+		}
+	}
+}
+
 // Test ValidateStringWithName(str, strName string, options ...StringValidationOption) StringValidationResult
 func TestValidateStringWithName(t *testing.T) {
 	// Test cases
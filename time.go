@@ -0,0 +1,44 @@
+package strval
+
+import "time"
+
+// This option will validate that the time is before t
+func MustBeBefore(t time.Time) Option[time.Time] {
+	return func(value time.Time, name string) error {
+		if !value.Before(t) {
+			return newFieldError(name, formatTime(value), RuleBefore, map[string]any{"time": formatTime(t)})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the time is after t
+func MustBeAfter(t time.Time) Option[time.Time] {
+	return func(value time.Time, name string) error {
+		if !value.After(t) {
+			return newFieldError(name, formatTime(value), RuleAfter, map[string]any{"time": formatTime(t)})
+		}
+
+		return nil
+	}
+}
+
+// This option will validate that the time is within start and end, both included
+func MustBeWithin(start, end time.Time) Option[time.Time] {
+	return func(value time.Time, name string) error {
+		if value.Before(start) || value.After(end) {
+			return newFieldError(name, formatTime(value), RuleTimeBetween, map[string]any{
+				"start": formatTime(start),
+				"end":   formatTime(end),
+			})
+		}
+
+		return nil
+	}
+}
+
+// formatTime formats a time for messages, in RFC 3339 format
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,68 @@
+package strval
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests the time options with Validate()
+func TestTimeOptions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	// Test cases
+	tests := []struct {
+		name            string
+		value           time.Time
+		option          Option[time.Time]
+		expectedMessage string
+	}{
+		{
+			name:   "before",
+			value:  start.Add(-time.Second),
+			option: MustBeBefore(start),
+		},
+		{
+			name:            "not before",
+			value:           start,
+			option:          MustBeBefore(start),
+			expectedMessage: "date must be before 2024-01-01T00:00:00Z",
+		},
+		{
+			name:   "after",
+			value:  end.Add(time.Hour),
+			option: MustBeAfter(end),
+		},
+		{
+			name:            "not after",
+			value:           start,
+			option:          MustBeAfter(end),
+			expectedMessage: "date must be after 2024-12-31T23:59:59Z",
+		},
+		{
+			name:   "within",
+			value:  start,
+			option: MustBeWithin(start, end),
+		},
+		{
+			name:            "not within",
+			value:           end.Add(time.Second),
+			option:          MustBeWithin(start, end),
+			expectedMessage: "date must be between 2024-01-01T00:00:00Z and 2024-12-31T23:59:59Z",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Validate(tt.value, "date", tt.option)
+
+			if result.Valid != (tt.expectedMessage == "") {
+				t.Fatalf("Valid = %v, want %v", result.Valid, tt.expectedMessage == "")
+			}
+			if tt.expectedMessage != "" && result.Messages[0] != tt.expectedMessage {
+				t.Errorf("Messages[0] = %q, want %q", result.Messages[0], tt.expectedMessage)
+			}
+		})
+	}
+}
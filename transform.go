@@ -0,0 +1,140 @@
+package strval
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A StringTransform returns a cleaned up version of a string, applied before the string is validated
+// The built-in transforms return the string itself when there is nothing to clean up.
+type StringTransform func(string) string
+
+// Trim returns a transform removing leading and trailing whitespace
+func Trim() StringTransform {
+	return strings.TrimSpace
+}
+
+// CollapseWhitespace returns a transform replacing every run of whitespace, including tabs and line breaks,
+// with a single space. Combine it with Trim to also remove leading and trailing whitespace.
+func CollapseWhitespace() StringTransform {
+	return collapseWhitespace
+}
+
+// ToLower returns a transform mapping every letter to lower case
+func ToLower() StringTransform {
+	return strings.ToLower
+}
+
+// NormalizeNFC returns a transform applying Unicode Normalization Form C, so that a character that can be
+// written precomposed or with combining marks is always stored the same way
+func NormalizeNFC() StringTransform {
+	return NFC
+}
+
+// NormalizeNFKC returns a transform applying Unicode Normalization Form KC, which also replaces compatibility
+// characters such as full-width letters, ligatures and superscripts with their plain equivalents
+func NormalizeNFKC() StringTransform {
+	return NFKC
+}
+
+// StripControlChars returns a transform removing control characters (Unicode category Cc),
+// except tabs and line breaks (\t, \n and \r)
+func StripControlChars() StringTransform {
+	return func(str string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+				return -1
+			}
+			return r
+		}, str)
+	}
+}
+
+// RemoveZeroWidth returns a transform removing invisible zero width characters: U+200B ZERO WIDTH SPACE,
+// U+200C ZERO WIDTH NON-JOINER, U+200D ZERO WIDTH JOINER, U+2060 WORD JOINER and U+FEFF ZERO WIDTH NO-BREAK SPACE.
+// Emoji sequences joined with U+200D, such as family emoji, are split into their parts.
+func RemoveZeroWidth() StringTransform {
+	return func(str string) string {
+		return strings.Map(func(r rune) rune {
+			switch r {
+			case '\u200B', '\u200C', '\u200D', '\u2060', '\uFEFF':
+				return -1
+			}
+			return r
+		}, str)
+	}
+}
+
+// ChainTransforms returns a transform applying the provided transforms in order
+func ChainTransforms(transforms ...StringTransform) StringTransform {
+	return func(str string) string {
+		return applyTransforms(str, transforms)
+	}
+}
+
+// This option will validate the string with the provided options after applying the transform
+// Use it to normalize a single rule, for example to compare case-insensitively with MustBeOneOf.
+// FieldErrors report the transformed value.
+func Transformed(transform StringTransform, options ...StringValidationOption) StringValidationOption {
+	return func(str, strName string) error {
+		return allOf(transform(str), strName, options)
+	}
+}
+
+// ValidateAndNormalize applies transforms to a string, then validates the result against the provided options
+// str: The string to clean up and validate
+// strName: The name of the string to validate (used in error messages)
+// transforms: The transforms to apply, in order
+// options: The options to validate the transformed string against
+// Returns the transformed string, which is the string that was validated, and a StringValidationResult
+func ValidateAndNormalize(str, strName string, transforms []StringTransform, options ...StringValidationOption) (string, StringValidationResult) {
+	str = applyTransforms(str, transforms)
+
+	return str, ValidateStringWithName(str, strName, options...)
+}
+
+// applyTransforms applies transforms to a string in order
+func applyTransforms(str string, transforms []StringTransform) string {
+	for _, transform := range transforms {
+		str = transform(str)
+	}
+
+	return str
+}
+
+// collapseWhitespace replaces every run of whitespace with a single space
+func collapseWhitespace(str string) string {
+	inSpace := false
+
+	for i, r := range str {
+		isSpace := unicode.IsSpace(r)
+		if isSpace && (inSpace || r != ' ') {
+			return collapseWhitespaceFrom(str, i, inSpace)
+		}
+		inSpace = isSpace
+	}
+
+	return str
+}
+
+// collapseWhitespaceFrom collapses the whitespace of a string from the first byte that must change
+func collapseWhitespaceFrom(str string, start int, inSpace bool) string {
+	var b strings.Builder
+	b.Grow(len(str))
+	b.WriteString(str[:start])
+
+	for _, r := range str[start:] {
+		if !unicode.IsSpace(r) {
+			b.WriteRune(r)
+			inSpace = false
+			continue
+		}
+
+		if !inSpace {
+			b.WriteByte(' ')
+			inSpace = true
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,174 @@
+package strval
+
+import "testing"
+
+// Tests the built-in StringTransform values
+func TestTransforms(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		transform StringTransform
+		str       string
+		expected  string
+	}{
+		{
+			name:      "trim",
+			transform: Trim(),
+			str:       " \t user@example.com \n",
+			expected:  "user@example.com",
+		},
+		{
+			name:      "collapse whitespace",
+			transform: CollapseWhitespace(),
+			str:       " Jane \t\n  Doe ",
+			expected:  " Jane Doe ",
+		},
+		{
+			name:      "collapse single spaces",
+			transform: CollapseWhitespace(),
+			str:       "Jane Doe",
+			expected:  "Jane Doe",
+		},
+		{
+			name:      "collapse unicode whitespace",
+			transform: CollapseWhitespace(),
+			str:       "Jane\u00A0\u2003Doe",
+			expected:  "Jane Doe",
+		},
+		{
+			name:      "to lower",
+			transform: ToLower(),
+			str:       "JaneDoe \u00C9COLE",
+			expected:  "janedoe \u00E9cole",
+		},
+		{
+			name:      "nfc",
+			transform: NormalizeNFC(),
+			str:       "e\u0301",
+			expected:  "\u00E9",
+		},
+		{
+			name:      "nfkc",
+			transform: NormalizeNFKC(),
+			str:       "\uFF55\uFF53\uFF45\uFF52",
+			expected:  "user",
+		},
+		{
+			name:      "strip control chars",
+			transform: StripControlChars(),
+			str:       "a\x00b\x1bc\u0085d\te\r\n",
+			expected:  "abcd\te\r\n",
+		},
+		{
+			name:      "remove zero width",
+			transform: RemoveZeroWidth(),
+			str:       "\uFEFFad\u200Bmin\u2060",
+			expected:  "admin",
+		},
+		{
+			name:      "chain",
+			transform: ChainTransforms(Trim(), CollapseWhitespace(), ToLower()),
+			str:       "  Jane   DOE ",
+			expected:  "jane doe",
+		},
+		{
+			name:      "empty chain",
+			transform: ChainTransforms(),
+			str:       " a ",
+			expected:  " a ",
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transform(tt.str); got != tt.expected {
+				t.Errorf("transform(%+q) = %+q, want %+q", tt.str, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Tests StringValidationOption Transformed()
+func TestTransformed(t *testing.T) {
+	option := Transformed(ToLower(), MustBeOneOf("web", "mobile"))
+
+	// Test cases
+	tests := []struct {
+		name        string
+		str         string
+		errExpected bool
+	}{
+		{
+			name:        "matches after lowering",
+			str:         "Web",
+			errExpected: false,
+		},
+		{
+			name:        "no match",
+			str:         "desktop",
+			errExpected: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := option(tt.str, "client")
+			if (err != nil) != tt.errExpected {
+				t.Errorf("Transformed() error = %v, wantErr %v", err, tt.errExpected)
+			}
+		})
+	}
+}
+
+// Tests ValidateAndNormalize(str, strName string, transforms []StringTransform, options ...StringValidationOption) (string, StringValidationResult)
+func TestValidateAndNormalize(t *testing.T) {
+	transforms := []StringTransform{RemoveZeroWidth(), Trim(), ToLower()}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		str           string
+		expected      string
+		validExpected bool
+	}{
+		{
+			name:          "valid after cleaning up",
+			str:           "  User@Example.com\u200B ",
+			expected:      "user@example.com",
+			validExpected: true,
+		},
+		{
+			name:          "invalid",
+			str:           " not an email ",
+			expected:      "not an email",
+			validExpected: false,
+		},
+		{
+			name:          "empty after cleaning up",
+			str:           " \u200B ",
+			expected:      "",
+			validExpected: false,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, result := ValidateAndNormalize(tt.str, "email", transforms, MustNotBeEmpty(), MustBeValidEmailFormat())
+
+			if normalized != tt.expected {
+				t.Errorf("ValidateAndNormalize() string = %q, want %q", normalized, tt.expected)
+			}
+			if result.Valid != tt.validExpected {
+				t.Errorf("ValidateAndNormalize() valid = %v, want %v: %v", result.Valid, tt.validExpected, result.Messages)
+			}
+			for _, fieldErr := range result.Errors {
+				if fieldErr.Value != normalized {
+					t.Errorf("FieldError value = %q, want the normalized string %q", fieldErr.Value, normalized)
+				}
+			}
+		})
+	}
+}